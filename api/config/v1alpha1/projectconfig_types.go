@@ -25,6 +25,12 @@ const (
 
 	// EnvRelatedImageOauthProxy contains the name of the environment variable where the oauth-proxy image location is stored.
 	EnvRelatedImageOauthProxy = "RELATED_IMAGE_OAUTH_PROXY"
+
+	// EnvRelatedImageMemcached contains the name of the environment variable where the memcached image location is stored.
+	EnvRelatedImageMemcached = "RELATED_IMAGE_MEMCACHED"
+
+	// EnvRelatedImageRclone contains the name of the environment variable where the rclone image location is stored.
+	EnvRelatedImageRclone = "RELATED_IMAGE_RCLONE"
 )
 
 // ImagesSpec defines the image for each container.
@@ -58,6 +64,16 @@ type ImagesSpec struct {
 	//
 	// +optional
 	OauthProxy string `json:"oauthProxy,omitempty"`
+
+	// Memcached defines the memcached image used by the operator-managed cache.
+	//
+	// +optional
+	Memcached string `json:"memcached,omitempty"`
+
+	// Rclone defines the rclone image used to copy the objects of a storage migration.
+	//
+	// +optional
+	Rclone string `json:"rclone,omitempty"`
 }
 
 // BuiltInCertManagement is the configuration for the built-in facility to generate and rotate
@@ -221,6 +237,16 @@ type FeatureGates struct {
 	// GrafanaOperator defines whether the Grafana Operator CRD exists in the cluster.
 	// This CRD is part of grafana-operator.
 	GrafanaOperator bool `json:"grafanaOperator,omitempty"`
+
+	// GatewayAPI defines whether the Kubernetes Gateway API CRDs (HTTPRoute and GRPCRoute) exist in the cluster.
+	// More details: https://gateway-api.sigs.k8s.io
+	GatewayAPI bool `json:"gatewayAPI,omitempty"`
+
+	// StoragePreflight enables a preflight check of the object storage of TempoStack instances.
+	// Before rolling out a new or changed storage configuration, the operator lists, writes and deletes a probe object
+	// with the storage credentials and TLS settings, and reports the result in the StorageReady condition.
+	// The operator requires network access to the object storage.
+	StoragePreflight bool `json:"storagePreflight,omitempty"`
 }
 
 // ControllerManagerConfigurationSpec defines the desired state of GenericControllerManagerConfiguration.
@@ -319,6 +345,8 @@ func DefaultProjectConfig() ProjectConfig {
 			TempoGateway:    os.Getenv(EnvRelatedImageTempoGateway),
 			TempoGatewayOpa: os.Getenv(EnvRelatedImageTempoGatewayOpa),
 			OauthProxy:      os.Getenv(EnvRelatedImageOauthProxy),
+			Memcached:       os.Getenv(EnvRelatedImageMemcached),
+			Rclone:          os.Getenv(EnvRelatedImageRclone),
 		},
 		Gates: FeatureGates{
 			TLSProfile: string(TLSProfileModernType),
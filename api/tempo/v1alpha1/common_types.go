@@ -2,7 +2,9 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // PodStatusMap defines the type for mapping pod status to pod name.
@@ -91,3 +93,324 @@ const (
 	// a secret. This mode is only supported for certain object storage types in certain runtime environments.
 	CredentialModeTokenCCO CredentialMode = "token-cco"
 )
+
+// NetworkPolicySpec defines the NetworkPolicies created by the operator.
+// When enabled, only the traffic between the Tempo pods on the ports used by Tempo and the traffic to the
+// receivers, query APIs and metrics endpoints from the allowed peers is permitted.
+// The egress of the Tempo pods is restricted to DNS, the Kubernetes API, the object storage,
+// Kafka, the caches and the remote-write endpoints.
+type NetworkPolicySpec struct {
+	// Enabled defines if NetworkPolicies are created.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",order=1,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// Ingestion defines additional peers (e.g. namespaces of OpenTelemetry Collectors)
+	// which are allowed to send traces to the receivers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingestion Peers",order=2
+	Ingestion []networkingv1.NetworkPolicyPeer `json:"ingestion,omitempty"`
+
+	// Query defines additional peers (e.g. namespaces of Grafana or the ingress controller)
+	// which are allowed to reach the query APIs and the Jaeger UI.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Peers",order=3
+	Query []networkingv1.NetworkPolicyPeer `json:"query,omitempty"`
+
+	// Metrics defines additional peers (e.g. the namespace of Prometheus)
+	// which are allowed to scrape the metrics endpoints.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Peers",order=4
+	Metrics []networkingv1.NetworkPolicyPeer `json:"metrics,omitempty"`
+
+	// AllowSameNamespace defines if all pods of the namespace are allowed to reach
+	// the receivers, query APIs and metrics endpoints, in addition to the configured peers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Allow Same Namespace",order=5,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	AllowSameNamespace bool `json:"allowSameNamespace,omitempty"`
+
+	// Egress defines additional egress rules of the Tempo pods,
+	// for example to reach an OIDC issuer or a memcached server on a non-default port.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Additional Egress Rules",order=6
+	Egress []networkingv1.NetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// StorageTuningSpec defines the tuning of the trace storage.
+type StorageTuningSpec struct {
+	// BlocklistPoll defines how often the blocklist is polled from the object storage.
+	// It must be shorter than the global retention and the ingester complete block timeout (15m).
+	// Default: 5m.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Blocklist Poll Interval"
+	BlocklistPoll *metav1.Duration `json:"blocklistPoll,omitempty"`
+
+	// Block defines the format of the trace blocks.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Block"
+	Block *BlockTuningSpec `json:"block,omitempty"`
+
+	// Compaction defines how the blocks are compacted.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Compaction"
+	Compaction *CompactionTuningSpec `json:"compaction,omitempty"`
+
+	// WAL defines the Write-Ahead Log (WAL) of the ingester.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="WAL"
+	WAL *WALTuningSpec `json:"wal,omitempty"`
+}
+
+// BlockVersion defines the format of the trace blocks.
+//
+// +kubebuilder:validation:Enum=vParquet3;vParquet4
+type BlockVersion string
+
+const (
+	// BlockVersionParquet3 defines the vParquet3 block format.
+	BlockVersionParquet3 BlockVersion = "vParquet3"
+	// BlockVersionParquet4 defines the vParquet4 block format.
+	BlockVersionParquet4 BlockVersion = "vParquet4"
+)
+
+// DedicatedColumnScope defines the scope of a dedicated attribute column.
+//
+// +kubebuilder:validation:Enum=resource;span
+type DedicatedColumnScope string
+
+const (
+	// DedicatedColumnScopeResource defines a dedicated column for a resource attribute.
+	DedicatedColumnScopeResource DedicatedColumnScope = "resource"
+	// DedicatedColumnScopeSpan defines a dedicated column for a span attribute.
+	DedicatedColumnScopeSpan DedicatedColumnScope = "span"
+)
+
+// DedicatedColumnType defines the type of a dedicated attribute column.
+//
+// +kubebuilder:validation:Enum=string
+type DedicatedColumnType string
+
+const (
+	// DedicatedColumnTypeString defines a dedicated column for string attributes.
+	DedicatedColumnTypeString DedicatedColumnType = "string"
+)
+
+// DedicatedColumn defines an attribute which is stored in a dedicated column of the parquet blocks.
+type DedicatedColumn struct {
+	// Scope defines whether the attribute is a resource or a span attribute.
+	//
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scope"
+	Scope DedicatedColumnScope `json:"scope"`
+
+	// Name defines the name of the attribute.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
+
+	// Type defines the type of the attribute.
+	// Default: string.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=string
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Type"
+	Type DedicatedColumnType `json:"type,omitempty"`
+}
+
+// BlockTuningSpec defines the format of the trace blocks.
+type BlockTuningSpec struct {
+	// Version defines the parquet block format used to write new blocks.
+	// Existing blocks are still readable after changing the version.
+	// Default: the default block format of Tempo.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:vParquet3","urn:alm:descriptor:com.tectonic.ui:select:vParquet4"}
+	Version BlockVersion `json:"version,omitempty"`
+
+	// BloomFilterFalsePositive defines the false positive rate of the bloom filters, e.g. 0.01.
+	// The value must be greater than 0 and less than 1.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Bloom Filter False Positive Rate"
+	BloomFilterFalsePositive string `json:"bloomFilterFalsePositive,omitempty"`
+
+	// BloomFilterShardSizeBytes defines the maximum size (bytes) of a bloom filter shard.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:number",displayName="Bloom Filter Shard Size in Bytes"
+	BloomFilterShardSizeBytes *int `json:"bloomFilterShardSizeBytes,omitempty"`
+
+	// MaxDuration defines the maximum time the ingester keeps a block open before flushing it.
+	// Default: 10m.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Block Duration"
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+}
+
+// CompactionTuningSpec defines how the blocks are compacted.
+type CompactionTuningSpec struct {
+	// Window defines the time range of the blocks which are compacted together.
+	// Default: 1h.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Compaction Window"
+	Window *metav1.Duration `json:"window,omitempty"`
+
+	// MaxBlockBytes defines the maximum size (bytes) of a compacted block.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:number",displayName="Max Block Size in Bytes"
+	MaxBlockBytes *int `json:"maxBlockBytes,omitempty"`
+}
+
+// WALEncoding defines the compression of the Write-Ahead Log.
+//
+// +kubebuilder:validation:Enum=none;snappy;lz4;zstd;s2
+type WALEncoding string
+
+// WALTuningSpec defines the Write-Ahead Log (WAL) of the ingester.
+type WALTuningSpec struct {
+	// Encoding defines the compression of the WAL.
+	// Default: the default encoding of Tempo.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Encoding",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:none","urn:alm:descriptor:com.tectonic.ui:select:snappy","urn:alm:descriptor:com.tectonic.ui:select:lz4","urn:alm:descriptor:com.tectonic.ui:select:zstd","urn:alm:descriptor:com.tectonic.ui:select:s2"}
+	Encoding WALEncoding `json:"encoding,omitempty"`
+}
+
+// UpgradeStatus shows the upgrade steps of the last upgrade of the CR to a new operator version.
+type UpgradeStatus struct {
+	// FromVersion is the operator version of the CR before the upgrade.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	FromVersion string `json:"fromVersion,omitempty"`
+
+	// ToVersion is the operator version of the CR after the upgrade.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	ToVersion string `json:"toVersion,omitempty"`
+
+	// Steps lists the versions of the upgrade steps which were applied to the CR.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Steps []string `json:"steps,omitempty"`
+
+	// FailedStep is the version of the upgrade step which failed.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	FailedStep string `json:"failedStep,omitempty"`
+
+	// Message describes why the upgrade failed.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// SpecDiff is the unified diff of the spec of the CR before and after the upgrade.
+	// Long diffs are truncated, the upgrade --dry-run command shows the full diff.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	SpecDiff string `json:"specDiff,omitempty"`
+}
+
+// ApproveUpgradeAnnotation approves the upgrade of a CR with the manual upgrade policy.
+// The value of the annotation must be the operator version of the upgrade.
+const ApproveUpgradeAnnotation = "tempo.grafana.com/approve-upgrade"
+
+// UpgradePolicyMode defines when a CR is upgraded to a new operator version.
+//
+// +kubebuilder:validation:Enum=auto;manual;window
+type UpgradePolicyMode string
+
+const (
+	// UpgradePolicyModeAuto upgrades the CR right after the operator is updated.
+	UpgradePolicyModeAuto UpgradePolicyMode = "auto"
+	// UpgradePolicyModeManual holds the CR at the current operator version until the upgrade is approved
+	// with the tempo.grafana.com/approve-upgrade annotation.
+	UpgradePolicyModeManual UpgradePolicyMode = "manual"
+	// UpgradePolicyModeWindow upgrades the CR in the next maintenance window.
+	UpgradePolicyModeWindow UpgradePolicyMode = "window"
+)
+
+// UpgradePolicySpec defines when the operator upgrades the CR and rolls out the components after an operator update.
+type UpgradePolicySpec struct {
+	// Mode defines when the CR is upgraded to a new operator version.
+	// While the upgrade is held, the operator does not update the components of the CR and reports the UpgradePending condition.
+	// Default: auto.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mode",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:auto","urn:alm:descriptor:com.tectonic.ui:select:manual","urn:alm:descriptor:com.tectonic.ui:select:window"}
+	Mode UpgradePolicyMode `json:"mode,omitempty"`
+
+	// MaintenanceWindow defines when the CR is upgraded with the window mode.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maintenance Window"
+	MaintenanceWindow *MaintenanceWindowSpec `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindowSpec defines a recurring time range in which the CR can be upgraded.
+type MaintenanceWindowSpec struct {
+	// Schedule defines the start of the maintenance window in cron format, e.g. "0 2 * * 6" for Saturday at 02:00.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule"
+	Schedule string `json:"schedule"`
+
+	// Duration defines how long the maintenance window lasts.
+	// An upgrade which started in the maintenance window is always completed.
+	// Default: 1h.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Duration"
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// TimeZone defines the time zone of the schedule, e.g. "Europe/Berlin".
+	// Default: UTC.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Time Zone"
+	TimeZone string `json:"timeZone,omitempty"`
+}
@@ -1,8 +1,8 @@
 package v1alpha1
 
 type (
-	// IngressType represents how a service should be exposed (ingress vs route).
-	// +kubebuilder:validation:Enum=ingress;route;""
+	// IngressType represents how a service should be exposed (ingress, route or Gateway API).
+	// +kubebuilder:validation:Enum=ingress;route;gateway-api;""
 	// +kubebuilder:default=""
 	IngressType string
 )
@@ -14,8 +14,73 @@ const (
 	IngressTypeIngress IngressType = "ingress"
 	// IngressTypeRoute specifies that a route entry should be created.
 	IngressTypeRoute IngressType = "route"
+	// IngressTypeGatewayAPI specifies that Kubernetes Gateway API routes (HTTPRoute and GRPCRoute) should be created.
+	IngressTypeGatewayAPI IngressType = "gateway-api"
 )
 
+// GatewayAPISpec defines the options for the Kubernetes Gateway API routes.
+type GatewayAPISpec struct {
+	// ParentRef defines the Gateway to which the routes are attached.
+	//
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Parent Gateway"
+	ParentRef GatewayParentReference `json:"parentRef"`
+
+	// GRPCHost defines the hostname of the GRPCRoute of the gateway, which receives traces via OTLP/gRPC.
+	// The GRPCRoute and the HTTPRoute must not share a hostname on the same listener,
+	// therefore either a different hostname or a different listener (grpcSectionName) is required.
+	// Only used by the gateway of a TempoStack.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="gRPC Host"
+	GRPCHost string `json:"grpcHost,omitempty"`
+
+	// GRPCSectionName is the name of the listener of the Gateway to which the GRPCRoute of the gateway is attached.
+	// Defaults to parentRef.sectionName.
+	// Only used by the gateway of a TempoStack.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="gRPC Section Name"
+	GRPCSectionName string `json:"grpcSectionName,omitempty"`
+}
+
+// GRPCParentRef returns the Gateway to which the GRPCRoute is attached.
+func (s GatewayAPISpec) GRPCParentRef() GatewayParentReference {
+	parentRef := s.ParentRef
+	if s.GRPCSectionName != "" {
+		parentRef.SectionName = s.GRPCSectionName
+	}
+	return parentRef
+}
+
+// GatewayParentReference references a Gateway of the Kubernetes Gateway API.
+type GatewayParentReference struct {
+	// Name is the name of the Gateway.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway.
+	// Defaults to the namespace of the Tempo instance.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespace"
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of a listener of the Gateway.
+	// If empty, the routes are attached to all listeners which allow them.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Section Name"
+	SectionName string `json:"sectionName,omitempty"`
+}
+
 type (
 	// TLSRouteTerminationType is used to indicate which TLS settings should be used.
 	// +kubebuilder:validation:Enum=insecure;edge;passthrough;reencrypt
@@ -105,4 +105,22 @@ func (r *TempoMonolithic) Default(ctrlConfig configv1alpha1.ProjectConfig) {
 	if r.Spec.Query == nil {
 		r.Spec.Query = &MonolithicQuerySpec{}
 	}
+
+	if r.Spec.MetricsGenerator != nil && r.Spec.MetricsGenerator.Enabled {
+		if len(r.Spec.MetricsGenerator.Processors) == 0 {
+			r.Spec.MetricsGenerator.Processors = []MetricsGeneratorProcessor{
+				MetricsGeneratorProcessorServiceGraphs,
+				MetricsGeneratorProcessorSpanMetrics,
+			}
+		}
+		if r.Spec.MetricsGenerator.Size == nil {
+			//exhaustive:ignore
+			switch r.Spec.Storage.Traces.Backend {
+			case MonolithicTracesStorageBackendMemory:
+				r.Spec.MetricsGenerator.Size = ptr.To(twoGBQuantity)
+			default:
+				r.Spec.MetricsGenerator.Size = ptr.To(tenGBQuantity)
+			}
+		}
+	}
 }
@@ -542,6 +542,52 @@ func TestMonolithicDefault(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "metrics-generator enabled, set default processors and size",
+			input: &TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					Storage: &MonolithicStorageSpec{
+						Traces: MonolithicTracesStorageSpec{
+							Backend: "pv",
+						},
+					},
+					MetricsGenerator: &MonolithicMetricsGeneratorSpec{
+						Enabled: true,
+					},
+				},
+			},
+			expected: &TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					Storage: &MonolithicStorageSpec{
+						Traces: MonolithicTracesStorageSpec{
+							Backend: "pv",
+							Size:    &tenGBQuantity,
+						},
+					},
+					Ingestion: &MonolithicIngestionSpec{
+						OTLP: &MonolithicIngestionOTLPSpec{
+							GRPC: &MonolithicIngestionOTLPProtocolsGRPCSpec{
+								Enabled: true,
+							},
+							HTTP: &MonolithicIngestionOTLPProtocolsHTTPSpec{
+								Enabled: true,
+							},
+						},
+					},
+					Management: "Managed",
+					Timeout:    metav1.Duration{Duration: time.Second * 30},
+					Query:      &MonolithicQuerySpec{},
+					MetricsGenerator: &MonolithicMetricsGeneratorSpec{
+						Enabled: true,
+						Processors: []MetricsGeneratorProcessor{
+							MetricsGeneratorProcessorServiceGraphs,
+							MetricsGeneratorProcessorSpanMetrics,
+						},
+						Size: &tenGBQuantity,
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -8,6 +8,16 @@ import (
 
 // TempoMonolithicSpec defines the desired state of TempoMonolithic.
 type TempoMonolithicSpec struct {
+	// TempoVersion pins the version of Tempo, e.g. 2.7.2.
+	// The version must be supported by the operator. The Tempo and Tempo Query images default to
+	// the images of this version, and the configuration is rendered for this version.
+	// Defaults to the Tempo version of the operator.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^\d+\.\d+\.\d+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tempo Version"
+	TempoVersion string `json:"tempoVersion,omitempty"`
+
 	// Storage defines the storage configuration.
 	//
 	// +kubebuilder:validation:Optional
@@ -74,12 +84,47 @@ type TempoMonolithicSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Extra Configuration",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
 	ExtraConfig *ExtraConfigSpec `json:"extraConfig,omitempty"`
 
+	// NetworkPolicy defines the NetworkPolicies created for the Tempo deployment.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Policy",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+
 	// Query defines query configuration.
 	//
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Configuration",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
 	Query *MonolithicQuerySpec `json:"query,omitempty"`
 
+	// MetricsGenerator defines the metrics-generator configuration.
+	// The metrics-generator derives metrics (service graphs, span metrics) from ingested traces
+	// and writes them to Prometheus remote-write endpoints.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Generator"
+	MetricsGenerator *MonolithicMetricsGeneratorSpec `json:"metricsGenerator,omitempty"`
+
+	// Retention defines how long the traces are stored, globally and per tenant.
+	// Per-tenant retention requires multi-tenancy to be enabled.
+	// Default: Tempo's default retention of 14 days.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retention"
+	Retention *RetentionSpec `json:"retention,omitempty"`
+
+	// Limits defines the ingestion and query limits, globally and per tenant.
+	// Per-tenant limits require multi-tenancy to be enabled.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Limits"
+	Limits *LimitSpec `json:"limits,omitempty"`
+
+	// UpgradePolicy defines when the TempoMonolithic is upgraded to a new operator version.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Upgrade Policy"
+	UpgradePolicy *UpgradePolicySpec `json:"upgradePolicy,omitempty"`
+
 	MonolithicSchedulerSpec `json:",inline"`
 }
 
@@ -94,6 +139,86 @@ type MonolithicQuerySpec struct {
 	RBAC RBACSpec `json:"rbac,omitempty"`
 }
 
+// MonolithicMetricsGeneratorSpec defines the metrics-generator configuration.
+type MonolithicMetricsGeneratorSpec struct {
+	// Enabled defines if the metrics-generator is enabled.
+	//
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",order=1,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// Processors defines the list of processors enabled for all tenants.
+	// Default: service-graphs and span-metrics.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Processors",order=2
+	Processors []MetricsGeneratorProcessor `json:"processors,omitempty"`
+
+	// RemoteWrite defines the Prometheus remote-write endpoints the generated metrics are sent to.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remote Write",order=3
+	RemoteWrite []MonolithicMetricsGeneratorRemoteWriteSpec `json:"remoteWrite,omitempty"`
+
+	// Size defines the size of the volume where the metrics-generator WAL and local blocks are stored.
+	// For in-memory storage, this defines the size of the tmpfs volume.
+	// For all other backends, this defines the size of the persistent volume.
+	// Default: 2Gi for memory, 10Gi for all other backends.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Size",order=4,xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Size *resource.Quantity `json:"size,omitempty"`
+}
+
+// MonolithicMetricsGeneratorRemoteWriteSpec defines a Prometheus remote-write endpoint.
+type MonolithicMetricsGeneratorRemoteWriteSpec struct {
+	MetricsGeneratorRemoteWriteSpec `json:",inline"`
+
+	// TLS defines the TLS configuration for the remote-write endpoint.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS"
+	TLS *TLSSpec `json:"tls,omitempty"`
+
+	// BasicAuth defines the basic authentication credentials for the remote-write endpoint.
+	// Cannot be used together with BearerToken.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Basic Authentication"
+	BasicAuth *MonolithicRemoteWriteBasicAuthSpec `json:"basicAuth,omitempty"`
+
+	// BearerToken defines the bearer token for the remote-write endpoint.
+	// Cannot be used together with BasicAuth.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Bearer Token"
+	BearerToken *MonolithicRemoteWriteBearerTokenSpec `json:"bearerToken,omitempty"`
+}
+
+// MonolithicRemoteWriteBasicAuthSpec defines basic authentication for a remote-write endpoint.
+type MonolithicRemoteWriteBasicAuthSpec struct {
+	// Secret is the name of a Secret containing the `username` and `password` keys.
+	// It needs to be in the same namespace as the TempoMonolithic custom resource.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	Secret string `json:"secret"`
+}
+
+// MonolithicRemoteWriteBearerTokenSpec defines bearer token authentication for a remote-write endpoint.
+type MonolithicRemoteWriteBearerTokenSpec struct {
+	// Secret is the name of a Secret containing the `token` key.
+	// It needs to be in the same namespace as the TempoMonolithic custom resource.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	Secret string `json:"secret"`
+}
+
 // MonolithicStorageSpec defines the storage for the Tempo deployment.
 type MonolithicStorageSpec struct {
 	// Traces defines the storage configuration for traces.
@@ -101,6 +226,21 @@ type MonolithicStorageSpec struct {
 	// +kubebuilder:validation:Required
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Traces"
 	Traces MonolithicTracesStorageSpec `json:"traces"`
+
+	// Tuning defines the tuning of the trace storage, e.g. the block format and the compaction.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tuning"
+	Tuning *StorageTuningSpec `json:"tuning,omitempty"`
+
+	// DedicatedColumns defines the attributes which are stored in dedicated columns of the parquet blocks.
+	// Searching for these attributes is significantly faster. At most 10 columns are allowed per scope.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Dedicated Attribute Columns"
+	DedicatedColumns []DedicatedColumn `json:"dedicatedColumns,omitempty"`
 }
 
 // MonolithicTracesStorageSpec defines the traces storage for the Tempo deployment.
@@ -292,6 +432,12 @@ type MonolithicJaegerUISpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Route",order=4
 	Route *MonolithicJaegerUIRouteSpec `json:"route,omitempty"`
 
+	// GatewayAPI defines the Kubernetes Gateway API HTTPRoute configuration for the Jaeger UI.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway API",order=5
+	GatewayAPI *MonolithicJaegerUIGatewayAPISpec `json:"gatewayAPI,omitempty"`
+
 	// Authentication defines the options for the oauth proxy used to protect jaeger UI
 	//
 	// +optional
@@ -374,6 +520,33 @@ type MonolithicJaegerUIRouteSpec struct {
 	Termination TLSRouteTerminationType `json:"termination,omitempty"`
 }
 
+// MonolithicJaegerUIGatewayAPISpec defines the settings for the Jaeger UI HTTPRoute.
+type MonolithicJaegerUIGatewayAPISpec struct {
+	// Enabled defines if a HTTPRoute object should be created for Jaeger UI.
+	//
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",order=1,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// Annotations defines the annotations of the HTTPRoute object.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Annotations"
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Host defines the hostname of the HTTPRoute object.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hostname"
+	Host string `json:"host,omitempty"`
+
+	// ParentRef defines the Gateway to which the HTTPRoute is attached.
+	//
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Parent Gateway"
+	ParentRef GatewayParentReference `json:"parentRef"`
+}
+
 // MonolithicMultitenancySpec defines the multi-tenancy settings for Tempo.
 type MonolithicMultitenancySpec struct {
 	// Enabled defines if multi-tenancy is enabled.
@@ -509,7 +682,7 @@ type TempoMonolithicStatus struct {
 	// +optional
 	OperatorVersion string `json:"operatorVersion,omitempty"`
 
-	// Version of the managed Tempo instance.
+	// Version of the managed Tempo instance, i.e. the tag of the Tempo image, updated once all Tempo pods run this version.
 	// +optional
 	TempoVersion string `json:"tempoVersion,omitempty"`
 
@@ -523,6 +696,13 @@ type TempoMonolithicStatus struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Upgrade shows the upgrade steps of the last upgrade to a new operator version,
+	// or of the pending upgrade while the upgrade policy holds the upgrade.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Upgrade"
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -580,3 +760,8 @@ func (tempo *TempoMonolithic) GetStatus() any {
 func (tempo *TempoMonolithic) SetStatus(s any) {
 	tempo.Status = s.(TempoMonolithicStatus)
 }
+
+// SetUpgradeStatus sets the upgrade status in the status field.
+func (tempo *TempoMonolithic) SetUpgradeStatus(s *UpgradeStatus) {
+	tempo.Status.Upgrade = s
+}
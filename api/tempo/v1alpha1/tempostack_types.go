@@ -1,9 +1,11 @@
 package v1alpha1
 
 import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/grafana/tempo-operator/api/config/v1alpha1"
 )
@@ -69,6 +71,17 @@ type TempoStackSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Container Images"
 	Images v1alpha1.ImagesSpec `json:"images,omitempty"`
 
+	// TempoVersion pins the version of Tempo, e.g. 2.7.2.
+	// The version must be supported by the operator. The Tempo and Tempo Query images default to
+	// the images of this version, and the configuration is rendered for this version.
+	// A Tempo image set in the CR must run the same minor release.
+	// Defaults to the Tempo version of the operator.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^\d+\.\d+\.\d+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tempo Version"
+	TempoVersion string `json:"tempoVersion,omitempty"`
+
 	// Storage defines the spec for the object storage endpoint to store traces.
 	// User is required to create secret and supply it.
 	//
@@ -131,6 +144,288 @@ type TempoStackSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Extra Configurations"
 	ExtraConfig *ExtraConfigSpec `json:"extraConfig,omitempty"`
+
+	// Cache defines the caching tier used by the querier and query-frontend
+	// to avoid fetching bloom filters, parquet footers and search results from object storage.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cache"
+	Cache *CacheSpec `json:"cache,omitempty"`
+
+	// Ingest defines how the distributors pass received traces to the ingesters.
+	// By default, the distributors push traces to the ingesters via gRPC.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingest"
+	Ingest *IngestSpec `json:"ingest,omitempty"`
+
+	// ZoneAwareness spreads the ingesters across failure domains and enables the zone-aware replication of Tempo.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Zone Awareness"
+	ZoneAwareness *ZoneAwarenessSpec `json:"zoneAwareness,omitempty"`
+
+	// NetworkPolicy defines the NetworkPolicies created for the TempoStack.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Policy"
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+
+	// UpgradePolicy defines when the TempoStack is upgraded to a new operator version.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Upgrade Policy"
+	UpgradePolicy *UpgradePolicySpec `json:"upgradePolicy,omitempty"`
+}
+
+// ZoneAwarenessSpec defines the zone-aware replication of the TempoStack.
+type ZoneAwarenessSpec struct {
+	// Enabled defines if zone-aware replication is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// TopologyKey defines the node label which identifies the zone of a node.
+	// Default: topology.kubernetes.io/zone.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Topology Key"
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// MaxSkew defines the maximum difference of the number of ingesters between two zones.
+	// Default: 1.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Skew"
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+
+	// Zones defines the availability zones of the ingesters.
+	// If set, the operator creates one ingester StatefulSet per zone, scheduled on the nodes
+	// whose topology key label matches the zone name. Each zone StatefulSet runs the configured number
+	// of ingester replicas, and the zones are updated one at a time.
+	// If not set, a single ingester StatefulSet is spread across all zones.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Zones"
+	Zones []ZoneSpec `json:"zones,omitempty"`
+}
+
+// ZoneSpec defines an availability zone of the ingesters.
+type ZoneSpec struct {
+	// Name is the name of the availability zone. It must match the topology key label of the nodes in this zone.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
+}
+
+// IngestSpec defines the ingest path of the TempoStack.
+type IngestSpec struct {
+	// Kafka configures the distributors to write traces to a Kafka topic.
+	// The ingesters and the block-builders consume the traces from the topic,
+	// and the block-builders write the blocks to the object storage.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kafka"
+	Kafka *KafkaIngestSpec `json:"kafka,omitempty"`
+}
+
+// KafkaIngestSpec defines the Kafka cluster of the ingest path.
+type KafkaIngestSpec struct {
+	// Brokers defines the addresses of the Kafka brokers (host:port).
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Brokers",order=1
+	Brokers []string `json:"brokers"`
+
+	// Topic defines the Kafka topic.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Topic",order=2
+	Topic string `json:"topic"`
+
+	// SASL defines the SASL PLAIN authentication with the Kafka brokers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="SASL",order=3
+	SASL *KafkaSASLSpec `json:"sasl,omitempty"`
+
+	// TLS defines the TLS configuration for connecting to the Kafka brokers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS",order=4
+	TLS TLSSpec `json:"tls,omitempty"`
+}
+
+// KafkaSASLSpec defines the SASL authentication with the Kafka brokers.
+type KafkaSASLSpec struct {
+	// Secret is the name of a Secret containing the SASL credentials (username and password).
+	// It needs to be in the same namespace as the Tempo custom resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret",displayName="Secret"
+	Secret string `json:"secret"`
+}
+
+// CacheSpec defines the caching tier of the TempoStack.
+type CacheSpec struct {
+	// Backend defines the cache backend.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backend",order=1
+	Backend CacheBackend `json:"backend"`
+
+	// Roles defines which data is stored in the cache.
+	// Default: bloom, parquet-footer and frontend-search.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Roles",order=2
+	Roles []CacheRole `json:"roles,omitempty"`
+
+	// Memcached defines the memcached configuration. Required if backend is memcached.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Memcached"
+	Memcached *MemcachedSpec `json:"memcached,omitempty"`
+
+	// Redis defines the redis configuration. Required if backend is redis.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Redis"
+	Redis *RedisSpec `json:"redis,omitempty"`
+}
+
+// CacheBackend defines the cache backend.
+//
+// +kubebuilder:validation:Enum=memcached;redis
+type CacheBackend string
+
+const (
+	// CacheBackendMemcached uses memcached as cache.
+	CacheBackendMemcached CacheBackend = "memcached"
+	// CacheBackendRedis uses redis as cache.
+	CacheBackendRedis CacheBackend = "redis"
+)
+
+// CacheRole defines which data is stored in the cache.
+//
+// +kubebuilder:validation:Enum=bloom;parquet-footer;frontend-search
+type CacheRole string
+
+const (
+	// CacheRoleBloom caches bloom filters of the backend blocks.
+	CacheRoleBloom CacheRole = "bloom"
+	// CacheRoleParquetFooter caches footers of the parquet backend blocks.
+	CacheRoleParquetFooter CacheRole = "parquet-footer"
+	// CacheRoleFrontendSearch caches search results in the query-frontend.
+	CacheRoleFrontendSearch CacheRole = "frontend-search"
+)
+
+// MemcachedSpec defines the memcached configuration.
+type MemcachedSpec struct {
+	// Host of an external memcached cluster.
+	// The memcached servers are discovered with a DNS SRV lookup of the Service port.
+	// Must be empty if the managed memcached is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host"
+	Host string `json:"host,omitempty"`
+
+	// Service defines the name of the port of the external memcached Service, used for the DNS SRV lookup.
+	// Default: memcached.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Port Name"
+	Service string `json:"service,omitempty"`
+
+	// Timeout defines the timeout of memcached requests.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout"
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// Managed defines a memcached StatefulSet deployed by the operator.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Managed Memcached"
+	Managed ManagedMemcachedSpec `json:"managed,omitempty"`
+}
+
+// ManagedMemcachedSpec defines the memcached deployed by the operator.
+type ManagedMemcachedSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// Currently there is no way to inline this field.
+	// See: https://github.com/golang/go/issues/6213
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:"component,omitempty"`
+
+	// Enabled defines if the operator deploys a memcached StatefulSet.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// MemoryLimitMB defines the memory used by each memcached instance for storing items, in megabytes.
+	// Default: 1024.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=64
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Memory Limit (MB)"
+	MemoryLimitMB int `json:"memoryLimitMB,omitempty"`
+}
+
+// RedisSpec defines the redis configuration.
+type RedisSpec struct {
+	// Endpoint of the redis server, for example "redis:6379".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint"
+	Endpoint string `json:"endpoint"`
+
+	// Timeout defines the timeout of redis requests.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout"
+	Timeout metav1.Duration `json:"timeout,omitempty"`
 }
 
 // ObservabilitySpec defines how telemetry data gets handled.
@@ -265,6 +560,27 @@ type ComponentStatus struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Gateway",order=6
 	Gateway PodStatusMap `json:"gateway"`
+
+	// MetricsGenerator is a map to the per pod status of the metrics-generator statefulset
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Metrics Generator",order=7
+	MetricsGenerator PodStatusMap `json:"metricsGenerator,omitempty"`
+
+	// Memcached is a map to the per pod status of the operator-managed memcached statefulset
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Memcached",order=8
+	Memcached PodStatusMap `json:"memcached,omitempty"`
+
+	// BlockBuilder is a map to the per pod status of the block-builder statefulset
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Block Builder",order=9
+	BlockBuilder PodStatusMap `json:"blockBuilder,omitempty"`
 }
 
 // TempoStackStatus defines the observed state of TempoStack.
@@ -273,7 +589,7 @@ type TempoStackStatus struct {
 	// +optional
 	OperatorVersion string `json:"operatorVersion,omitempty"`
 
-	// Version of the managed Tempo instance.
+	// Version of the managed Tempo instance, i.e. the tag of the Tempo image, updated once all Tempo pods run this version.
 	// +optional
 	TempoVersion string `json:"tempoVersion,omitempty"`
 
@@ -294,8 +610,77 @@ type TempoStackStatus struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// RuntimeOverrides shows whether the Tempo components loaded the current per-tenant overrides.
+	// The per-tenant overrides are reloaded by Tempo at runtime, without restarting the pods.
+	// The state is Unknown if the httpEncryption feature gate is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Runtime Overrides"
+	RuntimeOverrides *RuntimeOverridesStatus `json:"runtimeOverrides,omitempty"`
+
+	// DedicatedColumns shows the dedicated attribute columns which are loaded by all Tempo components.
+	// The list is updated once all components loaded the current per-tenant overrides.
+	// Not available if the httpEncryption feature gate is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Dedicated Attribute Columns"
+	DedicatedColumns []DedicatedColumn `json:"dedicatedColumns,omitempty"`
+
+	// Upgrade shows the upgrade steps of the last upgrade to a new operator version,
+	// or of the pending upgrade while the upgrade policy holds the upgrade.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Upgrade"
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+}
+
+// RuntimeOverridesStatus defines the observed state of the per-tenant overrides.
+type RuntimeOverridesStatus struct {
+	// Checksum of the per-tenant overrides of the overrides ConfigMap.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// ObservedTime is the time when all Tempo pods were observed to serve the per-tenant overrides
+	// of the current checksum at the /status/runtime_config endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	ObservedTime *metav1.Time `json:"observedTime,omitempty"`
+
+	// PendingPods lists the Tempo pods which did not load the per-tenant overrides yet.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	PendingPods []string `json:"pendingPods,omitempty"`
+
+	// State shows if all Tempo pods loaded the per-tenant overrides of the current checksum.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	State RuntimeOverridesState `json:"state,omitempty"`
 }
 
+// RuntimeOverridesState defines if the Tempo pods loaded the per-tenant overrides.
+//
+// +kubebuilder:validation:Enum=Loaded;Pending;Unknown
+type RuntimeOverridesState string
+
+const (
+	// RuntimeOverridesLoaded defines that all Tempo pods loaded the per-tenant overrides.
+	RuntimeOverridesLoaded RuntimeOverridesState = "Loaded"
+	// RuntimeOverridesPending defines that some Tempo pods did not load the per-tenant overrides yet.
+	RuntimeOverridesPending RuntimeOverridesState = "Pending"
+	// RuntimeOverridesUnknown defines that the operator cannot check if the Tempo pods loaded the per-tenant overrides,
+	// because the runtime configuration endpoint requires a client certificate if the httpEncryption feature gate is enabled.
+	RuntimeOverridesUnknown RuntimeOverridesState = "Unknown"
+)
+
 // ConditionStatus defines the status of a condition (e.g. ready, failed, pending or configuration error).
 type ConditionStatus string
 
@@ -308,6 +693,15 @@ const (
 	ConditionPending ConditionStatus = "Pending"
 	// ConditionConfigurationError defines that there is a configuration error.
 	ConditionConfigurationError ConditionStatus = "ConfigurationError"
+	// ConditionStorageReady defines that the object storage was checked successfully before rolling out the components.
+	// The condition is independent of the other conditions.
+	ConditionStorageReady ConditionStatus = "StorageReady"
+	// ConditionStorageMigrated defines that the storage migration is complete.
+	// The condition is independent of the other conditions.
+	ConditionStorageMigrated ConditionStatus = "StorageMigrated"
+	// ConditionUpgradePending defines that the upgrade policy holds the upgrade to a new operator version.
+	// The condition is independent of the other conditions.
+	ConditionUpgradePending ConditionStatus = "UpgradePending"
 )
 
 // AllStatusConditions lists all possible status conditions.
@@ -349,6 +743,34 @@ const (
 	ReasonFailedReconciliation ConditionReason = "FailedReconciliation"
 	// ReasonFailedUpgrade when the operator failed to upgrade an instance.
 	ReasonFailedUpgrade ConditionReason = "FailedUpgrade"
+	// ReasonStorageReady when the operator listed, wrote and deleted a probe object in the object storage.
+	ReasonStorageReady ConditionReason = "StorageReady"
+	// ReasonStorageBucketNotFound when the bucket of the object storage does not exist.
+	ReasonStorageBucketNotFound ConditionReason = "BucketNotFound"
+	// ReasonStorageAccessDenied when the storage credentials are invalid or lack permissions.
+	ReasonStorageAccessDenied ConditionReason = "StorageAccessDenied"
+	// ReasonStorageTLSError when the TLS certificate of the object storage cannot be verified.
+	ReasonStorageTLSError ConditionReason = "StorageTLSError"
+	// ReasonStorageUnreachable when the object storage endpoint cannot be reached.
+	ReasonStorageUnreachable ConditionReason = "StorageUnreachable"
+	// ReasonStorageCheckFailed when the storage preflight check failed for any other reason.
+	ReasonStorageCheckFailed ConditionReason = "StorageCheckFailed"
+	// ReasonStorageCheckSkipped when the storage preflight check is not supported for the storage configuration.
+	ReasonStorageCheckSkipped ConditionReason = "StorageCheckSkipped"
+	// ReasonStorageMigrationCopying when the objects are copied from the previous object storage,
+	// and the components still use the previous object storage.
+	ReasonStorageMigrationCopying ConditionReason = "StorageMigrationCopying"
+	// ReasonStorageMigrationSyncing when the components use the new object storage,
+	// and the objects written during the copy are copied from the previous object storage.
+	ReasonStorageMigrationSyncing ConditionReason = "StorageMigrationSyncing"
+	// ReasonStorageMigrationComplete when all objects are copied to the new object storage.
+	ReasonStorageMigrationComplete ConditionReason = "StorageMigrationComplete"
+	// ReasonStorageMigrationFailed when copying the objects failed.
+	ReasonStorageMigrationFailed ConditionReason = "StorageMigrationFailed"
+	// ReasonUpgradeAwaitingApproval when the upgrade of a CR with the manual upgrade policy is not approved yet.
+	ReasonUpgradeAwaitingApproval ConditionReason = "UpgradeAwaitingApproval"
+	// ReasonUpgradeAwaitingMaintenanceWindow when the upgrade of a CR is held until the next maintenance window.
+	ReasonUpgradeAwaitingMaintenanceWindow ConditionReason = "UpgradeAwaitingMaintenanceWindow"
 )
 
 // Resources defines resources configuration.
@@ -389,7 +811,7 @@ type SearchSpec struct {
 
 // ObjectStorageSecretType defines the type of storage which can be used with the Tempo cluster.
 //
-// +kubebuilder:validation:Enum=azure;gcs;s3
+// +kubebuilder:validation:Enum=azure;gcs;s3;swift
 type ObjectStorageSecretType string
 
 const (
@@ -401,6 +823,10 @@ const (
 
 	// ObjectStorageSecretS3 when using S3 for Tempo storage.
 	ObjectStorageSecretS3 ObjectStorageSecretType = "s3"
+
+	// ObjectStorageSecretSwift when using OpenStack Swift for Tempo storage.
+	// Tempo accesses Swift through its S3-compatible API (s3api middleware).
+	ObjectStorageSecretSwift ObjectStorageSecretType = "swift"
 )
 
 // ObjectStorageSecretSpec is a secret reference containing name only, no namespace.
@@ -409,7 +835,7 @@ type ObjectStorageSecretSpec struct {
 	//
 	// +required
 	// +kubebuilder:validation:Required
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:azure","urn:alm:descriptor:com.tectonic.ui:select:gcs","urn:alm:descriptor:com.tectonic.ui:select:s3"},displayName="Object Storage Secret Type"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:azure","urn:alm:descriptor:com.tectonic.ui:select:gcs","urn:alm:descriptor:com.tectonic.ui:select:s3","urn:alm:descriptor:com.tectonic.ui:select:swift"},displayName="Object Storage Secret Type"
 	Type ObjectStorageSecretType `json:"type"`
 	// CredentialMode can be used to set the desired credential mode for authenticating with the object storage.
 	// If this is not set, then the operator tries to infer the credential mode from the provided secret and its
@@ -439,11 +865,82 @@ type ObjectStorageSpec struct {
 
 	// Secret for object storage authentication.
 	// Name of a secret in the same namespace as the TempoStack custom resource.
+	// Required unless the filesystem storage is used.
 	//
-	// +kubebuilder:validation:Required
+	// +optional
+	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Storage Secret"
-	Secret ObjectStorageSecretSpec `json:"secret"`
+	Secret ObjectStorageSecretSpec `json:"secret,omitempty"`
 	// Don't forget to update storageSecretField in tempostack_controller.go if this field name changes.
+
+	// Filesystem stores the traces on a shared filesystem instead of an object storage.
+	// All Tempo components which access the trace storage mount the same PersistentVolumeClaim,
+	// therefore the PersistentVolumeClaim must support the ReadWriteMany access mode.
+	// This is intended for small installations without an object storage.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Filesystem Storage"
+	Filesystem *FilesystemStorageSpec `json:"filesystem,omitempty"`
+
+	// Tuning defines the tuning of the trace storage, e.g. the block format and the compaction.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tuning"
+	Tuning *StorageTuningSpec `json:"tuning,omitempty"`
+
+	// DedicatedColumns defines the attributes which are stored in dedicated columns of the parquet blocks.
+	// Searching for these attributes is significantly faster. At most 10 columns are allowed per scope.
+	// The columns are configured in the storage configuration and in the per-tenant overrides,
+	// which are reloaded by Tempo without restarting the pods.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Dedicated Attribute Columns"
+	DedicatedColumns []DedicatedColumn `json:"dedicatedColumns,omitempty"`
+
+	// Migration copies the traces from a previous object storage to the object storage configured in the storage secret.
+	// The Tempo components keep using the previous object storage until all objects are copied,
+	// then the components are switched to the new object storage and the objects written in the meantime are copied.
+	// The progress is reported in the StorageMigrated condition.
+	// Remove this field once the migration is complete.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Migration"
+	Migration *StorageMigrationSpec `json:"migration,omitempty"`
+}
+
+// StorageMigrationSpec defines the previous object storage of a storage migration.
+type StorageMigrationSpec struct {
+	// Source is the secret of the previous object storage.
+	// Only static credentials are supported, for the previous and for the new object storage.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Source Object Storage Secret"
+	Source ObjectStorageSecretSpec `json:"source"`
+
+	// SourceTLS is the TLS configuration for reaching the previous object storage endpoint.
+	// Client certificates are not supported during a migration.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Source TLS Config"
+	SourceTLS TLSSpec `json:"sourceTLS,omitempty"`
+}
+
+// FilesystemStorageSpec defines a shared filesystem for storing traces.
+type FilesystemStorageSpec struct {
+	// ClaimName is the name of an existing PersistentVolumeClaim with the ReadWriteMany access mode,
+	// in the same namespace as the TempoStack custom resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:PersistentVolumeClaim",displayName="PersistentVolumeClaim Name"
+	ClaimName string `json:"claimName"`
 }
 
 // MemberListSpec defines the configuration for the memberlist based hash ring.
@@ -505,7 +1002,7 @@ type TempoTemplateSpec struct {
 	// +optional
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Querier pods"
-	Querier TempoComponentSpec `json:"querier,omitempty"`
+	Querier TempoQuerierSpec `json:"querier,omitempty"`
 
 	// TempoQueryFrontendSpec defines the query frontend spec.
 	//
@@ -520,6 +1017,21 @@ type TempoTemplateSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway pods"
 	Gateway TempoGatewaySpec `json:"gateway,omitempty"`
+
+	// MetricsGenerator defines the tempo metrics-generator spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Generator pods"
+	MetricsGenerator TempoMetricsGeneratorSpec `json:"metricsGenerator,omitempty"`
+
+	// BlockBuilder defines the tempo block-builder component spec.
+	// The block-builder is only deployed if the Kafka ingest path is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Block Builder pods"
+	BlockBuilder TempoComponentSpec `json:"blockBuilder,omitempty"`
 }
 
 // TempoDistributorSpec defines the template of all requirements to configure
@@ -534,6 +1046,14 @@ type TempoDistributorSpec struct {
 	// +kubebuilder:validation:Optional
 	TempoComponentSpec `json:"component,omitempty"`
 
+	// Autoscaling defines a HorizontalPodAutoscaler for the distributor.
+	// If enabled, the replicas of the distributor are managed by the HorizontalPodAutoscaler.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscaling"
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// TLS defines TLS configuration for distributor receivers
 	//
 	// +optional
@@ -544,6 +1064,21 @@ type TempoDistributorSpec struct {
 	TLS TLSSpec `json:"tls,omitempty"`
 }
 
+// TempoQuerierSpec defines the template of the querier component.
+type TempoQuerierSpec struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// Autoscaling defines a HorizontalPodAutoscaler for the querier.
+	// If enabled, the replicas of the querier are managed by the HorizontalPodAutoscaler.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscaling"
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+}
+
 // TempoComponentSpec defines specific schedule settings for tempo components.
 type TempoComponentSpec struct {
 	// Replicas defines the number of replicas to be created for this component.
@@ -575,6 +1110,100 @@ type TempoComponentSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PodSecurityContext"
 	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// Affinity defines the Affinity rules for scheduling pods of this component.
+	// If not set, the pods of a component prefer to be scheduled on different nodes and zones.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Affinity",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints defines how the pods of this component are spread across topology domains.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Topology Spread Constraints",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PodDisruptionBudget overrides the PodDisruptionBudget of this component.
+	// By default, the ingester allows as many unavailable pods as the replication factor tolerates
+	// and all other components allow one unavailable pod.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Disruption Budget"
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+}
+
+// PodDisruptionBudgetSpec defines the PodDisruptionBudget of a component.
+// Only one of MinAvailable and MaxUnavailable can be set.
+type PodDisruptionBudgetSpec struct {
+	// MinAvailable defines the number or percentage of pods that must be available after an eviction.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Min Available"
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable defines the number or percentage of pods that can be unavailable after an eviction.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Unavailable"
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// AutoscalingSpec defines the HorizontalPodAutoscaler of a component.
+type AutoscalingSpec struct {
+	// Enabled defines if the HorizontalPodAutoscaler is created.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// MinReplicas defines the lower limit for the number of replicas.
+	// Default: 1.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Min Replicas"
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas defines the upper limit for the number of replicas.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Replicas"
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilization defines the target average CPU utilization, in percent of the requested CPU.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target CPU Utilization"
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+
+	// TargetMemoryUtilization defines the target average memory utilization, in percent of the requested memory.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target Memory Utilization"
+	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+
+	// Metrics defines additional metrics, for example custom or external metrics, used to compute the desired replica count.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics"
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
 }
 
 // TempoGatewaySpec extends TempoComponentSpec with gateway parameters.
@@ -606,6 +1235,90 @@ type TempoGatewaySpec struct {
 	RBAC RBACSpec `json:"rbac,omitempty"`
 }
 
+// TempoMetricsGeneratorSpec extends TempoComponentSpec with metrics-generator specific parameters.
+type TempoMetricsGeneratorSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// Currently there is no way to inline this field.
+	// See: https://github.com/golang/go/issues/6213
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:"component,omitempty"`
+
+	// Enabled defines if the metrics-generator component should be created.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// Processors defines the list of processors enabled for all tenants.
+	// Default: service-graphs and span-metrics.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Processors"
+	Processors []MetricsGeneratorProcessor `json:"processors,omitempty"`
+
+	// RemoteWrite defines the Prometheus remote-write endpoints the generated metrics are sent to.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remote Write"
+	RemoteWrite []MetricsGeneratorRemoteWriteSpec `json:"remoteWrite,omitempty"`
+
+	// StorageSize defines the size of the PVC holding the WAL of the generated metrics
+	// and the local blocks of the local-blocks processor. Defaults to 10Gi.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage size for PVCs"
+	StorageSize *resource.Quantity `json:"storageSize,omitempty"`
+}
+
+// MetricsGeneratorProcessor defines a processor of the metrics-generator.
+//
+// +kubebuilder:validation:Enum=service-graphs;span-metrics;local-blocks
+type MetricsGeneratorProcessor string
+
+const (
+	// MetricsGeneratorProcessorServiceGraphs generates service graph metrics.
+	MetricsGeneratorProcessorServiceGraphs MetricsGeneratorProcessor = "service-graphs"
+	// MetricsGeneratorProcessorSpanMetrics generates span rate, error, and duration (RED) metrics.
+	MetricsGeneratorProcessorSpanMetrics MetricsGeneratorProcessor = "span-metrics"
+	// MetricsGeneratorProcessorLocalBlocks stores recent traces locally, required for TraceQL metrics queries.
+	MetricsGeneratorProcessorLocalBlocks MetricsGeneratorProcessor = "local-blocks"
+)
+
+// MetricsGeneratorRemoteWriteSpec defines a Prometheus remote-write endpoint.
+type MetricsGeneratorRemoteWriteSpec struct {
+	// URL of the Prometheus remote-write endpoint.
+	// For example, "http://prometheus:9090/api/v1/write".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL"
+	URL string `json:"url"`
+
+	// SendExemplars defines if exemplars should be sent to the remote-write endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Send Exemplars",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	SendExemplars bool `json:"sendExemplars,omitempty"`
+
+	// Headers defines additional HTTP headers sent with each remote-write request.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Headers"
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
 // RBACSpec defines RBAC options.
 type RBACSpec struct {
 	// Enabled defines if the query RBAC should be enabled.
@@ -627,6 +1340,14 @@ type TempoQueryFrontendSpec struct {
 	// +kubebuilder:validation:Optional
 	TempoComponentSpec `json:"component,omitempty"`
 
+	// Autoscaling defines a HorizontalPodAutoscaler for the query-frontend.
+	// If enabled, the replicas of the query-frontend are managed by the HorizontalPodAutoscaler.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscaling"
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// JaegerQuery defines options specific to the Jaeger Query component.
 	//
 	// +optional
@@ -735,7 +1456,7 @@ type JaegerQueryMonitor struct {
 // IngressSpec defines Jaeger Query Ingress options.
 type IngressSpec struct {
 	// Type defines the type of Ingress for the Jaeger Query UI.
-	// Currently ingress, route and none are supported.
+	// Currently ingress, route, gateway-api and none are supported.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
@@ -767,6 +1488,14 @@ type IngressSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Route Configuration"
 	Route RouteSpec `json:"route,omitempty"`
+
+	// GatewayAPI defines the options for the Kubernetes Gateway API routes.
+	// Required if the type is gateway-api.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway API Configuration"
+	GatewayAPI *GatewayAPISpec `json:"gatewayAPI,omitempty"`
 }
 
 // RouteSpec defines OpenShift Route specific options.
@@ -843,6 +1572,43 @@ type IngestionLimitSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:number",displayName="Max Traces per User"
 	MaxTracesPerUser *int `json:"maxTracesPerUser,omitempty"`
+
+	// MaxAttributeBytes defines the maximum size (bytes) of an attribute key or value.
+	// Longer attributes are truncated by the distributor.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:number",displayName="Max Attribute Bytes"
+	MaxAttributeBytes *int `json:"maxAttributeBytes,omitempty"`
+
+	// Forwarders defines the names of the forwarders to which the spans of the tenant are sent.
+	// The forwarders must be configured in the distributor configuration of Tempo.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Forwarders"
+	Forwarders []string `json:"forwarders,omitempty"`
+
+	// MetricsGeneratorProcessors defines the metrics-generator processors enabled for the tenant.
+	// This field can only be set per tenant, the processors of all tenants are configured
+	// in spec.template.metricsGenerator.processors.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Generator Processors"
+	MetricsGeneratorProcessors []MetricsGeneratorProcessor `json:"metricsGeneratorProcessors,omitempty"`
+
+	// CostAttributionDimensions defines the span or resource attributes used to attribute the
+	// ingested bytes to, e.g. a team or a service. The key is the attribute name, the value is
+	// the name of the label in the usage metrics. If the value is empty, the label name is
+	// derived from the attribute name.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cost Attribution Dimensions"
+	CostAttributionDimensions map[string]string `json:"costAttributionDimensions,omitempty"`
 }
 
 // QueryLimit defines query limits.
@@ -870,6 +1636,22 @@ type QueryLimit struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Search Duration per User"
 	MaxSearchDuration metav1.Duration `json:"maxSearchDuration"`
+
+	// MaxMetricsDuration defines the maximum allowed time range for a TraceQL metrics query.
+	// default: `0s` to use the default of Tempo.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Metrics Duration per User"
+	MaxMetricsDuration metav1.Duration `json:"maxMetricsDuration,omitempty"`
+
+	// UnsafeQueryHints allows the tenant to use unsafe query hints in TraceQL queries,
+	// for example to override the sampling or the number of jobs of a query.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch",displayName="Unsafe Query Hints"
+	UnsafeQueryHints *bool `json:"unsafeQueryHints,omitempty"`
 }
 
 // RetentionSpec defines global and per tenant retention configurations.
@@ -954,3 +1736,8 @@ func (tempo *TempoStack) GetStatus() any {
 func (tempo *TempoStack) SetStatus(s any) {
 	tempo.Status = s.(TempoStackStatus)
 }
+
+// SetUpgradeStatus sets the upgrade status in the status field.
+func (tempo *TempoStack) SetUpgradeStatus(s *UpgradeStatus) {
+	tempo.Status.Upgrade = s
+}
@@ -0,0 +1,130 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TempoTenantSpec defines the desired state of TempoTenant.
+type TempoTenantSpec struct {
+	// TempoStack is the name of the TempoStack in the same namespace which serves this tenant.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TempoStack"
+	TempoStack string `json:"tempoStack"`
+
+	// TenantName defines the name of the tenant.
+	// The value of this field must be specified in the X-Scope-OrgID header.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant Name"
+	TenantName string `json:"tenantName"`
+
+	// Limits defines the ingestion and query limits of the tenant.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Limits"
+	Limits *RateLimitSpec `json:"limits,omitempty"`
+
+	// Retention defines the retention of the traces of the tenant.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retention"
+	Retention *RetentionConfig `json:"retention,omitempty"`
+
+	// Authentication defines the tempo-gateway authentication configuration of the tenant.
+	// Requires multi-tenancy to be enabled in the TempoStack.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Authentication"
+	Authentication *TempoTenantAuthenticationSpec `json:"authentication,omitempty"`
+}
+
+// TempoTenantAuthenticationSpec defines the tempo-gateway authentication configuration of a tenant.
+type TempoTenantAuthenticationSpec struct {
+	// TenantID defines a universally unique identifier of the tenant.
+	// Tempo uses this ID to prefix objects in the object storage.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant ID"
+	TenantID string `json:"tenantId"`
+
+	// OIDC defines the spec for the OIDC tenant's authentication.
+	// Required if the TempoStack uses the static multi-tenancy mode.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Configuration"
+	OIDC *OIDCSpec `json:"oidc,omitempty"`
+}
+
+// TempoTenantConditionType defines the type of a TempoTenant condition.
+type TempoTenantConditionType string
+
+const (
+	// TempoTenantConditionAccepted signals if the tenant configuration is applied to the TempoStack.
+	TempoTenantConditionAccepted TempoTenantConditionType = "Accepted"
+)
+
+const (
+	// ReasonTenantAccepted when the tenant configuration is applied to the TempoStack.
+	ReasonTenantAccepted ConditionReason = "Accepted"
+	// ReasonTempoStackNotFound when the referenced TempoStack does not exist.
+	ReasonTempoStackNotFound ConditionReason = "TempoStackNotFound"
+	// ReasonTenantConflict when the tenant is already configured in the TempoStack or in an older TempoTenant.
+	ReasonTenantConflict ConditionReason = "Conflict"
+	// ReasonTenantInvalidAuthentication when the authentication cannot be applied to the TempoStack.
+	ReasonTenantInvalidAuthentication ConditionReason = "InvalidAuthentication"
+	// ReasonTenantUnsupportedLimits when the limits are not supported by the Tempo version of the TempoStack.
+	ReasonTenantUnsupportedLimits ConditionReason = "UnsupportedLimits"
+)
+
+// TempoTenantStatus defines the observed state of TempoTenant.
+type TempoTenantStatus struct {
+	// Conditions of the tenant.
+	//
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="TempoStack",type="string",JSONPath=".spec.tempoStack",description="TempoStack"
+//+kubebuilder:printcolumn:name="Tenant",type="string",JSONPath=".spec.tenantName",description="Tenant Name"
+//+kubebuilder:printcolumn:name="Accepted",type="string",JSONPath=".status.conditions[?(@.type==\"Accepted\")].status",description="Accepted"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TempoTenant declares the limits, retention and authentication of a single tenant of a TempoStack.
+//
+// +operator-sdk:csv:customresourcedefinitions:displayName="TempoTenant",resources={{ConfigMap,v1},{Secret,v1}}
+//
+//nolint:godot
+type TempoTenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TempoTenantSpec   `json:"spec,omitempty"`
+	Status TempoTenantStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TempoTenantList contains a list of TempoTenant.
+type TempoTenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TempoTenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TempoTenant{}, &TempoTenantList{})
+}
@@ -5,9 +5,12 @@
 package v1alpha1
 
 import (
+	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -59,6 +62,123 @@ func (in *AuthorizationSpec) DeepCopy() *AuthorizationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilization != nil {
+		in, out := &in.TargetCPUUtilization, &out.TargetCPUUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilization != nil {
+		in, out := &in.TargetMemoryUtilization, &out.TargetMemoryUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockTuningSpec) DeepCopyInto(out *BlockTuningSpec) {
+	*out = *in
+	if in.BloomFilterShardSizeBytes != nil {
+		in, out := &in.BloomFilterShardSizeBytes, &out.BloomFilterShardSizeBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockTuningSpec.
+func (in *BlockTuningSpec) DeepCopy() *BlockTuningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockTuningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheSpec) DeepCopyInto(out *CacheSpec) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]CacheRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.Memcached != nil {
+		in, out := &in.Memcached, &out.Memcached
+		*out = new(MemcachedSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RedisSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheSpec.
+func (in *CacheSpec) DeepCopy() *CacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompactionTuningSpec) DeepCopyInto(out *CompactionTuningSpec) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBlockBytes != nil {
+		in, out := &in.MaxBlockBytes, &out.MaxBlockBytes
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompactionTuningSpec.
+func (in *CompactionTuningSpec) DeepCopy() *CompactionTuningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CompactionTuningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 	*out = *in
@@ -158,6 +278,54 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.MetricsGenerator != nil {
+		in, out := &in.MetricsGenerator, &out.MetricsGenerator
+		*out = make(PodStatusMap, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Memcached != nil {
+		in, out := &in.Memcached, &out.Memcached
+		*out = make(PodStatusMap, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.BlockBuilder != nil {
+		in, out := &in.BlockBuilder, &out.BlockBuilder
+		*out = make(PodStatusMap, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -170,6 +338,21 @@ func (in *ComponentStatus) DeepCopy() *ComponentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedColumn) DeepCopyInto(out *DedicatedColumn) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedColumn.
+func (in *DedicatedColumn) DeepCopy() *DedicatedColumn {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedColumn)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtraConfigSpec) DeepCopyInto(out *ExtraConfigSpec) {
 	*out = *in
@@ -186,6 +369,52 @@ func (in *ExtraConfigSpec) DeepCopy() *ExtraConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemStorageSpec) DeepCopyInto(out *FilesystemStorageSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemStorageSpec.
+func (in *FilesystemStorageSpec) DeepCopy() *FilesystemStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayAPISpec) DeepCopyInto(out *GatewayAPISpec) {
+	*out = *in
+	out.ParentRef = in.ParentRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAPISpec.
+func (in *GatewayAPISpec) DeepCopy() *GatewayAPISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAPISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaConfigSpec) DeepCopyInto(out *GrafanaConfigSpec) {
 	*out = *in
@@ -218,6 +447,26 @@ func (in *HashRingSpec) DeepCopy() *HashRingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngestSpec) DeepCopyInto(out *IngestSpec) {
+	*out = *in
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaIngestSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngestSpec.
+func (in *IngestSpec) DeepCopy() *IngestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngestionLimitSpec) DeepCopyInto(out *IngestionLimitSpec) {
 	*out = *in
@@ -241,6 +490,28 @@ func (in *IngestionLimitSpec) DeepCopyInto(out *IngestionLimitSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MaxAttributeBytes != nil {
+		in, out := &in.MaxAttributeBytes, &out.MaxAttributeBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.Forwarders != nil {
+		in, out := &in.Forwarders, &out.Forwarders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetricsGeneratorProcessors != nil {
+		in, out := &in.MetricsGeneratorProcessors, &out.MetricsGeneratorProcessors
+		*out = make([]MetricsGeneratorProcessor, len(*in))
+		copy(*out, *in)
+	}
+	if in.CostAttributionDimensions != nil {
+		in, out := &in.CostAttributionDimensions, &out.CostAttributionDimensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngestionLimitSpec.
@@ -269,6 +540,11 @@ func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
 		**out = **in
 	}
 	out.Route = in.Route
+	if in.GatewayAPI != nil {
+		in, out := &in.GatewayAPI, &out.GatewayAPI
+		*out = new(GatewayAPISpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
@@ -354,6 +630,47 @@ func (in *JaegerQuerySpec) DeepCopy() *JaegerQuerySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaIngestSpec) DeepCopyInto(out *KafkaIngestSpec) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SASL != nil {
+		in, out := &in.SASL, &out.SASL
+		*out = new(KafkaSASLSpec)
+		**out = **in
+	}
+	out.TLS = in.TLS
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaIngestSpec.
+func (in *KafkaIngestSpec) DeepCopy() *KafkaIngestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaIngestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSASLSpec) DeepCopyInto(out *KafkaSASLSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSASLSpec.
+func (in *KafkaSASLSpec) DeepCopy() *KafkaSASLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSASLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LimitSpec) DeepCopyInto(out *LimitSpec) {
 	*out = *in
@@ -377,6 +694,42 @@ func (in *LimitSpec) DeepCopy() *LimitSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowSpec) DeepCopyInto(out *MaintenanceWindowSpec) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowSpec.
+func (in *MaintenanceWindowSpec) DeepCopy() *MaintenanceWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedMemcachedSpec) DeepCopyInto(out *ManagedMemcachedSpec) {
+	*out = *in
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedMemcachedSpec.
+func (in *ManagedMemcachedSpec) DeepCopy() *ManagedMemcachedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedMemcachedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemberListSpec) DeepCopyInto(out *MemberListSpec) {
 	*out = *in
@@ -397,6 +750,23 @@ func (in *MemberListSpec) DeepCopy() *MemberListSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemcachedSpec) DeepCopyInto(out *MemcachedSpec) {
+	*out = *in
+	out.Timeout = in.Timeout
+	in.Managed.DeepCopyInto(&out.Managed)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemcachedSpec.
+func (in *MemcachedSpec) DeepCopy() *MemcachedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MemcachedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsConfigSpec) DeepCopyInto(out *MetricsConfigSpec) {
 	*out = *in
@@ -412,6 +782,28 @@ func (in *MetricsConfigSpec) DeepCopy() *MetricsConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsGeneratorRemoteWriteSpec) DeepCopyInto(out *MetricsGeneratorRemoteWriteSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsGeneratorRemoteWriteSpec.
+func (in *MetricsGeneratorRemoteWriteSpec) DeepCopy() *MetricsGeneratorRemoteWriteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsGeneratorRemoteWriteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonolithicComponentStatus) DeepCopyInto(out *MonolithicComponentStatus) {
 	*out = *in
@@ -528,6 +920,29 @@ func (in *MonolithicIngestionSpec) DeepCopy() *MonolithicIngestionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicJaegerUIGatewayAPISpec) DeepCopyInto(out *MonolithicJaegerUIGatewayAPISpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.ParentRef = in.ParentRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicJaegerUIGatewayAPISpec.
+func (in *MonolithicJaegerUIGatewayAPISpec) DeepCopy() *MonolithicJaegerUIGatewayAPISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicJaegerUIGatewayAPISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonolithicJaegerUIIngressSpec) DeepCopyInto(out *MonolithicJaegerUIIngressSpec) {
 	*out = *in
@@ -600,6 +1015,11 @@ func (in *MonolithicJaegerUISpec) DeepCopyInto(out *MonolithicJaegerUISpec) {
 		*out = new(MonolithicJaegerUIRouteSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GatewayAPI != nil {
+		in, out := &in.GatewayAPI, &out.GatewayAPI
+		*out = new(MonolithicJaegerUIGatewayAPISpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Authentication != nil {
 		in, out := &in.Authentication, &out.Authentication
 		*out = new(JaegerQueryAuthenticationSpec)
@@ -622,6 +1042,69 @@ func (in *MonolithicJaegerUISpec) DeepCopy() *MonolithicJaegerUISpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicMetricsGeneratorRemoteWriteSpec) DeepCopyInto(out *MonolithicMetricsGeneratorRemoteWriteSpec) {
+	*out = *in
+	in.MetricsGeneratorRemoteWriteSpec.DeepCopyInto(&out.MetricsGeneratorRemoteWriteSpec)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSSpec)
+		**out = **in
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(MonolithicRemoteWriteBasicAuthSpec)
+		**out = **in
+	}
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(MonolithicRemoteWriteBearerTokenSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicMetricsGeneratorRemoteWriteSpec.
+func (in *MonolithicMetricsGeneratorRemoteWriteSpec) DeepCopy() *MonolithicMetricsGeneratorRemoteWriteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicMetricsGeneratorRemoteWriteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicMetricsGeneratorSpec) DeepCopyInto(out *MonolithicMetricsGeneratorSpec) {
+	*out = *in
+	if in.Processors != nil {
+		in, out := &in.Processors, &out.Processors
+		*out = make([]MetricsGeneratorProcessor, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]MonolithicMetricsGeneratorRemoteWriteSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicMetricsGeneratorSpec.
+func (in *MonolithicMetricsGeneratorSpec) DeepCopy() *MonolithicMetricsGeneratorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicMetricsGeneratorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonolithicMultitenancySpec) DeepCopyInto(out *MonolithicMultitenancySpec) {
 	*out = *in
@@ -779,6 +1262,36 @@ func (in *MonolithicQuerySpec) DeepCopy() *MonolithicQuerySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicRemoteWriteBasicAuthSpec) DeepCopyInto(out *MonolithicRemoteWriteBasicAuthSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicRemoteWriteBasicAuthSpec.
+func (in *MonolithicRemoteWriteBasicAuthSpec) DeepCopy() *MonolithicRemoteWriteBasicAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicRemoteWriteBasicAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicRemoteWriteBearerTokenSpec) DeepCopyInto(out *MonolithicRemoteWriteBearerTokenSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicRemoteWriteBearerTokenSpec.
+func (in *MonolithicRemoteWriteBearerTokenSpec) DeepCopy() *MonolithicRemoteWriteBearerTokenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicRemoteWriteBearerTokenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonolithicSchedulerSpec) DeepCopyInto(out *MonolithicSchedulerSpec) {
 	*out = *in
@@ -817,6 +1330,16 @@ func (in *MonolithicSchedulerSpec) DeepCopy() *MonolithicSchedulerSpec {
 func (in *MonolithicStorageSpec) DeepCopyInto(out *MonolithicStorageSpec) {
 	*out = *in
 	in.Traces.DeepCopyInto(&out.Traces)
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(StorageTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DedicatedColumns != nil {
+		in, out := &in.DedicatedColumns, &out.DedicatedColumns
+		*out = make([]DedicatedColumn, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicStorageSpec.
@@ -905,6 +1428,49 @@ func (in *MonolithicTracesStorageSpec) DeepCopy() *MonolithicTracesStorageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+	if in.Ingestion != nil {
+		in, out := &in.Ingestion, &out.Ingestion
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDCSpec) DeepCopyInto(out *OIDCSpec) {
 	*out = *in
@@ -945,6 +1511,26 @@ func (in *ObjectStorageSpec) DeepCopyInto(out *ObjectStorageSpec) {
 	*out = *in
 	out.TLS = in.TLS
 	out.Secret = in.Secret
+	if in.Filesystem != nil {
+		in, out := &in.Filesystem, &out.Filesystem
+		*out = new(FilesystemStorageSpec)
+		**out = **in
+	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(StorageTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DedicatedColumns != nil {
+		in, out := &in.DedicatedColumns, &out.DedicatedColumns
+		*out = make([]DedicatedColumn, len(*in))
+		copy(*out, *in)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(StorageMigrationSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageSpec.
@@ -975,6 +1561,31 @@ func (in *ObservabilitySpec) DeepCopy() *ObservabilitySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in PodStatusMap) DeepCopyInto(out *PodStatusMap) {
 	{
@@ -1019,6 +1630,12 @@ func (in *QueryLimit) DeepCopyInto(out *QueryLimit) {
 		**out = **in
 	}
 	out.MaxSearchDuration = in.MaxSearchDuration
+	out.MaxMetricsDuration = in.MaxMetricsDuration
+	if in.UnsafeQueryHints != nil {
+		in, out := &in.UnsafeQueryHints, &out.UnsafeQueryHints
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryLimit.
@@ -1063,6 +1680,22 @@ func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisSpec) DeepCopyInto(out *RedisSpec) {
+	*out = *in
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisSpec.
+func (in *RedisSpec) DeepCopy() *RedisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resources) DeepCopyInto(out *Resources) {
 	*out = *in
@@ -1193,22 +1826,98 @@ func (in *RouteSpec) DeepCopy() *RouteSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SearchSpec) DeepCopyInto(out *SearchSpec) {
+func (in *RuntimeOverridesStatus) DeepCopyInto(out *RuntimeOverridesStatus) {
+	*out = *in
+	if in.ObservedTime != nil {
+		in, out := &in.ObservedTime, &out.ObservedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingPods != nil {
+		in, out := &in.PendingPods, &out.PendingPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeOverridesStatus.
+func (in *RuntimeOverridesStatus) DeepCopy() *RuntimeOverridesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeOverridesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchSpec) DeepCopyInto(out *SearchSpec) {
+	*out = *in
+	if in.DefaultResultLimit != nil {
+		in, out := &in.DefaultResultLimit, &out.DefaultResultLimit
+		*out = new(int)
+		**out = **in
+	}
+	out.MaxDuration = in.MaxDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchSpec.
+func (in *SearchSpec) DeepCopy() *SearchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageMigrationSpec) DeepCopyInto(out *StorageMigrationSpec) {
+	*out = *in
+	out.Source = in.Source
+	out.SourceTLS = in.SourceTLS
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationSpec.
+func (in *StorageMigrationSpec) DeepCopy() *StorageMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageTuningSpec) DeepCopyInto(out *StorageTuningSpec) {
 	*out = *in
-	if in.DefaultResultLimit != nil {
-		in, out := &in.DefaultResultLimit, &out.DefaultResultLimit
-		*out = new(int)
+	if in.BlocklistPoll != nil {
+		in, out := &in.BlocklistPoll, &out.BlocklistPoll
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Block != nil {
+		in, out := &in.Block, &out.Block
+		*out = new(BlockTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Compaction != nil {
+		in, out := &in.Compaction, &out.Compaction
+		*out = new(CompactionTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WAL != nil {
+		in, out := &in.WAL, &out.WAL
+		*out = new(WALTuningSpec)
 		**out = **in
 	}
-	out.MaxDuration = in.MaxDuration
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchSpec.
-func (in *SearchSpec) DeepCopy() *SearchSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageTuningSpec.
+func (in *StorageTuningSpec) DeepCopy() *StorageTuningSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SearchSpec)
+	out := new(StorageTuningSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1275,6 +1984,23 @@ func (in *TempoComponentSpec) DeepCopyInto(out *TempoComponentSpec) {
 		*out = new(v1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoComponentSpec.
@@ -1291,6 +2017,11 @@ func (in *TempoComponentSpec) DeepCopy() *TempoComponentSpec {
 func (in *TempoDistributorSpec) DeepCopyInto(out *TempoDistributorSpec) {
 	*out = *in
 	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	out.TLS = in.TLS
 }
 
@@ -1322,6 +2053,39 @@ func (in *TempoGatewaySpec) DeepCopy() *TempoGatewaySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoMetricsGeneratorSpec) DeepCopyInto(out *TempoMetricsGeneratorSpec) {
+	*out = *in
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	if in.Processors != nil {
+		in, out := &in.Processors, &out.Processors
+		*out = make([]MetricsGeneratorProcessor, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]MetricsGeneratorRemoteWriteSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StorageSize != nil {
+		in, out := &in.StorageSize, &out.StorageSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoMetricsGeneratorSpec.
+func (in *TempoMetricsGeneratorSpec) DeepCopy() *TempoMetricsGeneratorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoMetricsGeneratorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TempoMonolithic) DeepCopyInto(out *TempoMonolithic) {
 	*out = *in
@@ -1425,11 +2189,36 @@ func (in *TempoMonolithicSpec) DeepCopyInto(out *TempoMonolithicSpec) {
 		*out = new(ExtraConfigSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Query != nil {
 		in, out := &in.Query, &out.Query
 		*out = new(MonolithicQuerySpec)
 		**out = **in
 	}
+	if in.MetricsGenerator != nil {
+		in, out := &in.MetricsGenerator, &out.MetricsGenerator
+		*out = new(MonolithicMetricsGeneratorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(RetentionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(LimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(UpgradePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.MonolithicSchedulerSpec.DeepCopyInto(&out.MonolithicSchedulerSpec)
 }
 
@@ -1454,6 +2243,11 @@ func (in *TempoMonolithicStatus) DeepCopyInto(out *TempoMonolithicStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoMonolithicStatus.
@@ -1466,10 +2260,36 @@ func (in *TempoMonolithicStatus) DeepCopy() *TempoMonolithicStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoQuerierSpec) DeepCopyInto(out *TempoQuerierSpec) {
+	*out = *in
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoQuerierSpec.
+func (in *TempoQuerierSpec) DeepCopy() *TempoQuerierSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoQuerierSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TempoQueryFrontendSpec) DeepCopyInto(out *TempoQueryFrontendSpec) {
 	*out = *in
 	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.JaegerQuery.DeepCopyInto(&out.JaegerQuery)
 }
 
@@ -1575,7 +2395,7 @@ func (in *TempoStackSpec) DeepCopyInto(out *TempoStackSpec) {
 	in.Resources.DeepCopyInto(&out.Resources)
 	out.StorageSize = in.StorageSize.DeepCopy()
 	out.Images = in.Images
-	out.Storage = in.Storage
+	in.Storage.DeepCopyInto(&out.Storage)
 	in.Retention.DeepCopyInto(&out.Retention)
 	in.SearchSpec.DeepCopyInto(&out.SearchSpec)
 	in.HashRing.DeepCopyInto(&out.HashRing)
@@ -1591,6 +2411,31 @@ func (in *TempoStackSpec) DeepCopyInto(out *TempoStackSpec) {
 		*out = new(ExtraConfigSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(CacheSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingest != nil {
+		in, out := &in.Ingest, &out.Ingest
+		*out = new(IngestSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZoneAwareness != nil {
+		in, out := &in.ZoneAwareness, &out.ZoneAwareness
+		*out = new(ZoneAwarenessSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(UpgradePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoStackSpec.
@@ -1614,6 +2459,21 @@ func (in *TempoStackStatus) DeepCopyInto(out *TempoStackStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RuntimeOverrides != nil {
+		in, out := &in.RuntimeOverrides, &out.RuntimeOverrides
+		*out = new(RuntimeOverridesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DedicatedColumns != nil {
+		in, out := &in.DedicatedColumns, &out.DedicatedColumns
+		*out = make([]DedicatedColumn, len(*in))
+		copy(*out, *in)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoStackStatus.
@@ -1635,6 +2495,8 @@ func (in *TempoTemplateSpec) DeepCopyInto(out *TempoTemplateSpec) {
 	in.Querier.DeepCopyInto(&out.Querier)
 	in.QueryFrontend.DeepCopyInto(&out.QueryFrontend)
 	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.MetricsGenerator.DeepCopyInto(&out.MetricsGenerator)
+	in.BlockBuilder.DeepCopyInto(&out.BlockBuilder)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoTemplateSpec.
@@ -1647,6 +2509,137 @@ func (in *TempoTemplateSpec) DeepCopy() *TempoTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoTenant) DeepCopyInto(out *TempoTenant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoTenant.
+func (in *TempoTenant) DeepCopy() *TempoTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TempoTenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoTenantAuthenticationSpec) DeepCopyInto(out *TempoTenantAuthenticationSpec) {
+	*out = *in
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoTenantAuthenticationSpec.
+func (in *TempoTenantAuthenticationSpec) DeepCopy() *TempoTenantAuthenticationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoTenantAuthenticationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoTenantList) DeepCopyInto(out *TempoTenantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TempoTenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoTenantList.
+func (in *TempoTenantList) DeepCopy() *TempoTenantList {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoTenantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TempoTenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoTenantSpec) DeepCopyInto(out *TempoTenantSpec) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(RetentionConfig)
+		**out = **in
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(TempoTenantAuthenticationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoTenantSpec.
+func (in *TempoTenantSpec) DeepCopy() *TempoTenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoTenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoTenantStatus) DeepCopyInto(out *TempoTenantStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoTenantStatus.
+func (in *TempoTenantStatus) DeepCopy() *TempoTenantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoTenantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantSecretSpec) DeepCopyInto(out *TenantSecretSpec) {
 	*out = *in
@@ -1703,3 +2696,93 @@ func (in *TracingConfigSpec) DeepCopy() *TracingConfigSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePolicySpec) DeepCopyInto(out *UpgradePolicySpec) {
+	*out = *in
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePolicySpec.
+func (in *UpgradePolicySpec) DeepCopy() *UpgradePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WALTuningSpec) DeepCopyInto(out *WALTuningSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WALTuningSpec.
+func (in *WALTuningSpec) DeepCopy() *WALTuningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WALTuningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneAwarenessSpec) DeepCopyInto(out *ZoneAwarenessSpec) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAwarenessSpec.
+func (in *ZoneAwarenessSpec) DeepCopy() *ZoneAwarenessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneAwarenessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneSpec) DeepCopyInto(out *ZoneSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSpec.
+func (in *ZoneSpec) DeepCopy() *ZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,133 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/desiredstate"
+	"github.com/grafana/tempo-operator/internal/handlers/storage"
+	"github.com/grafana/tempo-operator/internal/manifests/storagemigration"
+	"github.com/grafana/tempo-operator/internal/webhooks"
+)
+
+// buildDesiredState builds the objects of a TempoStack or TempoMonolithic CR the same way as the operator.
+// The storage secrets, gateway tenant secrets, TempoTenants and the objects of the CR are fetched with the client.
+func buildDesiredState(ctx context.Context, c client.Client, scheme *runtime.Scheme, ctrlConfig configv1alpha1.ProjectConfig, cr client.Object) (desiredstate.State, error) {
+	opts := desiredstate.Options{
+		CtrlConfig: &ctrlConfig,
+	}
+
+	var (
+		ccoState, state desiredstate.State
+		err             error
+	)
+	switch tempo := cr.(type) {
+	case *v1alpha1.TempoStack:
+		// apply default values from Defaulter webhook
+		defaulterWebhook := webhooks.NewDefaulter(ctrlConfig)
+		err = defaulterWebhook.Default(ctx, tempo)
+		if err != nil {
+			return desiredstate.State{}, err
+		}
+		if ctrlConfig.Gates.StoragePreflight {
+			opts.StoragePreflight = storage.NewPreflight(c, storage.NewS3ObjectStorage)
+		}
+
+		ccoState, err = desiredstate.TempoStackCredentialsRequests(ctx, c, *tempo)
+		if err != nil {
+			return desiredstate.State{}, err
+		}
+		state, err = desiredstate.TempoStack(ctx, c, opts, *tempo)
+		if err != nil {
+			return desiredstate.State{}, err
+		}
+		if tempo.Spec.Storage.Migration == nil {
+			// The Jobs of a previous storage migration are deleted.
+			jobs, err := listStorageMigrationJobs(ctx, c, *tempo)
+			if err != nil {
+				return desiredstate.State{}, err
+			}
+			state.OwnedObjects = append(state.OwnedObjects, jobs...)
+		}
+
+	case *v1alpha1.TempoMonolithic:
+		tempo.Default(ctrlConfig)
+
+		ccoState, err = desiredstate.TempoMonolithicCredentialsRequests(ctx, c, *tempo)
+		if err != nil {
+			return desiredstate.State{}, err
+		}
+		state, err = desiredstate.TempoMonolithic(ctx, c, opts, *tempo)
+		if err != nil {
+			return desiredstate.State{}, err
+		}
+
+	default:
+		return desiredstate.State{}, fmt.Errorf("unsupported kind %s, expected TempoStack or TempoMonolithic", cr.GetObjectKind().GroupVersionKind().Kind)
+	}
+
+	state.Objects = append(ccoState.Objects, state.Objects...)
+	state.OwnedObjects = append(ccoState.OwnedObjects, state.OwnedObjects...)
+
+	err = setControllerReferences(ctx, c, scheme, cr, state.Objects)
+	if err != nil {
+		return desiredstate.State{}, err
+	}
+	return state, nil
+}
+
+// listStorageMigrationJobs lists the Jobs of the storage migration of a TempoStack in the cluster.
+func listStorageMigrationJobs(ctx context.Context, c client.Client, tempo v1alpha1.TempoStack) ([]client.Object, error) {
+	jobs := &batchv1.JobList{}
+	err := c.List(ctx, jobs, &client.ListOptions{
+		Namespace:     tempo.Namespace,
+		LabelSelector: labels.SelectorFromSet(storagemigration.Labels(tempo.Name)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing storage migration jobs: %w", err)
+	}
+
+	objects := make([]client.Object, len(jobs.Items))
+	for i := range jobs.Items {
+		objects[i] = &jobs.Items[i]
+	}
+	return objects, nil
+}
+
+// setControllerReferences sets the owner references of the objects to the CR in the cluster.
+// If the CR doesn't exist yet or has no UID, the owner references are not compared.
+func setControllerReferences(ctx context.Context, c client.Client, scheme *runtime.Scheme, cr client.Object, objects []client.Object) error {
+	owner := cr.DeepCopyObject().(client.Object)
+	err := c.Get(ctx, client.ObjectKeyFromObject(cr), owner)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting %s: %w", cr.GetName(), err)
+	}
+	if owner.GetUID() == "" {
+		// the objects of an offline directory might not have UIDs
+		return nil
+	}
+
+	for _, obj := range objects {
+		if obj.GetNamespace() == "" {
+			// cluster-scoped objects can't have a namespaced owner
+			continue
+		}
+		if err := ctrl.SetControllerReference(owner, obj, scheme); err != nil {
+			return err
+		}
+	}
+	return nil
+}
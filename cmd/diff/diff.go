@@ -0,0 +1,310 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/grafana/tempo-operator/internal/desiredstate"
+	"github.com/grafana/tempo-operator/internal/manifests"
+	"github.com/grafana/tempo-operator/internal/manifests/storagemigration"
+)
+
+type action string
+
+const (
+	actionCreate    action = "create"
+	actionUpdate    action = "update"
+	actionRecreate  action = "recreate"
+	actionDelete    action = "delete"
+	actionUnchanged action = "unchanged"
+)
+
+var actionSymbols = map[action]string{
+	actionCreate:   "+",
+	actionUpdate:   "~",
+	actionRecreate: "!",
+	actionDelete:   "-",
+}
+
+// objectDiff is the change the operator would apply to a single object.
+type objectDiff struct {
+	gvk    schema.GroupVersionKind
+	key    client.ObjectKey
+	action action
+	// diff is the diff between the live object and the live object updated by the operator.
+	diff string
+	// rollout contains the changed fields of the pod template, which trigger a rollout of the pods.
+	rollout []string
+	// reason explains why the object is recreated.
+	reason string
+}
+
+// diffState compares the desired state of a CR with the objects in the cluster.
+func diffState(ctx context.Context, c client.Client, scheme *runtime.Scheme, state desiredstate.State) ([]objectDiff, error) {
+	diffs := []objectDiff{}
+	desiredKeys := map[string]bool{}
+
+	for _, obj := range state.Objects {
+		d, err := diffObject(ctx, c, scheme, obj)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, d)
+		desiredKeys[objectID(d.gvk, d.key)] = true
+	}
+
+	for _, job := range state.StorageMigrationJobs {
+		d, err := diffStorageMigrationJob(ctx, c, scheme, job)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, d)
+		desiredKeys[objectID(d.gvk, d.key)] = true
+	}
+
+	// Objects in the cluster which are not managed anymore are pruned.
+	for _, obj := range state.OwnedObjects {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, err
+		}
+		key := client.ObjectKeyFromObject(obj)
+		if desiredKeys[objectID(gvk, key)] {
+			continue
+		}
+		diffs = append(diffs, objectDiff{gvk: gvk, key: key, action: actionDelete})
+	}
+
+	return diffs, nil
+}
+
+// diffStorageMigrationJob compares the checksum of the storage configuration of a storage migration Job.
+// The operator creates the Job once, and recreates it if the storage configuration changed.
+func diffStorageMigrationJob(ctx context.Context, c client.Client, scheme *runtime.Scheme, job *batchv1.Job) (objectDiff, error) {
+	gvk, err := apiutil.GVKForObject(job, scheme)
+	if err != nil {
+		return objectDiff{}, err
+	}
+	d := objectDiff{
+		gvk: gvk,
+		key: client.ObjectKeyFromObject(job),
+	}
+
+	live := &batchv1.Job{}
+	err = c.Get(ctx, d.key, live)
+	if apierrors.IsNotFound(err) {
+		d.action = actionCreate
+		return d, nil
+	}
+	if err != nil {
+		return objectDiff{}, fmt.Errorf("error getting %s %s: %w", gvk.Kind, d.key, err)
+	}
+
+	if live.Annotations[storagemigration.ChecksumAnnotation] == job.Annotations[storagemigration.ChecksumAnnotation] {
+		d.action = actionUnchanged
+		return d, nil
+	}
+	d.action = actionRecreate
+	d.reason = "the storage configuration of the storage migration changed"
+	return d, nil
+}
+
+// diffObject applies the same mutate function as the operator to the live object,
+// and compares the result with the live object.
+func diffObject(ctx context.Context, c client.Client, scheme *runtime.Scheme, desiredObj client.Object) (objectDiff, error) {
+	gvk, err := apiutil.GVKForObject(desiredObj, scheme)
+	if err != nil {
+		return objectDiff{}, err
+	}
+	d := objectDiff{
+		gvk: gvk,
+		key: client.ObjectKeyFromObject(desiredObj),
+	}
+
+	obj := desiredObj.DeepCopyObject().(client.Object)
+	desired := desiredObj.DeepCopyObject().(client.Object)
+	err = c.Get(ctx, d.key, obj)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		d.action = actionCreate
+		return d, nil
+	}
+	if err != nil {
+		return objectDiff{}, fmt.Errorf("error getting %s %s: %w", gvk.Kind, d.key, err)
+	}
+
+	live := obj.DeepCopyObject().(client.Object)
+	err = manifests.MutateFuncFor(obj, desired)()
+	var immutableErr *manifests.ImmutableErr
+	if errors.As(err, &immutableErr) {
+		d.action = actionRecreate
+		d.reason = immutableErr.Error()
+		return d, nil
+	}
+	if err != nil {
+		return objectDiff{}, fmt.Errorf("error updating %s %s: %w", gvk.Kind, d.key, err)
+	}
+
+	if equality.Semantic.DeepEqual(live, obj) {
+		d.action = actionUnchanged
+		return d, nil
+	}
+
+	liveFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return objectDiff{}, err
+	}
+	updatedFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return objectDiff{}, err
+	}
+
+	d.action = actionUpdate
+	d.diff, err = unifiedDiff(liveFields, updatedFields)
+	if err != nil {
+		return objectDiff{}, err
+	}
+	switch obj.(type) {
+	case *appsv1.Deployment, *appsv1.StatefulSet, *appsv1.DaemonSet:
+		d.rollout = changedFields(
+			nestedField(liveFields, "spec", "template"),
+			nestedField(updatedFields, "spec", "template"),
+			"spec.template",
+		)
+	}
+	return d, nil
+}
+
+// unifiedDiff returns the line-based diff of the YAML representation of the objects.
+func unifiedDiff(live, updated map[string]interface{}) (string, error) {
+	liveYAML, err := yaml.Marshal(live)
+	if err != nil {
+		return "", err
+	}
+	updatedYAML, err := yaml.Marshal(updated)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(liveYAML)),
+		B:        difflib.SplitLines(string(updatedYAML)),
+		FromFile: "live",
+		ToFile:   "updated",
+		Context:  3,
+	})
+}
+
+func objectID(gvk schema.GroupVersionKind, key client.ObjectKey) string {
+	return gvk.GroupKind().String() + "/" + key.String()
+}
+
+func nestedField(obj map[string]interface{}, fields ...string) interface{} {
+	var current interface{} = obj
+	for _, f := range fields {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[f]
+	}
+	return current
+}
+
+var simpleFieldName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// changedFields returns the paths of all fields which differ between a and b.
+func changedFields(a, b interface{}, path string) []string {
+	aMap, aIsMap := a.(map[string]interface{})
+	bMap, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		keys := map[string]bool{}
+		for k := range aMap {
+			keys[k] = true
+		}
+		for k := range bMap {
+			keys[k] = true
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for k := range keys {
+			sortedKeys = append(sortedKeys, k)
+		}
+		sort.Strings(sortedKeys)
+
+		fields := []string{}
+		for _, k := range sortedKeys {
+			childPath := fmt.Sprintf("%s[%q]", path, k)
+			if simpleFieldName.MatchString(k) {
+				childPath = path + "." + k
+			}
+			fields = append(fields, changedFields(aMap[k], bMap[k], childPath)...)
+		}
+		return fields
+	}
+
+	aList, aIsList := a.([]interface{})
+	bList, bIsList := b.([]interface{})
+	if aIsList && bIsList && len(aList) == len(bList) {
+		fields := []string{}
+		for i := range aList {
+			fields = append(fields, changedFields(aList[i], bList[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return fields
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	return []string{path}
+}
+
+// printDiffs prints the changes of the objects of a CR, followed by a summary.
+func printDiffs(out io.Writer, owner string, diffs []objectDiff) error {
+	counts := map[action]int{}
+	var sb strings.Builder
+	for _, d := range diffs {
+		counts[d.action]++
+		if d.action == actionUnchanged {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "%s %s %s\n", actionSymbols[d.action], d.gvk.Kind, d.key)
+		if d.reason != "" {
+			fmt.Fprintf(&sb, "  the object will be deleted and re-created: %s\n", d.reason)
+		}
+		if len(d.rollout) > 0 {
+			sb.WriteString("  rollout caused by:\n")
+			for _, field := range d.rollout {
+				fmt.Fprintf(&sb, "    %s\n", field)
+			}
+		}
+		if d.diff != "" {
+			for _, line := range strings.Split(strings.TrimRight(d.diff, "\n"), "\n") {
+				fmt.Fprintf(&sb, "  %s\n", line)
+			}
+		}
+	}
+
+	fmt.Fprintf(&sb, "%s: %d to create, %d to update, %d to recreate, %d to delete, %d unchanged\n",
+		owner, counts[actionCreate], counts[actionUpdate], counts[actionRecreate], counts[actionDelete], counts[actionUnchanged])
+
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
@@ -0,0 +1,210 @@
+package diff
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/cmd/root"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/storagemigration"
+)
+
+func TestChangedFields(t *testing.T) {
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				"tempo.grafana.com/config.hash": "a",
+			},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"image": "tempo:1", "name": "tempo"},
+			},
+		},
+	}
+	updated := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				"tempo.grafana.com/config.hash": "b",
+			},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"image": "tempo:2", "name": "tempo"},
+			},
+			"nodeSelector": map[string]interface{}{"zone": "a"},
+		},
+	}
+
+	require.Equal(t, []string{
+		`spec.template.metadata.annotations["tempo.grafana.com/config.hash"]`,
+		"spec.template.spec.containers[0].image",
+		"spec.template.spec.nodeSelector",
+	}, changedFields(live, updated, "spec.template"))
+	require.Empty(t, changedFields(live, live, "spec.template"))
+}
+
+func TestDiffState(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+
+	ctrlConfig := configv1alpha1.ProjectConfig{
+		DefaultImages: configv1alpha1.ImagesSpec{
+			Tempo:      "docker.io/grafana/tempo:x.y.z",
+			TempoQuery: "docker.io/grafana/tempo-query:x.y.z",
+		},
+		Gates: configv1alpha1.FeatureGates{
+			TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+		},
+	}
+	storageSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "minio",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"endpoint":          []byte("http://minio:9000"),
+			"bucket":            []byte("tempo"),
+			"access_key_id":     []byte("id"),
+			"access_key_secret": []byte("secret"),
+		},
+	}
+	tempo := &v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "minio",
+					Type: v1alpha1.ObjectStorageSecretS3,
+				},
+			},
+		},
+	}
+
+	// build the objects of the current CR
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(storageSecret).Build()
+	state, err := buildDesiredState(context.Background(), c, scheme, ctrlConfig, tempo)
+	require.NoError(t, err)
+	for _, obj := range state.Objects {
+		require.NoError(t, c.Create(context.Background(), obj))
+	}
+
+	// a Service which is not managed by the operator anymore
+	orphan := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-simplest-orphan",
+			Namespace: "default",
+			Labels:    manifestutils.CommonLabels("simplest"),
+		},
+	}
+	require.NoError(t, c.Create(context.Background(), orphan))
+
+	// change the Tempo image
+	ctrlConfig.DefaultImages.Tempo = "docker.io/grafana/tempo:x.y.z-1"
+
+	state, err = buildDesiredState(context.Background(), c, scheme, ctrlConfig, tempo)
+	require.NoError(t, err)
+	diffs, err := diffState(context.Background(), c, scheme, state)
+	require.NoError(t, err)
+
+	actions := map[string]action{}
+	for _, d := range diffs {
+		actions[d.gvk.Kind+" "+d.key.Name] = d.action
+	}
+	require.Equal(t, actionUnchanged, actions["ConfigMap tempo-simplest"])
+	require.Equal(t, actionUpdate, actions["Deployment tempo-simplest-querier"])
+	require.Equal(t, actionUpdate, actions["StatefulSet tempo-simplest-ingester"])
+	require.Equal(t, actionDelete, actions["Service tempo-simplest-orphan"])
+
+	for _, d := range diffs {
+		if d.gvk.Kind == "Deployment" && d.key.Name == "tempo-simplest-querier" {
+			require.Equal(t, []string{"spec.template.spec.containers[0].image"}, d.rollout)
+			require.Contains(t, d.diff, "-        image: docker.io/grafana/tempo:x.y.z\n")
+			require.Contains(t, d.diff, "+        image: docker.io/grafana/tempo:x.y.z-1\n")
+		}
+	}
+
+	out := &strings.Builder{}
+	require.NoError(t, printDiffs(out, "TempoStack default/simplest", diffs))
+	require.Contains(t, out.String(), `~ Deployment default/tempo-simplest-querier
+  rollout caused by:
+    spec.template.spec.containers[0].image
+`)
+	require.Contains(t, out.String(), "- Service default/tempo-simplest-orphan\n")
+	require.Contains(t, out.String(), "TempoStack default/simplest: 0 to create, 5 to update, 0 to recreate, 1 to delete, 15 unchanged\n")
+}
+
+func TestDiffObjectCreate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	d, err := diffObject(context.Background(), c, scheme, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "tempo", Namespace: "default"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, actionCreate, d.action)
+	require.Equal(t, client.ObjectKey{Name: "tempo", Namespace: "default"}, d.key)
+}
+
+func TestDiffStorageMigrationJob(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tempo-simplest-storage-migration-copy",
+			Namespace:   "default",
+			Annotations: map[string]string{storagemigration.ChecksumAnnotation: "a"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	d, err := diffStorageMigrationJob(context.Background(), c, scheme, job)
+	require.NoError(t, err)
+	require.Equal(t, actionCreate, d.action)
+
+	require.NoError(t, c.Create(context.Background(), job.DeepCopy()))
+	d, err = diffStorageMigrationJob(context.Background(), c, scheme, job)
+	require.NoError(t, err)
+	require.Equal(t, actionUnchanged, d.action)
+
+	job.Annotations[storagemigration.ChecksumAnnotation] = "b"
+	d, err = diffStorageMigrationJob(context.Background(), c, scheme, job)
+	require.NoError(t, err)
+	require.Equal(t, actionRecreate, d.action)
+	require.Equal(t, "the storage configuration of the storage migration changed", d.reason)
+}
+
+func TestDiffCmdOffline(t *testing.T) {
+	c := root.NewRootCommand()
+	c.AddCommand(NewDiffCommand())
+
+	out := &strings.Builder{}
+	c.SetOut(out)
+	c.SetErr(out)
+
+	c.SetArgs([]string{"diff", "--cr", "testdata/cr.yaml", "--live-dir", "testdata/live", "--namespace", "observability"})
+	_, err := c.ExecuteC()
+	require.NoError(t, err)
+
+	require.Contains(t, out.String(), "+ StatefulSet observability/tempo-sample\n")
+	require.Contains(t, out.String(), "TempoMonolithic observability/sample: 4 to create, 0 to update, 0 to recreate, 0 to delete, 0 unchanged\n")
+}
@@ -0,0 +1,209 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/cmd/generate"
+	"github.com/grafana/tempo-operator/cmd/root"
+)
+
+var log = ctrl.Log.WithName("diff")
+
+// NewClusterClient returns a client for the cluster of the kubeconfig, and the namespace of the current context.
+func NewClusterClient(scheme *runtime.Scheme, kubeconfigPath string) (client.Client, string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfigPath
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create Kubernetes client config: %w", err)
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, "", err
+	}
+
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, "", fmt.Errorf("creating the Kubernetes client: %w", err)
+	}
+	return c, namespace, nil
+}
+
+// newOfflineClient returns an in-memory client, which contains the objects of all YAML files of a directory.
+func newOfflineClient(scheme *runtime.Scheme, dir string) (client.Client, error) {
+	files, err := filepath.Glob(filepath.Join(filepath.Clean(dir), "*.y*ml"))
+	if err != nil {
+		return nil, err
+	}
+	jsonFiles, err := filepath.Glob(filepath.Join(filepath.Clean(dir), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, jsonFiles...)
+
+	objects := []client.Object{}
+	for _, file := range files {
+		fileObjects, err := loadFile(scheme, file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", file, err)
+		}
+		objects = append(objects, fileObjects...)
+	}
+
+	for _, obj := range objects {
+		// stringData is merged into data by the API server
+		if secret, ok := obj.(*corev1.Secret); ok {
+			if len(secret.StringData) > 0 && secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			for k, v := range secret.StringData {
+				secret.Data[k] = []byte(v)
+			}
+		}
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), nil
+}
+
+func loadFile(scheme *runtime.Scheme, path string) ([]client.Object, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Error(err, "error closing file", "path", path)
+		}
+	}()
+
+	return generate.LoadObjects(scheme, file)
+}
+
+func diff(c *cobra.Command, k8sclient client.Client, namespace string, crReader io.Reader) error {
+	rootCmdConfig := c.Context().Value(root.RootConfigKey{}).(root.RootConfig)
+	scheme := rootCmdConfig.Options.Scheme
+
+	crs, err := generate.LoadObjects(scheme, crReader)
+	if err != nil {
+		return fmt.Errorf("error loading spec: %w", err)
+	}
+
+	found := false
+	for _, cr := range crs {
+		switch cr.(type) {
+		case *v1alpha1.TempoStack, *v1alpha1.TempoMonolithic:
+		default:
+			continue
+		}
+		found = true
+
+		if cr.GetNamespace() == "" {
+			cr.SetNamespace(namespace)
+		}
+		gvk, err := apiutil.GVKForObject(cr, scheme)
+		if err != nil {
+			return err
+		}
+		owner := fmt.Sprintf("%s %s", gvk.Kind, client.ObjectKeyFromObject(cr))
+
+		state, err := buildDesiredState(c.Context(), k8sclient, scheme, rootCmdConfig.CtrlConfig, cr)
+		if err != nil {
+			return fmt.Errorf("error building manifests of %s: %w", owner, err)
+		}
+		diffs, err := diffState(c.Context(), k8sclient, scheme, state)
+		if err != nil {
+			return fmt.Errorf("error comparing manifests of %s: %w", owner, err)
+		}
+		err = printDiffs(c.OutOrStdout(), owner, diffs)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("no TempoStack or TempoMonolithic found")
+	}
+	return nil
+}
+
+// NewDiffCommand returns a new diff command.
+func NewDiffCommand() *cobra.Command {
+	var crPath string
+	var kubeconfigPath string
+	var liveDir string
+	var namespace string
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the changes the operator would apply to the objects of a Tempo CR",
+		Long: "Show the changes the operator would apply to the objects of a TempoStack or TempoMonolithic CR.\n\n" +
+			"The desired objects are compared with the live objects of the cluster, or with the objects " +
+			"of a directory of YAML files (--live-dir). The changes of Deployments and StatefulSets list " +
+			"the fields of the pod template which cause a rollout of the pods.\n\n" +
+			"The desired objects are built the same way as the operator, including storage migrations, " +
+			"deferred ingester zone updates and, if the storagePreflight feature gate is enabled, the storage preflight check.",
+		RunE: func(c *cobra.Command, args []string) error {
+			rootCmdConfig := c.Context().Value(root.RootConfigKey{}).(root.RootConfig)
+			scheme := rootCmdConfig.Options.Scheme
+
+			var k8sclient client.Client
+			defaultNamespace := "default"
+			if liveDir != "" {
+				var err error
+				k8sclient, err = newOfflineClient(scheme, liveDir)
+				if err != nil {
+					return err
+				}
+			} else {
+				var err error
+				k8sclient, defaultNamespace, err = NewClusterClient(scheme, kubeconfigPath)
+				if err != nil {
+					return err
+				}
+			}
+			if namespace != "" {
+				defaultNamespace = namespace
+			}
+
+			var crReader io.Reader
+			if crPath == "/dev/stdin" {
+				log.Info("reading from stdin")
+				crReader = c.InOrStdin()
+			} else {
+				pathCleaned := filepath.Clean(crPath)
+				file, err := os.Open(pathCleaned)
+				if err != nil {
+					return fmt.Errorf("error reading cr: %w", err)
+				}
+				crReader = file
+				defer func() {
+					if err := file.Close(); err != nil {
+						log.Error(err, "error closing file", "path", pathCleaned)
+					}
+				}()
+			}
+
+			return diff(c, k8sclient, defaultNamespace, crReader)
+		},
+	}
+	cmd.Flags().StringVar(&crPath, "cr", "/dev/stdin", "Input CRs")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to the kubeconfig file (defaults to $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVar(&liveDir, "live-dir", "", "Directory of YAML files with the live objects, Secrets and ConfigMaps, instead of a cluster")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of CRs without a namespace (defaults to the namespace of the kubeconfig context)")
+	return cmd
+}
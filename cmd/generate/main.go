@@ -3,6 +3,7 @@ package generate
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -10,15 +11,22 @@ import (
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/cmd/root"
+	"github.com/grafana/tempo-operator/internal/handlers/storage"
 	"github.com/grafana/tempo-operator/internal/manifests"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/monolithic"
 	"github.com/grafana/tempo-operator/internal/webhooks"
 )
 
@@ -28,15 +36,60 @@ const yamlOrJsonDecoderBufferSize = 8192
 
 var log = ctrl.Log.WithName("generate")
 
-func loadSpec(r io.Reader) (v1alpha1.TempoStack, error) {
-	spec := v1alpha1.TempoStack{}
+// LoadObjects decodes all objects of a multi-document YAML or JSON stream.
+// The items of a List (e.g. the output of kubectl get -o yaml) are returned as separate objects.
+func LoadObjects(scheme *runtime.Scheme, r io.Reader) ([]client.Object, error) {
+	deserializer := serializer.NewCodecFactory(scheme).UniversalDeserializer()
 	decoder := k8syaml.NewYAMLOrJSONDecoder(r, yamlOrJsonDecoderBufferSize)
-	err := decoder.Decode(&spec)
+
+	objects := []client.Object{}
+	for {
+		raw := runtime.RawExtension{}
+		err := decoder.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(raw.Raw) == 0 {
+			// skip empty documents
+			continue
+		}
+
+		decoded, err := decodeObjects(deserializer, raw.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+
+	return objects, nil
+}
+
+func decodeObjects(deserializer runtime.Decoder, data []byte) ([]client.Object, error) {
+	obj, _, err := deserializer.Decode(data, nil, nil)
 	if err != nil {
-		return v1alpha1.TempoStack{}, err
+		return nil, err
+	}
+
+	if list, ok := obj.(*corev1.List); ok {
+		objects := []client.Object{}
+		for _, item := range list.Items {
+			decoded, err := decodeObjects(deserializer, item.Raw)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, decoded...)
+		}
+		return objects, nil
 	}
 
-	return spec, nil
+	clientObj, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unsupported object %s", obj.GetObjectKind().GroupVersionKind())
+	}
+	return []client.Object{clientObj}, nil
 }
 
 func build(params manifestutils.Params) ([]client.Object, error) {
@@ -55,6 +108,144 @@ func build(params manifestutils.Params) ([]client.Object, error) {
 	return objects, nil
 }
 
+func buildMonolithic(opts monolithic.Options) ([]client.Object, error) {
+	// apply default values, the same way as the operator
+	opts.Tempo.Default(opts.CtrlConfig)
+
+	objects, err := monolithic.BuildAll(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// secretExists returns true if the Secret is part of the input.
+func secretExists(ctx context.Context, c client.Client, namespace string, name string) bool {
+	if name == "" {
+		return false
+	}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &corev1.Secret{})
+	return err == nil
+}
+
+// monolithicStorageSecret returns the name of the storage secret of a TempoMonolithic.
+func monolithicStorageSecret(tempo v1alpha1.TempoMonolithic) string {
+	if tempo.Spec.Storage == nil {
+		return ""
+	}
+
+	traces := tempo.Spec.Storage.Traces
+	switch {
+	case traces.Backend == v1alpha1.MonolithicTracesStorageBackendS3 && traces.S3 != nil:
+		return traces.S3.Secret
+	case traces.Backend == v1alpha1.MonolithicTracesStorageBackendAzure && traces.Azure != nil:
+		return traces.Azure.Secret
+	case traces.Backend == v1alpha1.MonolithicTracesStorageBackendGCS && traces.GCS != nil:
+		return traces.GCS.Secret
+	default:
+		return ""
+	}
+}
+
+// buildAll builds the manifests of all TempoStack and TempoMonolithic CRs of the input.
+// The storage parameters are resolved from the storage secrets of the input. If a storage secret
+// is not part of the input, the storage parameters of the command line flags are used instead.
+func buildAll(ctx context.Context, scheme *runtime.Scheme, params manifestutils.Params, input []client.Object) ([]client.Object, error) {
+	// Secrets, ConfigMaps and other objects of the input are served by an in-memory client,
+	// which is used to resolve the storage parameters.
+	resources := []client.Object{}
+	for _, obj := range input {
+		switch resource := obj.(type) {
+		case *v1alpha1.TempoStack, *v1alpha1.TempoMonolithic:
+		case *corev1.Secret:
+			// stringData is merged into data by the API server
+			if len(resource.StringData) > 0 && resource.Data == nil {
+				resource.Data = map[string][]byte{}
+			}
+			for k, v := range resource.StringData {
+				resource.Data[k] = []byte(v)
+			}
+			resources = append(resources, resource)
+		default:
+			resources = append(resources, obj)
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(resources...).Build()
+
+	objects := []client.Object{}
+	crs := 0
+	for _, obj := range input {
+		switch tempo := obj.(type) {
+		case *v1alpha1.TempoStack:
+			crs++
+			stackParams := params
+			stackParams.Tempo = *tempo
+			stackObjects, err := buildTempoStack(ctx, c, stackParams)
+			if err != nil {
+				return nil, fmt.Errorf("TempoStack %s: %w", tempo.Name, err)
+			}
+			objects = append(objects, stackObjects...)
+
+		case *v1alpha1.TempoMonolithic:
+			crs++
+			monolithicObjects, err := buildTempoMonolithic(ctx, c, params, *tempo)
+			if err != nil {
+				return nil, fmt.Errorf("TempoMonolithic %s: %w", tempo.Name, err)
+			}
+			objects = append(objects, monolithicObjects...)
+		}
+	}
+
+	if crs == 0 {
+		return nil, fmt.Errorf("no TempoStack or TempoMonolithic found")
+	}
+	return objects, nil
+}
+
+func buildTempoStack(ctx context.Context, c client.Client, params manifestutils.Params) ([]client.Object, error) {
+	if !secretExists(ctx, c, params.Tempo.Namespace, params.Tempo.Spec.Storage.Secret.Name) {
+		return build(params)
+	}
+
+	// apply default values before resolving the storage parameters
+	defaulterWebhook := webhooks.NewDefaulter(params.CtrlConfig)
+	err := defaulterWebhook.Default(ctx, &params.Tempo)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs field.ErrorList
+	params.StorageParams, errs = storage.GetStorageParamsForTempoStack(ctx, c, params.Tempo)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid storage configuration: %w", errs.ToAggregate())
+	}
+
+	return manifests.BuildAll(params)
+}
+
+func buildTempoMonolithic(ctx context.Context, c client.Client, params manifestutils.Params, tempo v1alpha1.TempoMonolithic) ([]client.Object, error) {
+	opts := monolithic.Options{
+		CtrlConfig:    params.CtrlConfig,
+		Tempo:         tempo,
+		StorageParams: params.StorageParams,
+	}
+	// apply default values before resolving the storage parameters
+	opts.Tempo.Default(opts.CtrlConfig)
+
+	// memory and PV storage don't require a storage secret
+	secret := monolithicStorageSecret(opts.Tempo)
+	if secret == "" || secretExists(ctx, c, opts.Tempo.Namespace, secret) {
+		var errs field.ErrorList
+		opts.StorageParams, errs = storage.GetStorageParamsForTempoMonolithic(ctx, c, opts.Tempo)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("invalid storage configuration: %w", errs.ToAggregate())
+		}
+	}
+
+	return monolithic.BuildAll(opts)
+}
+
 func toYAMLManifest(scheme *runtime.Scheme, objects []client.Object, out io.Writer) error {
 	for _, obj := range objects {
 		_, err := fmt.Fprintln(out, "---")
@@ -127,13 +318,12 @@ func generate(c *cobra.Command, crPath string, outPath string, params manifestut
 		}()
 	}
 
-	spec, err := loadSpec(specReader)
+	input, err := LoadObjects(options.Scheme, specReader)
 	if err != nil {
 		return fmt.Errorf("error loading spec: %w", err)
 	}
 
-	params.Tempo = spec
-	objects, err := build(params)
+	objects, err := buildAll(c.Context(), options.Scheme, params, input)
 	if err != nil {
 		return fmt.Errorf("error building manifests: %w", err)
 	}
@@ -174,13 +364,14 @@ func NewGenerateCommand() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "generate",
-		Short: "Generate YAML manifests from a Tempo CR",
+		Short: "Generate YAML manifests from TempoStack and TempoMonolithic CRs",
 		RunE: func(c *cobra.Command, args []string) error {
 			rootCmdConfig := c.Context().Value(root.RootConfigKey{}).(root.RootConfig)
 			params := manifestutils.Params{
 				CtrlConfig: rootCmdConfig.CtrlConfig,
 			}
 
+			// The storage parameters of the flags are used if the storage secret is not part of the input.
 			switch {
 			case azureContainer != "":
 				params.StorageParams.AzureStorage = &manifestutils.AzureStorage{
@@ -200,7 +391,7 @@ func NewGenerateCommand() *cobra.Command {
 			return generate(c, crPath, outPath, params)
 		},
 	}
-	cmd.Flags().StringVar(&crPath, "cr", "/dev/stdin", "Input CR")
+	cmd.Flags().StringVar(&crPath, "cr", "/dev/stdin", "Input CRs, storage Secrets and ConfigMaps (multi-document YAML)")
 	cmd.Flags().StringVar(&outPath, "output", "/dev/stdout", "File to store the manifests")
 	cmd.Flags().StringVar(&azureContainer, "storage.azure.container", "azure", "Azure container (taken from storage secret)")
 	cmd.Flags().StringVar(&gcsBucket, "storage.gcs.bucket", "tempo", "GCS storage bucket (taken from storage secret)")
@@ -14,8 +14,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/cmd/root"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/monolithic"
 )
 
 func TestBuild(t *testing.T) {
@@ -37,7 +39,75 @@ func TestBuild(t *testing.T) {
 
 	objects, err := build(params)
 	require.NoError(t, err)
-	require.Equal(t, 14, len(objects))
+	require.Equal(t, 20, len(objects))
+}
+
+func TestBuildMonolithic(t *testing.T) {
+	opts := monolithic.Options{
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Tempo: "tempo-image",
+			},
+		},
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "sample",
+			},
+		},
+	}
+
+	objects, err := buildMonolithic(opts)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(objects))
+}
+
+func TestLoadObjects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+
+	input := `
+---
+apiVersion: tempo.grafana.com/v1alpha1
+kind: TempoMonolithic
+metadata:
+  name: sample
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ca
+data:
+  service-ca.crt: ca
+`
+	objects, err := LoadObjects(scheme, strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	require.IsType(t, &v1alpha1.TempoMonolithic{}, objects[0])
+	require.IsType(t, &corev1.ConfigMap{}, objects[1])
+	require.Equal(t, "sample", objects[0].GetName())
+
+	list := `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: storage
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: ca
+`
+	objects, err = LoadObjects(scheme, strings.NewReader(list))
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	require.IsType(t, &corev1.Secret{}, objects[0])
+	require.IsType(t, &corev1.ConfigMap{}, objects[1])
+
+	_, err = LoadObjects(scheme, strings.NewReader("apiVersion: v1\nkind: Unknown\n"))
+	require.Error(t, err)
 }
 
 func TestYAMLEncoding(t *testing.T) {
@@ -136,3 +206,54 @@ metadata:
   name: tempo-simplest-distributor
 `)
 }
+
+func TestGenerateCmdMultiDocument(t *testing.T) {
+	c := root.NewRootCommand()
+	c.AddCommand(NewGenerateCommand())
+
+	out := &strings.Builder{}
+	c.SetOut(out)
+	c.SetErr(out)
+
+	c.SetArgs([]string{"generate", "--cr", "testdata/multi.yaml"})
+	_, err := c.ExecuteC()
+	require.NoError(t, err)
+
+	// TempoStack
+	require.Contains(t, out.String(), `
+  name: tempo-simplest-distributor
+`)
+	// TempoMonolithic
+	require.Contains(t, out.String(), `
+kind: StatefulSet
+metadata:
+  labels:
+    app.kubernetes.io/component: tempo
+    app.kubernetes.io/instance: mono
+    app.kubernetes.io/managed-by: tempo-operator
+    app.kubernetes.io/name: tempo-monolithic
+  name: tempo-mono
+`)
+	// storage parameters from the storage secret of the input
+	require.Contains(t, out.String(), "bucket: tempo-traces")
+	require.NotContains(t, out.String(), "bucket: tempo\n")
+}
+
+func TestGenerateCmdNoCR(t *testing.T) {
+	c := root.NewRootCommand()
+	c.AddCommand(NewGenerateCommand())
+	c.SetIn(strings.NewReader(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: minio
+`))
+
+	out := &strings.Builder{}
+	c.SetOut(out)
+	c.SetErr(out)
+
+	c.SetArgs([]string{"generate"})
+	_, err := c.ExecuteC()
+	require.ErrorContains(t, err, "no TempoStack or TempoMonolithic found")
+}
@@ -4,9 +4,11 @@ import (
 	"flag"
 	"os"
 
+	"github.com/grafana/tempo-operator/cmd/diff"
 	"github.com/grafana/tempo-operator/cmd/generate"
 	"github.com/grafana/tempo-operator/cmd/root"
 	"github.com/grafana/tempo-operator/cmd/start"
+	"github.com/grafana/tempo-operator/cmd/upgrade"
 	"github.com/grafana/tempo-operator/cmd/version"
 	"github.com/grafana/tempo-operator/internal/logging"
 )
@@ -15,6 +17,8 @@ func main() {
 	rootCmd := root.NewRootCommand()
 	rootCmd.AddCommand(start.NewStartCommand())
 	rootCmd.AddCommand(generate.NewGenerateCommand())
+	rootCmd.AddCommand(diff.NewDiffCommand())
+	rootCmd.AddCommand(upgrade.NewUpgradeCommand())
 	rootCmd.AddCommand(version.NewVersionCommand())
 
 	logging.SetupLogging()
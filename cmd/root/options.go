@@ -8,15 +8,20 @@ import (
 	"path/filepath"
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 )
 
 var errConfigFileLoading = errors.New("could not read file at path")
@@ -39,7 +44,7 @@ func loadConfigFile(scheme *runtime.Scheme, outConfig *configv1alpha1.ProjectCon
 // LoadConfig initializes the controller configuration, optionally overriding the defaults
 // from a provided configuration file.
 func LoadConfig(scheme *runtime.Scheme, configFile string) (*configv1alpha1.ProjectConfig, ctrl.Options, error) {
-	options := ctrl.Options{Scheme: scheme}
+	options := ctrl.Options{Scheme: scheme, Cache: cacheOptions()}
 	ctrlConfig := configv1alpha1.DefaultProjectConfig()
 
 	if configFile == "" {
@@ -61,6 +66,18 @@ func LoadConfig(scheme *runtime.Scheme, configFile string) (*configv1alpha1.Proj
 	return &ctrlConfig, options, nil
 }
 
+// cacheOptions restricts the informers of the manager.
+// The operator only reads pods it manages, therefore only these pods are cached instead of all pods of the cluster.
+func cacheOptions() cache.Options {
+	return cache.Options{
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Pod{}: {
+				Label: labels.SelectorFromSet(manifestutils.ManagedByOperatorLabels()),
+			},
+		},
+	}
+}
+
 func mergeOptionsFromFile(o manager.Options, cfg *configv1alpha1.ProjectConfig) manager.Options {
 	o = setLeaderElectionConfig(o, cfg.ControllerManagerConfigurationSpec)
 
@@ -7,6 +7,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
 )
@@ -61,3 +63,13 @@ func TestReadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheOptions(t *testing.T) {
+	options := cacheOptions()
+	require.Len(t, options.ByObject, 1)
+	for obj, byObject := range options.ByObject {
+		assert.IsType(t, &corev1.Pod{}, obj)
+		assert.True(t, byObject.Label.Matches(labels.Set{"app.kubernetes.io/managed-by": "tempo-operator", "app.kubernetes.io/name": "tempo"}))
+		assert.False(t, byObject.Label.Matches(labels.Set{"app": "other"}))
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/grafana/tempo-operator/cmd/root"
 	controllers "github.com/grafana/tempo-operator/internal/controller/tempo"
 	"github.com/grafana/tempo-operator/internal/crdmetrics"
+	"github.com/grafana/tempo-operator/internal/handlers/storage"
 	"github.com/grafana/tempo-operator/internal/version"
 	"github.com/grafana/tempo-operator/internal/webhooks"
 	//+kubebuilder:scaffold:imports
@@ -63,12 +64,18 @@ func start(c *cobra.Command, args []string) {
 		}
 	}
 
+	var storagePreflight *storage.Preflight
+	if ctrlConfig.Gates.StoragePreflight {
+		storagePreflight = storage.NewPreflight(mgr.GetClient(), storage.NewS3ObjectStorage)
+	}
+
 	if err = (&controllers.TempoStackReconciler{
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		Recorder:   mgr.GetEventRecorderFor("tempostack-controller"),
-		CtrlConfig: ctrlConfig,
-		Version:    version,
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		Recorder:         mgr.GetEventRecorderFor("tempostack-controller"),
+		CtrlConfig:       ctrlConfig,
+		Version:          version,
+		StoragePreflight: storagePreflight,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "TempoStack")
 		os.Exit(1)
@@ -85,6 +92,22 @@ func start(c *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if err = (&controllers.TempoTenantReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TempoTenant")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.ZoneLabelingReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "zonelabeling")
+		os.Exit(1)
+	}
+
 	enableWebhooks := os.Getenv("ENABLE_WEBHOOKS") != "false"
 	if enableWebhooks {
 		if err = (&webhooks.TempoStackWebhook{}).SetupWebhookWithManager(mgr, ctrlConfig); err != nil {
@@ -0,0 +1,179 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/cmd/diff"
+	"github.com/grafana/tempo-operator/cmd/root"
+	"github.com/grafana/tempo-operator/internal/upgrade"
+	"github.com/grafana/tempo-operator/internal/version"
+)
+
+var log = ctrl.Log.WithName("upgrade")
+
+// planCR prints the upgrade steps of a single CR, and returns false if the upgrade would fail.
+func planCR(ctx context.Context, out io.Writer, u upgrade.Upgrade, kind string, cr upgrade.UpgradeableCR, managed bool, policy *v1alpha1.UpgradePolicySpec) (bool, error) {
+	owner := fmt.Sprintf("%s %s", kind, client.ObjectKeyFromObject(cr))
+
+	// The operator skips unmanaged CRs, and new CRs with an empty operator version are already up-to-date.
+	if !managed {
+		_, err := fmt.Fprintf(out, "%s: skipped, the CR is unmanaged\n", owner)
+		return true, err
+	}
+	if cr.GetOperatorVersion() == "" {
+		_, err := fmt.Fprintf(out, "%s: up-to-date\n", owner)
+		return true, err
+	}
+
+	upgradeStatus, err := u.Plan(ctx, cr)
+	if err != nil {
+		return false, fmt.Errorf("error upgrading %s: %w", owner, err)
+	}
+	if upgradeStatus == nil {
+		_, err := fmt.Fprintf(out, "%s: up-to-date (version %s)\n", owner, cr.GetOperatorVersion())
+		return true, err
+	}
+
+	_, err = io.WriteString(out, formatUpgradeStatus(owner, upgradeStatus))
+	if err != nil {
+		return false, err
+	}
+
+	pending, _, err := upgrade.Hold(cr, policy, u.Version.OperatorVersion, time.Now())
+	if err != nil {
+		_, err = fmt.Fprintf(out, "  invalid upgrade policy: %s\n", err)
+		return false, err
+	}
+	if pending != nil {
+		_, err = fmt.Fprintf(out, "  held by the upgrade policy: %s\n", pending.Message)
+	}
+	return upgradeStatus.Message == "", err
+}
+
+// formatUpgradeStatus formats the upgrade steps, the changes of the spec and the error of an upgrade.
+func formatUpgradeStatus(owner string, upgradeStatus *v1alpha1.UpgradeStatus) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: upgrade from %s to %s\n", owner, upgradeStatus.FromVersion, upgradeStatus.ToVersion)
+
+	steps := "none"
+	if len(upgradeStatus.Steps) > 0 {
+		steps = strings.Join(upgradeStatus.Steps, ", ")
+	}
+	fmt.Fprintf(&sb, "  steps: %s\n", steps)
+
+	if upgradeStatus.SpecDiff != "" {
+		sb.WriteString("  spec changes:\n")
+		for _, line := range strings.Split(strings.TrimRight(upgradeStatus.SpecDiff, "\n"), "\n") {
+			fmt.Fprintf(&sb, "    %s\n", line)
+		}
+	}
+
+	if upgradeStatus.FailedStep != "" {
+		fmt.Fprintf(&sb, "  upgrade step %s would fail: %s\n", upgradeStatus.FailedStep, upgradeStatus.Message)
+	} else if upgradeStatus.Message != "" {
+		fmt.Fprintf(&sb, "  upgrade would fail: %s\n", upgradeStatus.Message)
+	}
+	return sb.String()
+}
+
+func dryRun(c *cobra.Command, k8sclient client.Client, namespace string, toVersion string) error {
+	rootCmdConfig := c.Context().Value(root.RootConfigKey{}).(root.RootConfig)
+	ctx := c.Context()
+	out := c.OutOrStdout()
+
+	v := version.Get()
+	if toVersion != "" {
+		v.OperatorVersion = toVersion
+	}
+	u := upgrade.Upgrade{
+		Client:     k8sclient,
+		CtrlConfig: rootCmdConfig.CtrlConfig,
+		Version:    v,
+		Log:        log,
+	}
+
+	failed := 0
+	tempoStacks := &v1alpha1.TempoStackList{}
+	err := k8sclient.List(ctx, tempoStacks, client.InNamespace(namespace))
+	if err != nil {
+		return fmt.Errorf("error listing TempoStacks: %w", err)
+	}
+	for i := range tempoStacks.Items {
+		tempo := &tempoStacks.Items[i]
+		ok, err := planCR(ctx, out, u, "TempoStack", tempo, tempo.Spec.ManagementState == v1alpha1.ManagementStateManaged, tempo.Spec.UpgradePolicy)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			failed++
+		}
+	}
+
+	tempoMonolithics := &v1alpha1.TempoMonolithicList{}
+	err = k8sclient.List(ctx, tempoMonolithics, client.InNamespace(namespace))
+	if err != nil {
+		return fmt.Errorf("error listing TempoMonolithics: %w", err)
+	}
+	for i := range tempoMonolithics.Items {
+		tempo := &tempoMonolithics.Items[i]
+		ok, err := planCR(ctx, out, u, "TempoMonolithic", tempo, tempo.Spec.Management != v1alpha1.ManagementStateUnmanaged, tempo.Spec.UpgradePolicy)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("the automated upgrade of %d CR(s) would fail, the CRs must be corrected manually", failed)
+	}
+	return nil
+}
+
+// NewUpgradeCommand returns a new upgrade command.
+func NewUpgradeCommand() *cobra.Command {
+	var kubeconfigPath string
+	var namespace string
+	var toVersion string
+	var isDryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Show the upgrade steps of all TempoStack and TempoMonolithic CRs",
+		Long: "Show the upgrade steps the operator would run for all TempoStack and TempoMonolithic CRs " +
+			"when upgrading them to the version of this binary.\n\n" +
+			"For each CR the command prints the upgrade steps, the resulting changes of the spec, and the upgrade steps " +
+			"which would fail. The changes are sent to the cluster in dry-run mode, therefore the upgraded CRs are " +
+			"validated by the admission webhooks of the operator, but no changes are persisted.\n\n" +
+			"Use the same --config file as the operator. The CRs are upgraded by the operator, " +
+			"therefore only the --dry-run mode is supported.",
+		RunE: func(c *cobra.Command, args []string) error {
+			if !isDryRun {
+				return fmt.Errorf("only --dry-run is supported, the CRs are upgraded by the operator")
+			}
+
+			rootCmdConfig := c.Context().Value(root.RootConfigKey{}).(root.RootConfig)
+			k8sclient, _, err := diff.NewClusterClient(rootCmdConfig.Options.Scheme, kubeconfigPath)
+			if err != nil {
+				return err
+			}
+
+			return dryRun(c, k8sclient, namespace, toVersion)
+		},
+	}
+	cmd.Flags().BoolVar(&isDryRun, "dry-run", false, "Show the upgrade steps without upgrading the CRs")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to the kubeconfig file (defaults to $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the CRs (defaults to all namespaces)")
+	cmd.Flags().StringVar(&toVersion, "to-version", "", "Operator version to upgrade to (defaults to the version of this binary)")
+	return cmd
+}
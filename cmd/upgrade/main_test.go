@@ -0,0 +1,132 @@
+package upgrade
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/cmd/root"
+)
+
+func newTestCommand(scheme *runtime.Scheme) (*cobra.Command, *strings.Builder) {
+	c := &cobra.Command{}
+	c.SetContext(context.WithValue(context.Background(), root.RootConfigKey{}, root.RootConfig{
+		Options: ctrl.Options{Scheme: scheme},
+	}))
+	out := &strings.Builder{}
+	c.SetOut(out)
+	return c, out
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestDryRun(t *testing.T) {
+	scheme := newScheme()
+	tempoStack := &v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "simplest", Namespace: "observability"},
+		Spec: v1alpha1.TempoStackSpec{
+			ManagementState: v1alpha1.ManagementStateManaged,
+			UpgradePolicy:   &v1alpha1.UpgradePolicySpec{Mode: v1alpha1.UpgradePolicyModeManual},
+			Template: v1alpha1.TempoTemplateSpec{
+				QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+					JaegerQuery: v1alpha1.JaegerQuerySpec{
+						MonitorTab: v1alpha1.JaegerQueryMonitor{
+							PrometheusEndpoint: "https://thanos-querier.openshift-monitoring.svc.cluster.local:9091",
+						},
+					},
+				},
+			},
+		},
+		Status: v1alpha1.TempoStackStatus{OperatorVersion: "0.15.0"},
+	}
+	unmanaged := &v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: "observability"},
+		Spec:       v1alpha1.TempoStackSpec{ManagementState: v1alpha1.ManagementStateUnmanaged},
+		Status:     v1alpha1.TempoStackStatus{OperatorVersion: "0.1.0"},
+	}
+	tempoMonolithic := &v1alpha1.TempoMonolithic{
+		ObjectMeta: metav1.ObjectMeta{Name: "mono", Namespace: "observability"},
+		Status:     v1alpha1.TempoMonolithicStatus{OperatorVersion: "0.16.0"},
+	}
+	k8sclient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tempoStack, unmanaged, tempoMonolithic).Build()
+
+	c, out := newTestCommand(scheme)
+	err := dryRun(c, k8sclient, "", "0.16.0")
+	require.NoError(t, err)
+
+	require.Contains(t, out.String(), `TempoStack observability/simplest: upgrade from 0.15.0 to 0.16.0
+  steps: 0.15.4
+  spec changes:
+    --- original
+    +++ upgraded
+`)
+	require.Contains(t, out.String(), "    -        prometheusEndpoint: https://thanos-querier.openshift-monitoring.svc.cluster.local:9091\n")
+	require.Contains(t, out.String(), "    +        prometheusEndpoint: https://thanos-querier.openshift-monitoring.svc.cluster.local:9092\n")
+	require.Contains(t, out.String(), `  held by the upgrade policy: The upgrade from operator version 0.15.0 to 0.16.0 is pending, approve it with the annotation tempo.grafana.com/approve-upgrade: "0.16.0"`+"\n")
+	require.Contains(t, out.String(), "TempoStack observability/unmanaged: skipped, the CR is unmanaged\n")
+	require.Contains(t, out.String(), "TempoMonolithic observability/mono: up-to-date (version 0.16.0)\n")
+
+	// the CR must not be changed in dry-run mode
+	live := &v1alpha1.TempoStack{}
+	err = k8sclient.Get(context.Background(), client.ObjectKeyFromObject(tempoStack), live)
+	require.NoError(t, err)
+	require.Equal(t, "0.15.0", live.Status.OperatorVersion)
+	require.Equal(t, "https://thanos-querier.openshift-monitoring.svc.cluster.local:9091", live.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint)
+}
+
+func TestDryRunFailedUpgrade(t *testing.T) {
+	scheme := newScheme()
+	tempoStack := &v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "observability"},
+		Spec:       v1alpha1.TempoStackSpec{ManagementState: v1alpha1.ManagementStateManaged},
+		Status:     v1alpha1.TempoStackStatus{OperatorVersion: "invalid"},
+	}
+	k8sclient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tempoStack).Build()
+
+	c, out := newTestCommand(scheme)
+	err := dryRun(c, k8sclient, "observability", "0.16.0")
+	require.EqualError(t, err, "the automated upgrade of 1 CR(s) would fail, the CRs must be corrected manually")
+	require.Contains(t, out.String(), "TempoStack observability/broken: upgrade from invalid to 0.16.0\n")
+	require.Contains(t, out.String(), "  upgrade would fail: invalid operator version of the instance: Invalid Semantic Version\n")
+}
+
+func TestFormatUpgradeStatus(t *testing.T) {
+	out := formatUpgradeStatus("TempoStack ns/name", &v1alpha1.UpgradeStatus{
+		FromVersion: "0.10.0",
+		ToVersion:   "0.16.0",
+		Steps:       []string{},
+		FailedStep:  "0.11.0",
+		Message:     "jobs.batch \"chown-name\" already exists",
+	})
+	require.Equal(t, `TempoStack ns/name: upgrade from 0.10.0 to 0.16.0
+  steps: none
+  upgrade step 0.11.0 would fail: jobs.batch "chown-name" already exists
+`, out)
+}
+
+func TestUpgradeCmdRequiresDryRun(t *testing.T) {
+	c := root.NewRootCommand()
+	c.AddCommand(NewUpgradeCommand())
+	c.SetOut(&strings.Builder{})
+	c.SetErr(&strings.Builder{})
+
+	c.SetArgs([]string{"upgrade"})
+	_, err := c.ExecuteC()
+	require.EqualError(t, err, "only --dry-run is supported, the CRs are upgraded by the operator")
+}
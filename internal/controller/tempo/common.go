@@ -4,26 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
-	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
-	"github.com/grafana/tempo-operator/internal/handlers/gateway"
 	"github.com/grafana/tempo-operator/internal/manifests"
-	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
-	"github.com/grafana/tempo-operator/internal/status"
-	"github.com/grafana/tempo-operator/internal/webhooks"
 )
 
 func isNamespaceScoped(obj client.Object) bool {
@@ -43,10 +35,13 @@ func reconcileManagedObjects(
 	owner metav1.Object,
 	scheme *runtime.Scheme,
 	managedObjects []client.Object,
-	ownedObjects map[types.UID]client.Object,
+	ownedObjects []client.Object,
 ) error {
 	log := log.FromContext(ctx)
-	pruneObjects := ownedObjects
+	pruneObjects := map[types.UID]client.Object{}
+	for _, obj := range ownedObjects {
+		pruneObjects[obj.GetUID()] = obj
+	}
 
 	// Create or update all objects managed by the operator
 	errs := []error{}
@@ -115,65 +110,3 @@ func reconcileManagedObjects(
 
 	return nil
 }
-
-// listFieldErrors converts field.ErrorList to a comma separated string of errors.
-func listFieldErrors(fieldErrs field.ErrorList) string {
-	msgs := make([]string, len(fieldErrs))
-	for i, fieldErr := range fieldErrs {
-		msgs[i] = fieldErr.Detail
-	}
-	return strings.Join(msgs, ", ")
-}
-
-func getTenantParams(
-	ctx context.Context,
-	k8sclient client.Client,
-	ctrlConfig *configv1alpha1.ProjectConfig,
-	namespace string,
-	name string,
-	tenants v1alpha1.TenantsSpec,
-	gatewayEnabled bool,
-) ([]*manifestutils.GatewayTenantOIDCSecret, []*manifestutils.GatewayTenantsData, error) {
-	log := log.FromContext(ctx)
-
-	err := webhooks.ValidateTenantConfigs(&tenants, gatewayEnabled)
-	if err != nil {
-		err = &status.ConfigurationError{
-			Message: fmt.Sprintf("Invalid tenants configuration: %s", err),
-			Reason:  v1alpha1.ReasonInvalidTenantsConfiguration,
-		}
-		return nil, nil, err
-	}
-
-	switch tenants.Mode {
-	case v1alpha1.ModeStatic:
-		tenantsSecrets, err := gateway.GetOIDCTenantSecrets(ctx, k8sclient, namespace, tenants)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		return tenantsSecrets, nil, nil
-
-	case v1alpha1.ModeOpenShift:
-		if ctrlConfig.Gates.OpenShift.BaseDomain == "" {
-			domain, err := gateway.GetOpenShiftBaseDomain(ctx, k8sclient)
-			if err != nil {
-				return nil, nil, err
-			}
-
-			log.Info("OpenShift base domain set", "openshift-base-domain", domain)
-			ctrlConfig.Gates.OpenShift.BaseDomain = domain
-		}
-
-		tenantsData, err := gateway.GetGatewayTenantsData(ctx, k8sclient, namespace, name)
-		if err != nil {
-			// just log the error the secret is not created if the loop for an instance runs for the first time.
-			log.Info("Failed to get gateway secret and/or tenants.yaml", "error", err)
-		}
-
-		return nil, tenantsData, nil
-
-	default:
-		return nil, nil, nil
-	}
-}
@@ -2,8 +2,8 @@ package controllers
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"time"
 
 	grafanav1 "github.com/grafana/grafana-operator/v5/api/v1beta1"
 	routev1 "github.com/openshift/api/route/v1"
@@ -13,11 +13,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -26,15 +27,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
-	"github.com/grafana/tempo-operator/internal/handlers/storage"
+	"github.com/grafana/tempo-operator/internal/desiredstate"
 	"github.com/grafana/tempo-operator/internal/manifests/cloudcredentials"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/monolithic"
 	"github.com/grafana/tempo-operator/internal/status"
-	"github.com/grafana/tempo-operator/internal/tlsprofile"
 	"github.com/grafana/tempo-operator/internal/upgrade"
 	"github.com/grafana/tempo-operator/internal/version"
 )
@@ -106,13 +107,28 @@ func (r *TempoMonolithicReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	// New CRs with empty OperatorVersion are ignored, as they're already up-to-date.
 	// The versions will be set when the status field is refreshed.
 	if tempo.Status.OperatorVersion != "" && tempo.Status.OperatorVersion != r.Version.OperatorVersion {
-		upgraded, err := upgrade.Upgrade{
+		// The upgrade policy can hold the upgrade, in which case the components are not updated
+		// to avoid rolling out the components with the new operator version.
+		pending, requeueAfter, err := upgrade.Hold(&tempo, tempo.Spec.UpgradePolicy, r.Version.OperatorVersion, time.Now())
+		if err != nil {
+			err = &status.ConfigurationError{
+				Message: fmt.Sprintf("invalid upgrade policy: %s", err),
+				Reason:  v1alpha1.ReasonFailedUpgrade,
+			}
+			return ctrl.Result{}, status.HandleTempoMonolithicStatus(ctx, r.Client, tempo, err)
+		}
+		u := upgrade.Upgrade{
 			Client:     r.Client,
 			Recorder:   r.Recorder,
 			CtrlConfig: r.CtrlConfig,
 			Version:    r.Version,
 			Log:        log.WithName("upgrade"),
-		}.Upgrade(ctx, &tempo)
+		}
+		if pending != nil {
+			return r.reconcileHeldUpgrade(ctx, u, tempo, *pending, requeueAfter)
+		}
+
+		upgraded, err := u.Upgrade(ctx, &tempo)
 		if err != nil {
 			return ctrl.Result{}, status.HandleTempoMonolithicStatus(ctx, r.Client, tempo, err)
 		}
@@ -133,32 +149,6 @@ func (r *TempoMonolithicReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	// The ephemeral defaults should not be written back to the cluster.
 	tempo.Default(r.CtrlConfig)
 
-	// Discover CCO Environment configured (if any)
-	tokenCCOAuthEnv := cloudcredentials.DiscoverTokenCCOAuthConfig()
-
-	// We can use this before inferred, as COO mode cannot be inferred and need to be set explicit
-	// if is not set at this point, we need to clean up resources.
-	if tokenCCOAuthEnv != nil && r.getCredentialMode(tempo) == v1alpha1.CredentialModeTokenCCO {
-		ccoObjects, err := cloudcredentials.BuildCredentialsRequest(&tempo, tempo.Spec.ServiceAccount, tokenCCOAuthEnv)
-		if err != nil {
-			return ctrl.Result{}, status.HandleTempoMonolithicStatus(ctx, r.Client, tempo, err)
-		}
-
-		ownedCCOObjects, err := r.getCCOOwnedObjects(ctx, tempo)
-		if err != nil {
-			return ctrl.Result{}, status.HandleTempoMonolithicStatus(ctx, r.Client, tempo, err)
-		}
-
-		err = reconcileManagedObjects(ctx, r.Client, &tempo, r.Scheme, ccoObjects, ownedCCOObjects)
-
-		if err != nil {
-			return ctrl.Result{}, status.HandleTempoMonolithicStatus(ctx, r.Client, tempo, err)
-		}
-	} else if tokenCCOAuthEnv == nil && r.getCredentialMode(tempo) == v1alpha1.CredentialModeTokenCCO {
-		return ctrl.Result{}, status.HandleTempoMonolithicStatus(ctx, r.Client, tempo,
-			errors.New("cannot configure tempo in CCO mode without CCO environment"))
-	}
-
 	err := r.createOrUpdate(ctx, tempo)
 	if err != nil {
 		return ctrl.Result{}, status.HandleTempoMonolithicStatus(ctx, r.Client, tempo, err)
@@ -170,195 +160,50 @@ func (r *TempoMonolithicReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	return ctrl.Result{}, status.HandleTempoMonolithicStatus(ctx, r.Client, tempo, nil)
 }
 
-func (r *TempoMonolithicReconciler) getCredentialMode(tempo v1alpha1.TempoMonolithic) v1alpha1.CredentialMode {
-	if tempo.Spec.Storage.Traces.Backend == v1alpha1.MonolithicTracesStorageBackendS3 {
-		return tempo.Spec.Storage.Traces.S3.CredentialMode
+// reconcileHeldUpgrade shows the pending upgrade in the status of a TempoMonolithic whose upgrade is held by the upgrade policy.
+// The components are not updated to avoid rolling out the components with the new operator version,
+// but the status of the components is refreshed.
+func (r *TempoMonolithicReconciler) reconcileHeldUpgrade(ctx context.Context, u upgrade.Upgrade, tempo v1alpha1.TempoMonolithic, pending metav1.Condition, requeueAfter time.Duration) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("holding upgrade because of the upgrade policy", "message", pending.Message)
+	changed := tempo.DeepCopy()
+	meta.SetStatusCondition(&changed.Status.Conditions, pending)
+	// show the upgrade steps which run once the upgrade is approved or the maintenance window starts
+	upgradeStatus, err := u.PendingStatus(ctx, &tempo)
+	if err != nil {
+		log.Error(err, "failed to plan the pending upgrade")
+	} else {
+		changed.Status.Upgrade = upgradeStatus
+	}
+	if !equality.Semantic.DeepEqual(changed.Status, tempo.Status) {
+		if err := r.Status().Patch(ctx, changed, client.MergeFrom(&tempo)); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
-	// We only support inference mode for others, so return empty string
-	return ""
+	return ctrl.Result{RequeueAfter: requeueAfter}, status.HandleTempoMonolithicStatus(ctx, r.Client, *changed, nil)
 }
 
 func (r *TempoMonolithicReconciler) createOrUpdate(ctx context.Context, tempo v1alpha1.TempoMonolithic) error {
-	opts := monolithic.Options{
-		CtrlConfig: r.CtrlConfig,
-		Tempo:      tempo,
-	}
-
-	var errs field.ErrorList
-	opts.StorageParams, errs = storage.GetStorageParamsForTempoMonolithic(ctx, r.Client, tempo)
-	if len(errs) > 0 {
-		return &status.ConfigurationError{
-			Reason:  v1alpha1.ReasonInvalidStorageConfig,
-			Message: listFieldErrors(errs),
-		}
+	ccoState, err := desiredstate.TempoMonolithicCredentialsRequests(ctx, r.Client, tempo)
+	if err != nil {
+		return err
 	}
-
-	if tempo.Spec.Multitenancy.IsGatewayEnabled() {
-		var err error
-		opts.GatewayTenantSecret, opts.GatewayTenantsData, err = getTenantParams(ctx, r.Client, &r.CtrlConfig, tempo.Namespace, tempo.Name, tempo.Spec.Multitenancy.TenantsSpec, true)
+	if len(ccoState.Objects) > 0 {
+		err = reconcileManagedObjects(ctx, r.Client, &tempo, r.Scheme, ccoState.Objects, ccoState.OwnedObjects)
 		if err != nil {
 			return err
 		}
 	}
 
-	var err error
-	opts.TLSProfile, err = tlsprofile.Get(ctx, r.CtrlConfig.Gates, r.Client)
-	if err != nil {
-		switch err {
-		case tlsprofile.ErrGetProfileFromCluster, tlsprofile.ErrGetInvalidProfile:
-			return &status.ConfigurationError{
-				Message: err.Error(),
-				Reason:  v1alpha1.ReasonCouldNotGetOpenShiftTLSPolicy,
-			}
-		default:
-			return err
-		}
-	}
-
-	managedObjects, err := monolithic.BuildAll(opts)
-	if err != nil {
-		return fmt.Errorf("error building manifests: %w", err)
-	}
-
-	ownedObjects, err := r.getOwnedObjects(ctx, tempo)
+	state, err := desiredstate.TempoMonolithic(ctx, r.Client, desiredstate.Options{
+		CtrlConfig: &r.CtrlConfig,
+	}, tempo)
 	if err != nil {
 		return err
 	}
 
-	return reconcileManagedObjects(ctx, r.Client, &tempo, r.Scheme, managedObjects, ownedObjects)
-}
-func (r *TempoMonolithicReconciler) getCCOOwnedObjects(ctx context.Context, tempo v1alpha1.TempoMonolithic) (map[types.UID]client.Object, error) {
-	ownedObjects := map[types.UID]client.Object{}
-	listOps := &client.ListOptions{
-		Namespace:     tempo.GetNamespace(),
-		LabelSelector: labels.SelectorFromSet(monolithic.CommonLabels(tempo.Name)),
-	}
-	credentialRequestsList := &cloudcredentialv1.CredentialsRequestList{}
-	err := r.List(ctx, credentialRequestsList, listOps)
-	if err != nil {
-		return nil, fmt.Errorf("error listing cloud credential requests: %w", err)
-	}
-	for i := range credentialRequestsList.Items {
-		ownedObjects[credentialRequestsList.Items[i].GetUID()] = &credentialRequestsList.Items[i]
-	}
-	return ownedObjects, nil
-}
-
-func (r *TempoMonolithicReconciler) getOwnedObjects(ctx context.Context, tempo v1alpha1.TempoMonolithic) (map[types.UID]client.Object, error) {
-	ownedObjects := map[types.UID]client.Object{}
-	listOps := &client.ListOptions{
-		Namespace:     tempo.GetNamespace(),
-		LabelSelector: labels.SelectorFromSet(monolithic.CommonLabels(tempo.Name)),
-	}
-	clusterWideListOps := &client.ListOptions{
-		LabelSelector: labels.SelectorFromSet(monolithic.ClusterScopedCommonLabels(tempo.ObjectMeta)),
-	}
-
-	// Add all resources where the operator can conditionally create an object.
-	// For example, Ingress and Route can be enabled or disabled in the CR.
-
-	servicesList := &corev1.ServiceList{}
-	err := r.List(ctx, servicesList, listOps)
-	if err != nil {
-		return nil, fmt.Errorf("error listing services: %w", err)
-	}
-	for i := range servicesList.Items {
-		ownedObjects[servicesList.Items[i].GetUID()] = &servicesList.Items[i]
-	}
-
-	ingressList := &networkingv1.IngressList{}
-	err = r.List(ctx, ingressList, listOps)
-	if err != nil {
-		return nil, fmt.Errorf("error listing ingress: %w", err)
-	}
-	for i := range ingressList.Items {
-		ownedObjects[ingressList.Items[i].GetUID()] = &ingressList.Items[i]
-	}
-
-	// metrics reader for Jaeger UI Monitor Tab
-	rolesList := &rbacv1.RoleList{}
-	err = r.List(ctx, rolesList, listOps)
-	if err != nil {
-		return nil, fmt.Errorf("error listing roles: %w", err)
-	}
-	for i := range rolesList.Items {
-		ownedObjects[rolesList.Items[i].GetUID()] = &rolesList.Items[i]
-	}
-
-	// metrics reader for Jaeger UI Monitor Tab
-	roleBindingList := &rbacv1.RoleBindingList{}
-	err = r.List(ctx, roleBindingList, listOps)
-	if err != nil {
-		return nil, fmt.Errorf("error listing role bindings: %w", err)
-	}
-	for i := range roleBindingList.Items {
-		ownedObjects[roleBindingList.Items[i].GetUID()] = &roleBindingList.Items[i]
-	}
-
-	// TokenReview and SubjectAccessReview when gateway is configured with multi-tenancy in OpenShift mode
-	clusterRoleList := &rbacv1.ClusterRoleList{}
-	err = r.List(ctx, clusterRoleList, clusterWideListOps)
-	if err != nil {
-		return nil, fmt.Errorf("error listing cluster roles: %w", err)
-	}
-	for i := range clusterRoleList.Items {
-		ownedObjects[clusterRoleList.Items[i].GetUID()] = &clusterRoleList.Items[i]
-	}
-
-	// TokenReview and SubjectAccessReview when gateway is configured with multi-tenancy in OpenShift mode
-	clusterRoleBindingList := &rbacv1.ClusterRoleBindingList{}
-	err = r.List(ctx, clusterRoleBindingList, clusterWideListOps)
-	if err != nil {
-		return nil, fmt.Errorf("error listing cluster role bindings: %w", err)
-	}
-	for i := range clusterRoleBindingList.Items {
-		ownedObjects[clusterRoleBindingList.Items[i].GetUID()] = &clusterRoleBindingList.Items[i]
-	}
-
-	if r.CtrlConfig.Gates.PrometheusOperator {
-		servicemonitorList := &monitoringv1.ServiceMonitorList{}
-		err := r.List(ctx, servicemonitorList, listOps)
-		if err != nil {
-			return nil, fmt.Errorf("error listing service monitors: %w", err)
-		}
-		for i := range servicemonitorList.Items {
-			ownedObjects[servicemonitorList.Items[i].GetUID()] = servicemonitorList.Items[i]
-		}
-
-		prometheusRulesList := &monitoringv1.PrometheusRuleList{}
-		err = r.List(ctx, prometheusRulesList, listOps)
-		if err != nil {
-			return nil, fmt.Errorf("error listing prometheus rules: %w", err)
-		}
-		for i := range prometheusRulesList.Items {
-			ownedObjects[prometheusRulesList.Items[i].GetUID()] = prometheusRulesList.Items[i]
-		}
-	}
-
-	if r.CtrlConfig.Gates.OpenShift.OpenShiftRoute {
-		routesList := &routev1.RouteList{}
-		err := r.List(ctx, routesList, listOps)
-		if err != nil {
-			return nil, fmt.Errorf("error listing routes: %w", err)
-		}
-		for i := range routesList.Items {
-			ownedObjects[routesList.Items[i].GetUID()] = &routesList.Items[i]
-		}
-	}
-
-	if r.CtrlConfig.Gates.GrafanaOperator {
-		datasourceList := &grafanav1.GrafanaDatasourceList{}
-		err := r.List(ctx, datasourceList, listOps)
-		if err != nil {
-			return nil, fmt.Errorf("error listing datasources: %w", err)
-		}
-		for i := range datasourceList.Items {
-			ownedObjects[datasourceList.Items[i].GetUID()] = &datasourceList.Items[i]
-		}
-	}
-
-	return ownedObjects, nil
+	return reconcileManagedObjects(ctx, r.Client, &tempo, r.Scheme, state.Objects, state.OwnedObjects)
 }
 
 func (r *TempoMonolithicReconciler) findTempoMonolithicForStorageSecret(ctx context.Context, secret client.Object) []reconcile.Request {
@@ -415,6 +260,7 @@ func (r *TempoMonolithicReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&networkingv1.Ingress{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&rbacv1.ClusterRole{}).
 		Owns(&rbacv1.ClusterRoleBinding{}).
 		Owns(&rbacv1.Role{}).
@@ -429,6 +275,10 @@ func (r *TempoMonolithicReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		builder = builder.Owns(&routev1.Route{})
 	}
 
+	if r.CtrlConfig.Gates.GatewayAPI {
+		builder = builder.Owns(&gatewayv1.HTTPRoute{})
+	}
+
 	if r.CtrlConfig.Gates.PrometheusOperator {
 		builder = builder.Owns(&monitoringv1.ServiceMonitor{})
 		builder = builder.Owns(&monitoringv1.PrometheusRule{})
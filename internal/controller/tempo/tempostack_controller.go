@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"time"
 
 	"github.com/go-logr/logr"
 	grafanav1 "github.com/grafana/grafana-operator/v5/api/v1beta1"
@@ -11,10 +14,15 @@ import (
 	cloudcredentialv1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,12 +35,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/certrotation/handlers"
+	"github.com/grafana/tempo-operator/internal/handlers/storage"
 	"github.com/grafana/tempo-operator/internal/manifests/cloudcredentials"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
 	"github.com/grafana/tempo-operator/internal/status"
 	"github.com/grafana/tempo-operator/internal/upgrade"
 	"github.com/grafana/tempo-operator/internal/version"
@@ -40,8 +51,14 @@ import (
 
 const (
 	storageSecretField = ".spec.storage.secret.name" // nolint #nosec
+
+	runtimeOverridesRequeueInterval = 30 * time.Second
+	// runtimeOverridesTimeout bounds the time spent querying the runtime configuration of all Tempo pods in a reconcile.
+	runtimeOverridesTimeout = 10 * time.Second
 )
 
+var runtimeConfigHTTPClient = &http.Client{Timeout: 5 * time.Second}
+
 // TempoStackReconciler reconciles a TempoStack object.
 type TempoStackReconciler struct {
 	client.Client
@@ -49,6 +66,10 @@ type TempoStackReconciler struct {
 	Recorder   record.EventRecorder
 	CtrlConfig configv1alpha1.ProjectConfig
 	Version    version.Version
+
+	// StoragePreflight checks the object storage before rolling out the components.
+	// The check is disabled if StoragePreflight is nil.
+	StoragePreflight *storage.Preflight
 }
 
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
@@ -56,22 +77,27 @@ type TempoStackReconciler struct {
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings;clusterroles;rolebindings;roles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=create;get
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=ingresscontrollers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=dnses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=grafana.integreatly.org,resources=grafanadatasources,verbs=get;list;watch;create;update;patch;delete
 
 // Upgrate for 0.11.0 to Tempo 2.5
-// +kubebuilder:rbac:groups="core",resources=persistentvolumeclaims,verbs=list;watch
-// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="core",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 
 //+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempostacks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempostacks/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempostacks/finalizers,verbs=update
+//+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempotenants,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cloudcredential.openshift.io,resources=credentialsrequests,verbs=get;list;watch;create;update;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -89,8 +115,11 @@ func (r *TempoStackReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			log.Error(err, "unable to fetch TempoStack")
 			return ctrl.Result{}, fmt.Errorf("could not fetch tempo: %w", err)
 		}
-		// instance is not found, metrics can be cleared
+		// instance is not found, metrics and the storage preflight result can be cleared
 		status.ClearTempoStackMetrics(req.Namespace, req.Name)
+		if r.StoragePreflight != nil {
+			r.StoragePreflight.Forget(req.NamespacedName)
+		}
 
 		// we'll ignore not-found errors, since they can't be fixed by an immediate
 		// requeue (we'll need to wait for a new notification), and we can get them
@@ -131,13 +160,28 @@ func (r *TempoStackReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// New CRs with empty OperatorVersion are ignored, as they're already up-to-date.
 	// The versions will be set when the status field is refreshed.
 	if tempo.Status.OperatorVersion != "" && tempo.Status.OperatorVersion != r.Version.OperatorVersion {
-		upgraded, err := upgrade.Upgrade{
+		// The upgrade policy can hold the upgrade, in which case the components are not updated
+		// to avoid rolling out the components with the new operator version.
+		pending, requeueAfter, err := upgrade.Hold(&tempo, tempo.Spec.UpgradePolicy, r.Version.OperatorVersion, time.Now())
+		if err != nil {
+			err = &status.ConfigurationError{
+				Message: fmt.Sprintf("invalid upgrade policy: %s", err),
+				Reason:  v1alpha1.ReasonFailedUpgrade,
+			}
+			return r.handleReconcileStatus(ctx, log, tempo, err)
+		}
+		u := upgrade.Upgrade{
 			Client:     r.Client,
 			Recorder:   r.Recorder,
 			CtrlConfig: r.CtrlConfig,
 			Version:    r.Version,
 			Log:        log.WithName("upgrade"),
-		}.Upgrade(ctx, &tempo)
+		}
+		if pending != nil {
+			return r.reconcileHeldUpgrade(ctx, log, req, u, tempo, *pending, requeueAfter)
+		}
+
+		upgraded, err := u.Upgrade(ctx, &tempo)
 		if err != nil {
 			return r.handleReconcileStatus(ctx, log, tempo, err)
 		}
@@ -170,6 +214,41 @@ func (r *TempoStackReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return r.handleReconcileStatus(ctx, log, tempo, nil)
 }
 
+// reconcileHeldUpgrade shows the pending upgrade in the status of a TempoStack whose upgrade is held by the upgrade policy.
+// The components are not updated to avoid rolling out the components with the new operator version,
+// but the certificates are still rotated and the status of the components is refreshed.
+func (r *TempoStackReconciler) reconcileHeldUpgrade(ctx context.Context, log logr.Logger, req ctrl.Request, u upgrade.Upgrade, tempo v1alpha1.TempoStack, pending metav1.Condition, requeueAfter time.Duration) (ctrl.Result, error) {
+	log.Info("holding upgrade because of the upgrade policy", "message", pending.Message)
+	changed := tempo.DeepCopy()
+	meta.SetStatusCondition(&changed.Status.Conditions, pending)
+	// show the upgrade steps which run once the upgrade is approved or the maintenance window starts
+	upgradeStatus, err := u.PendingStatus(ctx, &tempo)
+	if err != nil {
+		log.Error(err, "failed to plan the pending upgrade")
+	} else {
+		changed.Status.Upgrade = upgradeStatus
+	}
+	if !equality.Semantic.DeepEqual(changed.Status, tempo.Status) {
+		if err := r.Status().Patch(ctx, changed, client.MergeFrom(&tempo)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	tempo = *changed
+
+	if r.CtrlConfig.Gates.BuiltInCertManagement.Enabled {
+		err := handlers.CreateOrRotateCertificates(ctx, log, req, r.Client, r.Scheme, r.CtrlConfig.Gates)
+		if err != nil {
+			return r.handleReconcileStatus(ctx, log, tempo, fmt.Errorf("built in cert manager error: %w", err))
+		}
+	}
+
+	result, err := r.handleReconcileStatus(ctx, log, tempo, nil)
+	if requeueAfter > 0 && (result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = requeueAfter
+	}
+	return result, err
+}
+
 // handleReconcileStatus updates the status of each component and sets an appropriate status condition:
 //
 //   - No error: Only update components status
@@ -187,6 +266,18 @@ func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log lo
 		log.Error(rerr, "could not get components status")
 	}
 
+	rerr = r.updateRuntimeOverridesStatus(ctx, tempo, &newStatus)
+	if rerr != nil {
+		log.Error(rerr, "could not get runtime overrides status")
+	}
+
+	r.updateStorageReadyCondition(tempo, &newStatus)
+
+	rerr = r.updateStorageMigratedCondition(ctx, tempo, &newStatus)
+	if rerr != nil {
+		log.Error(rerr, "could not get storage migration status")
+	}
+
 	var configurationError *status.ConfigurationError
 	if reconcileError == nil {
 		// No error.
@@ -219,7 +310,72 @@ func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log lo
 	// Note: controller-runtime will always reconcile if this function returns any error except TerminalError.
 	// Result.Requeue and Result.RequeueAfter are only respected if err == nil
 	// https://github.com/kubernetes-sigs/controller-runtime/blob/v0.15.0/pkg/internal/controller/controller.go#L315-L341
-	return ctrl.Result{}, reconcileError
+	result := ctrl.Result{}
+	if newStatus.RuntimeOverrides != nil && len(newStatus.RuntimeOverrides.PendingPods) > 0 {
+		// The kubelet updates the mounted ConfigMap periodically, and Tempo reloads the file periodically.
+		result.RequeueAfter = runtimeOverridesRequeueInterval
+	}
+	return result, reconcileError
+}
+
+// updateRuntimeOverridesStatus checks if the Tempo pods loaded the current per-tenant overrides,
+// and updates the effective dedicated attribute columns once all pods loaded them.
+// The runtime configuration endpoint cannot be queried if the httpEncryption feature gate is enabled,
+// because Tempo requires a client certificate. In this case the state is reported as Unknown.
+func (r *TempoStackReconciler) updateRuntimeOverridesStatus(ctx context.Context, tempo v1alpha1.TempoStack, newStatus *v1alpha1.TempoStackStatus) error {
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{
+		Namespace: tempo.Namespace,
+		Name:      naming.Name(manifestutils.RuntimeOverridesComponentName, tempo.Name),
+	}, configMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			newStatus.RuntimeOverrides = nil
+			newStatus.DedicatedColumns = nil
+			return nil
+		}
+		return err
+	}
+
+	overrides := []byte(configMap.Data[manifestutils.RuntimeOverridesFileName])
+	if r.CtrlConfig.Gates.HTTPEncryption {
+		newStatus.RuntimeOverrides = status.UnknownRuntimeOverridesStatus(overrides)
+		newStatus.DedicatedColumns = nil
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, runtimeOverridesTimeout)
+	defer cancel()
+	runtimeOverrides, err := status.GetRuntimeOverridesStatus(ctx, r, status.HTTPRuntimeConfigGetter(runtimeConfigHTTPClient), tempo, overrides, time.Now())
+	if err != nil {
+		return err
+	}
+	dedicatedColumns, err := status.GetDedicatedColumnsStatus(tempo, runtimeOverrides, overrides)
+	if err != nil {
+		return err
+	}
+
+	newStatus.RuntimeOverrides = runtimeOverrides
+	newStatus.DedicatedColumns = dedicatedColumns
+	return nil
+}
+
+// updateStorageReadyCondition sets the StorageReady condition to the result of the last storage preflight check.
+// The condition is removed if the storage preflight check is disabled.
+func (r *TempoStackReconciler) updateStorageReadyCondition(tempo v1alpha1.TempoStack, newStatus *v1alpha1.TempoStackStatus) {
+	// The conditions may share the underlying array with the conditions of the TempoStack,
+	// which are required to compute the status patch.
+	newStatus.Conditions = slices.Clone(newStatus.Conditions)
+
+	if r.StoragePreflight == nil {
+		meta.RemoveStatusCondition(&newStatus.Conditions, string(v1alpha1.ConditionStorageReady))
+		return
+	}
+
+	storageReady, ok := r.StoragePreflight.Result(client.ObjectKeyFromObject(&tempo))
+	if ok {
+		meta.SetStatusCondition(&newStatus.Conditions, storageReady)
+	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -232,7 +388,11 @@ func (r *TempoStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		if tempostacks.Spec.Storage.Secret.Name == "" {
 			return nil
 		}
-		return []string{tempostacks.Spec.Storage.Secret.Name}
+		names := []string{tempostacks.Spec.Storage.Secret.Name}
+		if tempostacks.Spec.Storage.Migration != nil && tempostacks.Spec.Storage.Migration.Source.Name != "" {
+			names = append(names, tempostacks.Spec.Storage.Migration.Source.Name)
+		}
+		return names
 	})
 	if err != nil {
 		return err
@@ -247,21 +407,35 @@ func (r *TempoStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.Secret{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&networkingv1.Ingress{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&rbacv1.ClusterRole{}).
 		Owns(&rbacv1.ClusterRoleBinding{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
+		Owns(&batchv1.Job{}).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findTempoStackForStorageSecret),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&v1alpha1.TempoTenant{},
+			handler.EnqueueRequestsFromMapFunc(findTempoStackForTempoTenant),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		)
 
 	if r.CtrlConfig.Gates.OpenShift.OpenShiftRoute {
 		builder = builder.Owns(&routev1.Route{})
 	}
 
+	if r.CtrlConfig.Gates.GatewayAPI {
+		builder = builder.Owns(&gatewayv1.HTTPRoute{})
+		builder = builder.Owns(&gatewayv1.GRPCRoute{})
+	}
+
 	if r.CtrlConfig.Gates.PrometheusOperator {
 		builder = builder.Owns(&monitoringv1.ServiceMonitor{})
 		builder = builder.Owns(&monitoringv1.PrometheusRule{})
@@ -320,6 +494,17 @@ func (r *TempoStackReconciler) findTempoStackForStorageSecret(ctx context.Contex
 	return requests
 }
 
+func findTempoStackForTempoTenant(_ context.Context, tenant client.Object) []reconcile.Request {
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      tenant.(*v1alpha1.TempoTenant).Spec.TempoStack,
+				Namespace: tenant.GetNamespace(),
+			},
+		},
+	}
+}
+
 // GetPodsComponent is used for fetching component pod status and refreshing the status of the CR.
 func (r *TempoStackReconciler) GetPodsComponent(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
 	pods := &corev1.PodList{}
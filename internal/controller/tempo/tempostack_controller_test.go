@@ -14,6 +14,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -22,6 +23,7 @@ import (
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/certrotation"
 	"github.com/grafana/tempo-operator/internal/status"
 	"github.com/grafana/tempo-operator/internal/version"
 )
@@ -1070,3 +1072,138 @@ func TestUpgrade(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "100.0.0", updatedTempo.Status.OperatorVersion)
 }
+
+func TestUpgradeManualPolicy(t *testing.T) {
+	nsn := types.NamespacedName{Name: "upgrade-manual-test", Namespace: "default"}
+	storageSecret := createSecret(t, nsn)
+	createTempoCR(t, nsn, storageSecret)
+
+	reconciler := TempoStackReconciler{
+		Client:   k8sClient,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(1),
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Tempo: "docker.io/grafana/tempo:1.5.0",
+			},
+			Gates: configv1alpha1.FeatureGates{
+				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+			},
+		},
+		Version: version.Get(),
+	}
+	req := ctrl.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	tempo := v1alpha1.TempoStack{}
+	err = k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	tempo.Spec.UpgradePolicy = &v1alpha1.UpgradePolicySpec{Mode: v1alpha1.UpgradePolicyModeManual}
+	err = k8sClient.Update(context.Background(), &tempo)
+	require.NoError(t, err)
+
+	// Bump operator version, the upgrade is held until it is approved
+	reconciler.Version.OperatorVersion = "100.0.0"
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	err = k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0", tempo.Status.OperatorVersion)
+	pending := meta.FindStatusCondition(tempo.Status.Conditions, string(v1alpha1.ConditionUpgradePending))
+	require.NotNil(t, pending)
+	assert.Equal(t, string(v1alpha1.ReasonUpgradeAwaitingApproval), pending.Reason)
+	// the status shows the pending upgrade
+	require.NotNil(t, tempo.Status.Upgrade)
+	assert.Equal(t, "0.0.0", tempo.Status.Upgrade.FromVersion)
+	assert.Equal(t, "100.0.0", tempo.Status.Upgrade.ToVersion)
+	assert.Empty(t, tempo.Status.Upgrade.Message)
+
+	// Approve the upgrade
+	tempo.Annotations = map[string]string{v1alpha1.ApproveUpgradeAnnotation: "100.0.0"}
+	err = k8sClient.Update(context.Background(), &tempo)
+	require.NoError(t, err)
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	err = k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	assert.Equal(t, "100.0.0", tempo.Status.OperatorVersion)
+	assert.Nil(t, meta.FindStatusCondition(tempo.Status.Conditions, string(v1alpha1.ConditionUpgradePending)))
+}
+
+func TestUpgradeHeldRotatesCertificates(t *testing.T) {
+	nsn := types.NamespacedName{Name: "upgrade-held-certs-test", Namespace: "default"}
+	storageSecret := createSecret(t, nsn)
+	createTempoCR(t, nsn, storageSecret)
+
+	reconciler := TempoStackReconciler{
+		Client:   k8sClient,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(1),
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Tempo: "docker.io/grafana/tempo:1.5.0",
+			},
+			Gates: configv1alpha1.FeatureGates{
+				BuiltInCertManagement: configv1alpha1.BuiltInCertManagement{
+					Enabled: true,
+					CACertValidity: metav1.Duration{
+						Duration: time.Hour * 43830,
+					},
+					CACertRefresh: metav1.Duration{
+						Duration: time.Hour * 35064,
+					},
+					CertValidity: metav1.Duration{
+						Duration: time.Hour * 2160,
+					},
+					CertRefresh: metav1.Duration{
+						Duration: time.Hour * 1728,
+					},
+				},
+				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+			},
+		},
+		Version: version.Get(),
+	}
+	req := ctrl.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	tempo := v1alpha1.TempoStack{}
+	err = k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	tempo.Spec.UpgradePolicy = &v1alpha1.UpgradePolicySpec{Mode: v1alpha1.UpgradePolicyModeManual}
+	err = k8sClient.Update(context.Background(), &tempo)
+	require.NoError(t, err)
+
+	// The signing CA expired and was removed
+	signingCA := &corev1.Secret{}
+	signingCANsn := types.NamespacedName{Name: certrotation.SigningCASecretName(nsn.Name), Namespace: nsn.Namespace}
+	err = k8sClient.Get(context.Background(), signingCANsn, signingCA)
+	require.NoError(t, err)
+	err = k8sClient.Delete(context.Background(), signingCA)
+	require.NoError(t, err)
+
+	// Bump operator version, the upgrade is held but the certificates are rotated
+	reconciler.Version.OperatorVersion = "100.0.0"
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	rotatedCA := &corev1.Secret{}
+	err = k8sClient.Get(context.Background(), signingCANsn, rotatedCA)
+	require.NoError(t, err)
+	assert.NotEqual(t, signingCA.UID, rotatedCA.UID)
+	assert.NotEmpty(t, rotatedCA.Data)
+
+	err = k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0", tempo.Status.OperatorVersion)
+	assert.NotNil(t, meta.FindStatusCondition(tempo.Status.Conditions, string(v1alpha1.ConditionUpgradePending)))
+	assert.NotNil(t, meta.FindStatusCondition(tempo.Status.Conditions, string(v1alpha1.ConditionReady)))
+}
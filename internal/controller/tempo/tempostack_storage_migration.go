@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/storagemigration"
+)
+
+// reconcileStorageMigrationJobs creates the Jobs of a storage migration, and recreates them if the storage configuration changed.
+// The Jobs are deleted once the migration is removed from the TempoStack.
+func (r *TempoStackReconciler) reconcileStorageMigrationJobs(ctx context.Context, tempo v1alpha1.TempoStack, jobs []*batchv1.Job) error {
+	if tempo.Spec.Storage.Migration == nil {
+		return r.deleteStorageMigrationJobs(ctx, tempo)
+	}
+
+	for _, job := range jobs {
+		if err := r.ensureStorageMigrationJob(ctx, tempo, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureStorageMigrationJob creates the Job of a storage migration phase if it doesn't exist,
+// and recreates it if the storage configuration changed.
+func (r *TempoStackReconciler) ensureStorageMigrationJob(ctx context.Context, tempo v1alpha1.TempoStack, desired *batchv1.Job) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	existing := &batchv1.Job{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error getting storage migration job: %w", err)
+	}
+	if err == nil {
+		if existing.Annotations[storagemigration.ChecksumAnnotation] == desired.Annotations[storagemigration.ChecksumAnnotation] {
+			return nil
+		}
+
+		// The pod template of a Job is immutable.
+		log.Info("storage configuration changed, recreating storage migration job", "job", existing.Name)
+		err = r.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting storage migration job: %w", err)
+		}
+		// The Job is created in the next reconciliation, once the previous Job is deleted.
+		return nil
+	}
+
+	if err := ctrl.SetControllerReference(&tempo, desired, r.Scheme); err != nil {
+		return err
+	}
+	log.Info("creating storage migration job", "job", desired.Name)
+	if err := r.Create(ctx, desired); err != nil {
+		return fmt.Errorf("error creating storage migration job: %w", err)
+	}
+	return nil
+}
+
+// deleteStorageMigrationJobs deletes the Jobs of a storage migration, once the migration is removed from the TempoStack.
+func (r *TempoStackReconciler) deleteStorageMigrationJobs(ctx context.Context, tempo v1alpha1.TempoStack) error {
+	jobs := &batchv1.JobList{}
+	err := r.List(ctx, jobs, &client.ListOptions{
+		Namespace:     tempo.Namespace,
+		LabelSelector: labels.SelectorFromSet(storagemigration.Labels(tempo.Name)),
+	})
+	if err != nil {
+		return fmt.Errorf("error listing storage migration jobs: %w", err)
+	}
+
+	for i := range jobs.Items {
+		err := r.Delete(ctx, &jobs.Items[i], client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting storage migration job: %w", err)
+		}
+	}
+	return nil
+}
+
+// updateStorageMigratedCondition sets the StorageMigrated condition to the phase of the storage migration.
+// The condition is removed if the TempoStack has no storage migration.
+func (r *TempoStackReconciler) updateStorageMigratedCondition(ctx context.Context, tempo v1alpha1.TempoStack, newStatus *v1alpha1.TempoStackStatus) error {
+	if tempo.Spec.Storage.Migration == nil {
+		meta.RemoveStatusCondition(&newStatus.Conditions, string(v1alpha1.ConditionStorageMigrated))
+		return nil
+	}
+
+	copyJob, err := r.getStorageMigrationJob(ctx, tempo, storagemigration.PhaseCopy)
+	if err != nil {
+		return err
+	}
+	syncJob, err := r.getStorageMigrationJob(ctx, tempo, storagemigration.PhaseSync)
+	if err != nil {
+		return err
+	}
+
+	meta.SetStatusCondition(&newStatus.Conditions, storagemigration.Condition(copyJob, syncJob))
+	return nil
+}
+
+func (r *TempoStackReconciler) getStorageMigrationJob(ctx context.Context, tempo v1alpha1.TempoStack, phase storagemigration.Phase) (*batchv1.Job, error) {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: storagemigration.JobName(tempo.Name, phase)}, job)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/tenants"
+)
+
+// TempoTenantReconciler reports whether a TempoTenant is applied to the referenced TempoStack.
+// The configuration of the TempoTenant is applied by the TempoStack controller.
+type TempoTenantReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempotenants,verbs=get;list;watch
+//+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempotenants/status,verbs=get;update;patch
+
+// Reconcile updates the Accepted condition of a TempoTenant.
+func (r *TempoTenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("tempotenant-reconcile")
+
+	tenant := v1alpha1.TempoTenant{}
+	if err := r.Get(ctx, req.NamespacedName, &tenant); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("could not fetch tempo tenant: %w", err)
+	}
+
+	condition := tenants.NotFoundCondition(tenant)
+	tempo := v1alpha1.TempoStack{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: tenant.Namespace, Name: tenant.Spec.TempoStack}, &tempo)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("could not fetch tempo: %w", err)
+	}
+	if err == nil {
+		tempoTenants, err := tenants.GetTempoTenants(ctx, r.Client, tempo)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		_, conditions := tenants.Merge(tempo, tempoTenants)
+		condition = conditions[tenant.Name]
+	}
+
+	changed := tenant.DeepCopy()
+	condition.ObservedGeneration = tenant.Generation
+	if !meta.SetStatusCondition(&changed.Status.Conditions, condition) {
+		return ctrl.Result{}, nil
+	}
+
+	log.V(1).Info("updating tenant status", "tenant", req.NamespacedName, "reason", condition.Reason)
+	return ctrl.Result{}, r.Status().Patch(ctx, changed, client.MergeFrom(&tenant))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TempoTenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("tempotenant").
+		For(&v1alpha1.TempoTenant{}).
+		Watches(
+			&v1alpha1.TempoStack{},
+			handler.EnqueueRequestsFromMapFunc(r.findTempoTenantsForTempoStack),
+		).
+		Watches(
+			&v1alpha1.TempoTenant{},
+			handler.EnqueueRequestsFromMapFunc(r.findSiblingTempoTenants),
+		).
+		Complete(r)
+}
+
+// findTempoTenantsForTempoStack returns all TempoTenants referencing the TempoStack.
+func (r *TempoTenantReconciler) findTempoTenantsForTempoStack(ctx context.Context, tempo client.Object) []reconcile.Request {
+	tempoTenants, err := tenants.GetTempoTenants(ctx, r.Client, *tempo.(*v1alpha1.TempoStack))
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(tempoTenants))
+	for i, item := range tempoTenants {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
+
+// findSiblingTempoTenants returns all TempoTenants referencing the same TempoStack,
+// because adding or removing a TempoTenant can resolve or cause a conflict with another TempoTenant.
+func (r *TempoTenantReconciler) findSiblingTempoTenants(ctx context.Context, tenant client.Object) []reconcile.Request {
+	return r.findTempoTenantsForTempoStack(ctx, &v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tenant.(*v1alpha1.TempoTenant).Spec.TempoStack,
+			Namespace: tenant.GetNamespace(),
+		},
+	})
+}
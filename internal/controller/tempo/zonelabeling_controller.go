@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// ZoneLabelingReconciler copies the availability zone of the node a zone-aware pod is scheduled on
+// to the `tempo.grafana.com/availability-zone` annotation of the pod.
+// The availability zone of a node is not available through the downward API.
+type ZoneLabelingReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+
+// Reconcile sets the availability zone annotation of a zone-aware pod.
+func (r *ZoneLabelingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("zonelabeling-reconcile").WithValues("pod", req.NamespacedName)
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, req.NamespacedName, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// The pod is reconciled again once it is scheduled on a node.
+	if pod.Spec.NodeName == "" {
+		return ctrl.Result{}, nil
+	}
+	if _, ok := pod.Annotations[manifestutils.AvailabilityZoneAnnotation]; ok {
+		return ctrl.Result{}, nil
+	}
+
+	topologyKey := pod.Annotations[manifestutils.ZoneTopologyKeyAnnotation]
+	if topologyKey == "" {
+		return ctrl.Result{}, fmt.Errorf("pod %s does not have the %s annotation", req.NamespacedName, manifestutils.ZoneTopologyKeyAnnotation)
+	}
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+		return ctrl.Result{}, err
+	}
+	zone, ok := node.Labels[topologyKey]
+	if !ok {
+		return ctrl.Result{}, fmt.Errorf("node %s does not have the %s label", node.Name, topologyKey)
+	}
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[manifestutils.AvailabilityZoneAnnotation] = zone
+	if err := r.Patch(ctx, pod, patch); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.V(1).Info("set availability zone", "zone", zone)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ZoneLabelingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	zoneAwarePods := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetLabels()[manifestutils.ZoneAwarePodLabel] == "true"
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("zonelabeling").
+		For(&corev1.Pod{}, builder.WithPredicates(zoneAwarePods)).
+		Complete(r)
+}
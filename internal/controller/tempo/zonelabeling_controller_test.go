@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestReconcileZoneLabeling(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "zone-labeling-node",
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone": "eu-west-1a",
+			},
+		},
+	}
+	err := k8sClient.Create(context.Background(), node)
+	require.NoError(t, err)
+
+	nsn := types.NamespacedName{Name: "zone-labeling-ingester-0", Namespace: "default"}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by":  "tempo-operator",
+				manifestutils.ZoneAwarePodLabel: "true",
+			},
+			Annotations: map[string]string{
+				manifestutils.ZoneTopologyKeyAnnotation: "topology.kubernetes.io/zone",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: node.Name,
+			Containers: []corev1.Container{
+				{
+					Name:  "tempo",
+					Image: "tempo",
+				},
+			},
+		},
+	}
+	err = k8sClient.Create(context.Background(), pod)
+	require.NoError(t, err)
+
+	reconciler := ZoneLabelingReconciler{
+		Client: k8sClient,
+		Scheme: testScheme,
+	}
+	_, err = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: nsn})
+	require.NoError(t, err)
+
+	updated := &corev1.Pod{}
+	err = k8sClient.Get(context.Background(), nsn, updated)
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1a", updated.Annotations[manifestutils.AvailabilityZoneAnnotation])
+}
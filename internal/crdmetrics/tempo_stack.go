@@ -36,6 +36,9 @@ func (i *tempoStackMetrics) Setup() error {
 		"type",
 		func(instance client.Object) (string, bool) {
 			tempoStack := instance.(*v1alpha1.TempoStack)
+			if tempoStack.Spec.Storage.Filesystem != nil {
+				return "filesystem", true
+			}
 			return string(tempoStack.Spec.Storage.Secret.Type), true
 		})
 	if err != nil {
@@ -0,0 +1,170 @@
+package desiredstate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	grafanav1 "github.com/grafana/grafana-operator/v5/api/v1beta1"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/gateway"
+	"github.com/grafana/tempo-operator/internal/handlers/storage"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/status"
+	"github.com/grafana/tempo-operator/internal/webhooks"
+)
+
+// State contains the objects which the operator creates or updates for a TempoStack or TempoMonolithic,
+// and the objects of the CR in the cluster which are pruned if they are not managed anymore.
+//
+// The state is built by the controllers and by the diff command, therefore building it doesn't modify the cluster.
+type State struct {
+	// Objects are created or updated.
+	Objects []client.Object
+	// OwnedObjects are the objects of the CR in the cluster.
+	// Owned objects which are not part of Objects are pruned.
+	OwnedObjects []client.Object
+	// StorageMigrationJobs are the Jobs of the storage migration of a TempoStack.
+	// The Jobs are created once, and recreated if the storage configuration changes.
+	StorageMigrationJobs []*batchv1.Job
+}
+
+// Options contains the configuration of the operator required to build the desired state.
+type Options struct {
+	// CtrlConfig is the operator configuration.
+	// The OpenShift base domain is discovered once and stored in the CtrlConfig.
+	CtrlConfig *configv1alpha1.ProjectConfig
+	// StoragePreflight checks the object storage before a new or changed storage configuration is rolled out.
+	// The check is disabled if StoragePreflight is nil.
+	StoragePreflight *storage.Preflight
+}
+
+// listFieldErrors converts field.ErrorList to a comma separated string of errors.
+func listFieldErrors(fieldErrs field.ErrorList) string {
+	msgs := make([]string, len(fieldErrs))
+	for i, fieldErr := range fieldErrs {
+		msgs[i] = fieldErr.Detail
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// getTenantParams validates the tenants configuration, and fetches the OIDC secrets of the static mode
+// and the existing gateway tenants data of the OpenShift mode.
+func getTenantParams(
+	ctx context.Context,
+	k8sclient client.Client,
+	ctrlConfig *configv1alpha1.ProjectConfig,
+	namespace string,
+	name string,
+	tenants v1alpha1.TenantsSpec,
+	gatewayEnabled bool,
+) ([]*manifestutils.GatewayTenantOIDCSecret, []*manifestutils.GatewayTenantsData, error) {
+	log := log.FromContext(ctx)
+
+	err := webhooks.ValidateTenantConfigs(&tenants, gatewayEnabled)
+	if err != nil {
+		err = &status.ConfigurationError{
+			Message: fmt.Sprintf("Invalid tenants configuration: %s", err),
+			Reason:  v1alpha1.ReasonInvalidTenantsConfiguration,
+		}
+		return nil, nil, err
+	}
+
+	switch tenants.Mode {
+	case v1alpha1.ModeStatic:
+		tenantsSecrets, err := gateway.GetOIDCTenantSecrets(ctx, k8sclient, namespace, tenants)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return tenantsSecrets, nil, nil
+
+	case v1alpha1.ModeOpenShift:
+		if ctrlConfig.Gates.OpenShift.BaseDomain == "" {
+			domain, err := gateway.GetOpenShiftBaseDomain(ctx, k8sclient)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			log.Info("OpenShift base domain set", "openshift-base-domain", domain)
+			ctrlConfig.Gates.OpenShift.BaseDomain = domain
+		}
+
+		tenantsData, err := gateway.GetGatewayTenantsData(ctx, k8sclient, namespace, name)
+		if err != nil {
+			// just log the error the secret is not created if the loop for an instance runs for the first time.
+			log.Info("Failed to get gateway secret and/or tenants.yaml", "error", err)
+		}
+
+		return nil, tenantsData, nil
+
+	default:
+		return nil, nil, nil
+	}
+}
+
+// gatedOwnedLists returns the lists of objects of the optional integrations enabled by feature gates.
+func gatedOwnedLists(gates configv1alpha1.FeatureGates) []client.ObjectList {
+	lists := []client.ObjectList{}
+	if gates.PrometheusOperator {
+		lists = append(lists, &monitoringv1.ServiceMonitorList{}, &monitoringv1.PrometheusRuleList{})
+	}
+	if gates.OpenShift.OpenShiftRoute {
+		lists = append(lists, &routev1.RouteList{})
+	}
+	if gates.GatewayAPI {
+		lists = append(lists, &gatewayv1.HTTPRouteList{})
+	}
+	if gates.GrafanaOperator {
+		lists = append(lists, &grafanav1.GrafanaDatasourceList{})
+	}
+	return lists
+}
+
+// listOwnedObjects lists the objects of a CR, which are pruned if the operator doesn't manage them anymore.
+// Namespaced objects are selected by the common labels, and cluster-scoped objects by the cluster-scoped labels.
+func listOwnedObjects(ctx context.Context, c client.Client, namespace string, lists []client.ObjectList, commonLabels, clusterScopedLabels map[string]string) ([]client.Object, error) {
+	ownedObjects := []client.Object{}
+	for _, list := range lists {
+		listOps := &client.ListOptions{
+			Namespace:     namespace,
+			LabelSelector: labels.SelectorFromSet(commonLabels),
+		}
+		switch list.(type) {
+		case *rbacv1.ClusterRoleList, *rbacv1.ClusterRoleBindingList:
+			listOps = &client.ListOptions{
+				LabelSelector: labels.SelectorFromSet(clusterScopedLabels),
+			}
+		}
+
+		err := c.List(ctx, list, listOps)
+		if meta.IsNoMatchError(err) {
+			// the CRD is not installed in the cluster
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing %T: %w", list, err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			ownedObjects = append(ownedObjects, item.(client.Object))
+		}
+	}
+	return ownedObjects, nil
+}
@@ -0,0 +1,132 @@
+package desiredstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cloudcredentialv1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/storage"
+	"github.com/grafana/tempo-operator/internal/manifests/cloudcredentials"
+	"github.com/grafana/tempo-operator/internal/manifests/monolithic"
+	"github.com/grafana/tempo-operator/internal/status"
+	"github.com/grafana/tempo-operator/internal/tlsprofile"
+)
+
+// TempoMonolithicCredentialsRequests returns the CredentialsRequests of a TempoMonolithic with the token-cco credential mode,
+// and the CredentialsRequests of the TempoMonolithic in the cluster.
+// The state is empty if the TempoMonolithic doesn't use the token-cco credential mode.
+func TempoMonolithicCredentialsRequests(ctx context.Context, c client.Client, tempo v1alpha1.TempoMonolithic) (State, error) {
+	if monolithicCredentialMode(tempo) != v1alpha1.CredentialModeTokenCCO {
+		return State{}, nil
+	}
+
+	// We can use this before inferred, as COO mode cannot be inferred and need to be set explicit
+	// if is not set at this point, we need to clean up resources.
+	tokenCCOAuthEnv := cloudcredentials.DiscoverTokenCCOAuthConfig()
+	if tokenCCOAuthEnv == nil {
+		return State{}, errors.New("cannot configure tempo in CCO mode without CCO environment")
+	}
+
+	objects, err := cloudcredentials.BuildCredentialsRequest(&tempo, tempo.Spec.ServiceAccount, tokenCCOAuthEnv)
+	if err != nil {
+		return State{}, err
+	}
+
+	ownedObjects, err := listOwnedObjects(ctx, c, tempo.Namespace, []client.ObjectList{&cloudcredentialv1.CredentialsRequestList{}},
+		monolithic.CommonLabels(tempo.Name), monolithic.ClusterScopedCommonLabels(tempo.ObjectMeta))
+	if err != nil {
+		return State{}, err
+	}
+
+	return State{Objects: objects, OwnedObjects: ownedObjects}, nil
+}
+
+// TempoMonolithic builds the desired state of a TempoMonolithic.
+// The defaults must be applied to the TempoMonolithic before.
+func TempoMonolithic(ctx context.Context, c client.Client, opts Options, tempo v1alpha1.TempoMonolithic) (State, error) {
+	buildOpts := monolithic.Options{
+		CtrlConfig: *opts.CtrlConfig,
+		Tempo:      tempo,
+	}
+
+	var errs field.ErrorList
+	buildOpts.StorageParams, errs = storage.GetStorageParamsForTempoMonolithic(ctx, c, tempo)
+	if len(errs) > 0 {
+		return State{}, &status.ConfigurationError{
+			Reason:  v1alpha1.ReasonInvalidStorageConfig,
+			Message: listFieldErrors(errs),
+		}
+	}
+
+	var err error
+	if tempo.Spec.Multitenancy.IsGatewayEnabled() {
+		buildOpts.GatewayTenantSecret, buildOpts.GatewayTenantsData, err = getTenantParams(ctx, c, opts.CtrlConfig, tempo.Namespace, tempo.Name, tempo.Spec.Multitenancy.TenantsSpec, true)
+		if err != nil {
+			return State{}, err
+		}
+		// The OpenShift base domain is discovered while fetching the tenant parameters.
+		buildOpts.CtrlConfig = *opts.CtrlConfig
+	}
+
+	buildOpts.TLSProfile, err = tlsprofile.Get(ctx, opts.CtrlConfig.Gates, c)
+	if err != nil {
+		switch err {
+		case tlsprofile.ErrGetProfileFromCluster, tlsprofile.ErrGetInvalidProfile:
+			return State{}, &status.ConfigurationError{
+				Message: err.Error(),
+				Reason:  v1alpha1.ReasonCouldNotGetOpenShiftTLSPolicy,
+			}
+		default:
+			return State{}, err
+		}
+	}
+
+	var state State
+	state.Objects, err = monolithic.BuildAll(buildOpts)
+	if err != nil {
+		return State{}, fmt.Errorf("error building manifests: %w", err)
+	}
+
+	state.OwnedObjects, err = listOwnedObjects(ctx, c, tempo.Namespace, tempoMonolithicOwnedLists(opts.CtrlConfig.Gates),
+		monolithic.CommonLabels(tempo.Name), monolithic.ClusterScopedCommonLabels(tempo.ObjectMeta))
+	if err != nil {
+		return State{}, err
+	}
+
+	return state, nil
+}
+
+func monolithicCredentialMode(tempo v1alpha1.TempoMonolithic) v1alpha1.CredentialMode {
+	if tempo.Spec.Storage != nil && tempo.Spec.Storage.Traces.Backend == v1alpha1.MonolithicTracesStorageBackendS3 && tempo.Spec.Storage.Traces.S3 != nil {
+		return tempo.Spec.Storage.Traces.S3.CredentialMode
+	}
+
+	// We only support inference mode for others, so return empty string
+	return ""
+}
+
+// tempoMonolithicOwnedLists returns the lists of objects which the operator can conditionally create for a TempoMonolithic.
+// For example, Ingress and Route can be enabled or disabled in the CR.
+func tempoMonolithicOwnedLists(gates configv1alpha1.FeatureGates) []client.ObjectList {
+	lists := []client.ObjectList{
+		&corev1.ServiceList{},
+		&networkingv1.IngressList{},
+		&networkingv1.NetworkPolicyList{},
+		// metrics reader for Jaeger UI Monitor Tab
+		&rbacv1.RoleList{},
+		&rbacv1.RoleBindingList{},
+		// TokenReview and SubjectAccessReview when gateway is configured with multi-tenancy in OpenShift mode
+		&rbacv1.ClusterRoleList{},
+		&rbacv1.ClusterRoleBindingList{},
+	}
+	return append(lists, gatedOwnedLists(gates)...)
+}
@@ -0,0 +1,279 @@
+package desiredstate
+
+import (
+	"context"
+	"fmt"
+
+	cloudcredentialv1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/storage"
+	"github.com/grafana/tempo-operator/internal/handlers/tenants"
+	"github.com/grafana/tempo-operator/internal/manifests"
+	"github.com/grafana/tempo-operator/internal/manifests/cloudcredentials"
+	"github.com/grafana/tempo-operator/internal/manifests/ingester"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/storagemigration"
+	"github.com/grafana/tempo-operator/internal/status"
+	"github.com/grafana/tempo-operator/internal/tlsprofile"
+)
+
+// TempoStackCredentialsRequests returns the CredentialsRequests of a TempoStack with the token-cco credential mode,
+// and the CredentialsRequests of the TempoStack in the cluster.
+// The state is empty if the TempoStack doesn't use the token-cco credential mode.
+func TempoStackCredentialsRequests(ctx context.Context, c client.Client, tempo v1alpha1.TempoStack) (State, error) {
+	if tempo.Spec.Storage.Secret.CredentialMode != v1alpha1.CredentialModeTokenCCO {
+		return State{}, nil
+	}
+
+	// We can use this before inferred, as COO mode cannot be inferred and need to be set explicit
+	// if is not set at this point, we need to clean up resources.
+	tokenCCOAuthEnv := cloudcredentials.DiscoverTokenCCOAuthConfig()
+	if tokenCCOAuthEnv == nil {
+		return State{}, &status.ConfigurationError{
+			Reason: v1alpha1.ReasonInvalidStorageConfig,
+			Message: listFieldErrors(
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec", "storage").Child("credentialMode"),
+						v1alpha1.CredentialModeTokenCCO,
+						"cannot configure tempo in CCO mode without CCO environment",
+					),
+				}),
+		}
+	}
+
+	objects, err := cloudcredentials.BuildCredentialsRequest(&tempo, tempo.Spec.ServiceAccount, tokenCCOAuthEnv)
+	if err != nil {
+		return State{}, err
+	}
+
+	ownedObjects, err := listOwnedObjects(ctx, c, tempo.Namespace, []client.ObjectList{&cloudcredentialv1.CredentialsRequestList{}},
+		manifestutils.CommonLabels(tempo.Name), manifestutils.ClusterScopedCommonLabels(tempo.ObjectMeta))
+	if err != nil {
+		return State{}, err
+	}
+
+	return State{Objects: objects, OwnedObjects: ownedObjects}, nil
+}
+
+// TempoStack builds the desired state of a TempoStack.
+//
+// The per-tenant configuration of the TempoTenant resources is merged into the TempoStack,
+// the components use the previous object storage until all objects of a storage migration are copied,
+// and the ingester zones are updated one zone at a time.
+func TempoStack(ctx context.Context, c client.Client, opts Options, tempo v1alpha1.TempoStack) (State, error) {
+	// Apply the per-tenant configuration declared in TempoTenant resources.
+	// The status of the TempoTenant resources is updated by the TempoTenant controller.
+	tempoTenants, err := tenants.GetTempoTenants(ctx, c, tempo)
+	if err != nil {
+		return State{}, err
+	}
+	tempo, _ = tenants.Merge(tempo, tempoTenants)
+
+	params := manifestutils.Params{
+		Tempo:      tempo,
+		CtrlConfig: *opts.CtrlConfig,
+	}
+
+	var errs field.ErrorList
+	params.StorageParams, errs = storage.GetStorageParamsForTempoStack(ctx, c, tempo)
+	params.StorageParams.CloudCredentials.Environment = cloudcredentials.DiscoverTokenCCOAuthConfig()
+
+	if len(errs) > 0 {
+		return State{}, &status.ConfigurationError{
+			Reason:  v1alpha1.ReasonInvalidStorageConfig,
+			Message: listFieldErrors(errs),
+		}
+	}
+
+	// Don't roll out a new or changed storage configuration if the object storage is not usable.
+	// A transient failure of a storage configuration which was checked successfully before doesn't block the rollout.
+	if opts.StoragePreflight != nil {
+		storageReady, verified := opts.StoragePreflight.Check(ctx, tempo, params.StorageParams)
+		if storageReady.Status == metav1.ConditionFalse && !verified {
+			return State{}, fmt.Errorf("storage preflight check failed: %s", storageReady.Message)
+		}
+	}
+
+	var state State
+	// The components use the previous object storage until all objects of a storage migration are copied.
+	state.StorageMigrationJobs, err = storageMigration(ctx, c, &params)
+	if err != nil {
+		return State{}, err
+	}
+
+	if tempo.Spec.Tenants != nil {
+		params.GatewayTenantSecret, params.GatewayTenantsData, err = getTenantParams(ctx, c, opts.CtrlConfig, tempo.Namespace, tempo.Name, *tempo.Spec.Tenants, tempo.Spec.Template.Gateway.Enabled)
+		if err != nil {
+			return State{}, err
+		}
+		// The OpenShift base domain is discovered while fetching the tenant parameters.
+		params.CtrlConfig = *opts.CtrlConfig
+	}
+
+	params.TLSProfile, err = tlsprofile.Get(ctx, opts.CtrlConfig.Gates, c)
+	if err != nil {
+		switch err {
+		case tlsprofile.ErrGetProfileFromCluster:
+		case tlsprofile.ErrGetInvalidProfile:
+			return State{}, &status.ConfigurationError{
+				Message: err.Error(),
+				Reason:  v1alpha1.ReasonCouldNotGetOpenShiftTLSPolicy,
+			}
+		default:
+			return State{}, err
+		}
+	}
+
+	state.Objects, err = manifests.BuildAll(params)
+	// TODO (pavolloffay) check error type and change return appropriately
+	if err != nil {
+		return State{}, fmt.Errorf("error building manifests: %w", err)
+	}
+
+	if len(manifestutils.IngesterZones(tempo)) > 0 {
+		state.Objects, err = deferIngesterZoneUpdates(ctx, c, tempo, state.Objects)
+		if err != nil {
+			return State{}, err
+		}
+	}
+
+	// Collect all objects owned by the operator, to be able to prune objects
+	// which exist in the cluster but are not managed by the operator anymore.
+	// For example, when the Jaeger Query Ingress is enabled and later disabled,
+	// the Ingress object should be removed from the cluster.
+	state.OwnedObjects, err = listOwnedObjects(ctx, c, tempo.Namespace, tempoStackOwnedLists(opts.CtrlConfig.Gates),
+		manifestutils.CommonLabels(tempo.Name), manifestutils.ClusterScopedCommonLabels(tempo.ObjectMeta))
+	if err != nil {
+		return State{}, err
+	}
+
+	return state, nil
+}
+
+// storageMigration returns the Jobs of the storage migration, and switches the components to the previous
+// object storage until all objects are copied. The params must contain the storage parameters of the new object storage.
+//
+// The phases of the migration are derived from the Jobs:
+//   - copy: the copy Job runs, the components use the previous object storage.
+//   - sync: the copy Job succeeded, the components use the new object storage,
+//     and the sync Job copies the objects written during the copy phase.
+//
+// If the copy Job fails, or the copy Job in the cluster was created for a different storage configuration,
+// the components keep using the previous object storage.
+func storageMigration(ctx context.Context, c client.Client, params *manifestutils.Params) ([]*batchv1.Job, error) {
+	tempo := params.Tempo
+	if tempo.Spec.Storage.Migration == nil {
+		return nil, nil
+	}
+
+	sourceTempo := storagemigration.SourceTempoStack(tempo)
+	sourceParams, errs := storage.GetStorageParamsForTempoStack(ctx, c, sourceTempo)
+	if len(errs) > 0 {
+		return nil, &status.ConfigurationError{
+			Reason:  v1alpha1.ReasonInvalidStorageConfig,
+			Message: fmt.Sprintf("invalid storage migration source: %s", listFieldErrors(errs)),
+		}
+	}
+
+	if err := storagemigration.Validate(*params, sourceParams); err != nil {
+		return nil, &status.ConfigurationError{
+			Reason:  v1alpha1.ReasonInvalidStorageConfig,
+			Message: fmt.Sprintf("invalid storage migration: %s", err),
+		}
+	}
+
+	copyJob, err := storagemigration.BuildJob(*params, sourceParams, storagemigration.PhaseCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := &batchv1.Job{}
+	err = c.Get(ctx, client.ObjectKeyFromObject(copyJob), existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error getting storage migration job: %w", err)
+	}
+	copied := err == nil &&
+		existing.Annotations[storagemigration.ChecksumAnnotation] == copyJob.Annotations[storagemigration.ChecksumAnnotation] &&
+		storagemigration.CopyComplete(existing)
+	if !copied {
+		// Keep the components on the previous object storage until all objects are copied.
+		params.Tempo = sourceTempo
+		params.StorageParams = sourceParams
+		return []*batchv1.Job{copyJob}, nil
+	}
+
+	syncJob, err := storagemigration.BuildJob(*params, sourceParams, storagemigration.PhaseSync)
+	if err != nil {
+		return nil, err
+	}
+	return []*batchv1.Job{copyJob, syncJob}, nil
+}
+
+// deferIngesterZoneUpdates updates the pod templates of the ingester zone StatefulSets one zone at a time,
+// and retains the ingester StatefulSet without zones until the ingesters of all zones are available.
+// The reconciliation is triggered again once the status of the ingester StatefulSets changes.
+func deferIngesterZoneUpdates(ctx context.Context, c client.Client, tempo v1alpha1.TempoStack, managedObjects []client.Object) ([]client.Object, error) {
+	statefulSetList := &appsv1.StatefulSetList{}
+	err := c.List(ctx, statefulSetList, &client.ListOptions{
+		Namespace:     tempo.GetNamespace(),
+		LabelSelector: labels.SelectorFromSet(manifestutils.ComponentLabels(manifestutils.IngesterComponentName, tempo.Name)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ingester stateful sets: %w", err)
+	}
+
+	existing := map[string]*appsv1.StatefulSet{}
+	for i := range statefulSetList.Items {
+		existing[statefulSetList.Items[i].Name] = &statefulSetList.Items[i]
+	}
+
+	deferred := ingester.DeferZoneUpdates(managedObjects, existing)
+	if len(deferred) > 0 {
+		log := ctrl.LoggerFrom(ctx)
+		log.Info("waiting for the previous ingester zones to be rolled out", "deferredZones", deferred)
+	}
+	return ingester.RetainIngesterWithoutZones(managedObjects, existing, tempo.Name), nil
+}
+
+// tempoStackOwnedLists returns the lists of objects which the operator can conditionally create for a TempoStack.
+// For example, Ingress and Route can be enabled or disabled in the CR.
+func tempoStackOwnedLists(gates configv1alpha1.FeatureGates) []client.ObjectList {
+	lists := []client.ObjectList{
+		&networkingv1.IngressList{},
+		// metrics-generator can be enabled or disabled in the CR
+		&appsv1.StatefulSetList{},
+		&corev1.ServiceList{},
+		// autoscaling can be enabled or disabled per component in the CR
+		&autoscalingv2.HorizontalPodAutoscalerList{},
+		// the gateway, metrics-generator and managed memcached can be enabled or disabled in the CR
+		&policyv1.PodDisruptionBudgetList{},
+		&networkingv1.NetworkPolicyList{},
+		// metrics reader for Jaeger UI Monitor Tab
+		&rbacv1.RoleList{},
+		&rbacv1.RoleBindingList{},
+		// TokenReview and SubjectAccessReview when gateway is configured with multi-tenancy in OpenShift mode
+		&rbacv1.ClusterRoleList{},
+		&rbacv1.ClusterRoleBindingList{},
+	}
+	if gates.GatewayAPI {
+		lists = append(lists, &gatewayv1.GRPCRouteList{})
+	}
+	return append(lists, gatedOwnedLists(gates)...)
+}
@@ -0,0 +1,188 @@
+package desiredstate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/storagemigration"
+	"github.com/grafana/tempo-operator/internal/status"
+	"github.com/grafana/tempo-operator/internal/webhooks"
+)
+
+func testClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+
+	storageSecrets := []client.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3", Namespace: "default"},
+			Data: map[string][]byte{
+				"endpoint":          []byte("https://s3.eu-central-1.amazonaws.com"),
+				"bucket":            []byte("tempo"),
+				"access_key_id":     []byte("id"),
+				"access_key_secret": []byte("secret"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: "default"},
+			Data: map[string][]byte{
+				"endpoint":          []byte("http://minio:9000"),
+				"bucket":            []byte("tempo"),
+				"access_key_id":     []byte("id"),
+				"access_key_secret": []byte("secret"),
+			},
+		},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(storageSecrets, objects...)...).Build()
+}
+
+func testOptions() Options {
+	return Options{
+		CtrlConfig: &configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Tempo:      "docker.io/grafana/tempo:x.y.z",
+				TempoQuery: "docker.io/grafana/tempo-query:x.y.z",
+				Rclone:     "docker.io/rclone/rclone:x.y.z",
+			},
+			Gates: configv1alpha1.FeatureGates{
+				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+			},
+		},
+	}
+}
+
+func testTempoStack(t *testing.T) v1alpha1.TempoStack {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "s3",
+					Type: v1alpha1.ObjectStorageSecretS3,
+				},
+			},
+		},
+	}
+	require.NoError(t, webhooks.NewDefaulter(*testOptions().CtrlConfig).Default(context.Background(), &tempo))
+	return tempo
+}
+
+func tempoConfig(t *testing.T, objects []client.Object) string {
+	for _, obj := range objects {
+		if cm, ok := obj.(*corev1.ConfigMap); ok && cm.Name == "tempo-simplest" {
+			return cm.Data["tempo.yaml"]
+		}
+	}
+	require.Fail(t, "tempo ConfigMap not found")
+	return ""
+}
+
+func TestTempoStackStorageMigration(t *testing.T) {
+	tempo := testTempoStack(t)
+	tempo.Spec.Storage.Migration = &v1alpha1.StorageMigrationSpec{
+		Source: v1alpha1.ObjectStorageSecretSpec{
+			Name: "minio",
+			Type: v1alpha1.ObjectStorageSecretS3,
+		},
+	}
+	c := testClient(t)
+
+	// the components use the previous object storage while the objects are copied
+	state, err := TempoStack(context.Background(), c, testOptions(), tempo)
+	require.NoError(t, err)
+	require.Len(t, state.StorageMigrationJobs, 1)
+	copyJob := state.StorageMigrationJobs[0]
+	assert.Equal(t, storagemigration.JobName("simplest", storagemigration.PhaseCopy), copyJob.Name)
+	assert.Contains(t, tempoConfig(t, state.Objects), "endpoint: minio:9000")
+
+	// a copy Job of a different storage configuration doesn't switch the components
+	copyJob.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	staleJob := copyJob.DeepCopy()
+	staleJob.Annotations = map[string]string{storagemigration.ChecksumAnnotation: "stale"}
+	require.NoError(t, c.Create(context.Background(), staleJob))
+
+	state, err = TempoStack(context.Background(), c, testOptions(), tempo)
+	require.NoError(t, err)
+	require.Len(t, state.StorageMigrationJobs, 1)
+	assert.Contains(t, tempoConfig(t, state.Objects), "endpoint: minio:9000")
+
+	// the components use the new object storage once all objects are copied
+	require.NoError(t, c.Delete(context.Background(), staleJob))
+	copyJob.ResourceVersion = ""
+	require.NoError(t, c.Create(context.Background(), copyJob))
+
+	state, err = TempoStack(context.Background(), c, testOptions(), tempo)
+	require.NoError(t, err)
+	require.Len(t, state.StorageMigrationJobs, 2)
+	assert.Equal(t, storagemigration.JobName("simplest", storagemigration.PhaseSync), state.StorageMigrationJobs[1].Name)
+	assert.Contains(t, tempoConfig(t, state.Objects), "endpoint: s3.eu-central-1.amazonaws.com")
+}
+
+func TestTempoStackOwnedObjects(t *testing.T) {
+	orphan := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-simplest-orphan",
+			Namespace: "default",
+			Labels:    manifestutils.CommonLabels("simplest"),
+		},
+	}
+	other := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-other-querier",
+			Namespace: "default",
+			Labels:    manifestutils.CommonLabels("other"),
+		},
+	}
+	c := testClient(t, orphan, other)
+
+	state, err := TempoStack(context.Background(), c, testOptions(), testTempoStack(t))
+	require.NoError(t, err)
+	require.Len(t, state.OwnedObjects, 1)
+	assert.Equal(t, "tempo-simplest-orphan", state.OwnedObjects[0].GetName())
+	assert.Empty(t, state.StorageMigrationJobs)
+}
+
+func TestTempoStackInvalidTenants(t *testing.T) {
+	tempo := testTempoStack(t)
+	tempo.Spec.Tenants = &v1alpha1.TenantsSpec{
+		Mode: v1alpha1.ModeOpenShift,
+	}
+
+	_, err := TempoStack(context.Background(), testClient(t), testOptions(), tempo)
+	var configErr *status.ConfigurationError
+	require.ErrorAs(t, err, &configErr)
+	assert.Equal(t, v1alpha1.ReasonInvalidTenantsConfiguration, configErr.Reason)
+}
+
+func TestTempoStackCredentialsRequests(t *testing.T) {
+	tempo := testTempoStack(t)
+
+	state, err := TempoStackCredentialsRequests(context.Background(), testClient(t), tempo)
+	require.NoError(t, err)
+	assert.Empty(t, state.Objects)
+	assert.Empty(t, state.OwnedObjects)
+
+	tempo.Spec.Storage.Secret.CredentialMode = v1alpha1.CredentialModeTokenCCO
+	_, err = TempoStackCredentialsRequests(context.Background(), testClient(t), tempo)
+	var configErr *status.ConfigurationError
+	require.ErrorAs(t, err, &configErr)
+	assert.Equal(t, v1alpha1.ReasonInvalidStorageConfig, configErr.Reason)
+}
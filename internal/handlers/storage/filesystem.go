@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+)
+
+var (
+	// ErrFetchingPersistentVolumeClaim is used in the webhook to not fail validation when there was an error retrieving the PersistentVolumeClaim.
+	ErrFetchingPersistentVolumeClaim = "could not fetch PersistentVolumeClaim"
+)
+
+// validateFilesystem checks that the PersistentVolumeClaim of the filesystem storage exists
+// and can be mounted by all Tempo components at the same time.
+func validateFilesystem(ctx context.Context, client client.Client, namespace string, spec v1alpha1.FilesystemStorageSpec, path *field.Path) field.ErrorList {
+	claimNamePath := path.Child("claimName")
+
+	var pvc corev1.PersistentVolumeClaim
+	err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: spec.ClaimName}, &pvc)
+	if err != nil {
+		return field.ErrorList{field.Invalid(claimNamePath, spec.ClaimName, fmt.Sprintf("%s: %v", ErrFetchingPersistentVolumeClaim, err))}
+	}
+
+	if !slices.Contains(pvc.Spec.AccessModes, corev1.ReadWriteMany) {
+		return field.ErrorList{field.Invalid(
+			claimNamePath,
+			spec.ClaimName,
+			"PersistentVolumeClaim must support the ReadWriteMany access mode, because it is mounted by all Tempo components",
+		)}
+	}
+
+	return nil
+}
@@ -0,0 +1,326 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+const (
+	// preflightObjectPrefix is the prefix of the probe object, relative to the object prefix of the storage secret.
+	// Tempo ignores objects outside of the tenant directories.
+	preflightObjectPrefix = "tempo-operator-preflight"
+
+	preflightTimeout = 10 * time.Second
+
+	// preflightMinBackoff and preflightMaxBackoff bound the delay before a failed check of an unchanged
+	// storage configuration is repeated.
+	preflightMinBackoff = 10 * time.Second
+	preflightMaxBackoff = 5 * time.Minute
+)
+
+var (
+	// ErrBucketNotFound is returned by an ObjectStorage if the bucket does not exist.
+	ErrBucketNotFound = errors.New("bucket does not exist")
+	// ErrAccessDenied is returned by an ObjectStorage if the credentials are invalid or lack permissions.
+	ErrAccessDenied = errors.New("access denied")
+)
+
+// ObjectStorage is the subset of object storage operations used by the storage preflight check.
+// The interface allows replacing the object storage with a local stand-in.
+type ObjectStorage interface {
+	// List lists the objects with the given prefix.
+	List(ctx context.Context, prefix string) error
+	// Put writes an object.
+	Put(ctx context.Context, key string, data []byte) error
+	// Delete deletes an object.
+	Delete(ctx context.Context, key string) error
+}
+
+// S3Credentials contains the static credentials and the TLS configuration
+// used to connect to an S3-compatible object storage.
+type S3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	TLSConfig       *tls.Config
+}
+
+// S3ObjectStorageFactory creates an ObjectStorage for an S3-compatible object storage.
+type S3ObjectStorageFactory func(params manifestutils.S3, credentials S3Credentials) (ObjectStorage, error)
+
+type preflightResult struct {
+	checksum  string
+	condition metav1.Condition
+	// verifiedChecksum is the checksum of the last storage configuration which was checked successfully.
+	verifiedChecksum string
+	failures         int
+	nextCheck        time.Time
+}
+
+// Preflight checks that the object storage of a TempoStack is usable with the resolved credentials and TLS settings,
+// before the Tempo components are rolled out.
+//
+// Successful checks are cached until the storage configuration changes.
+// Failed checks of an unchanged storage configuration are repeated with an exponential backoff.
+type Preflight struct {
+	client      client.Client
+	newS3Client S3ObjectStorageFactory
+	now         func() time.Time
+
+	mu      sync.Mutex
+	results map[types.NamespacedName]preflightResult
+}
+
+// NewPreflight creates a new storage preflight check.
+func NewPreflight(client client.Client, newS3Client S3ObjectStorageFactory) *Preflight {
+	return &Preflight{
+		client:      client,
+		newS3Client: newS3Client,
+		now:         time.Now,
+		results:     map[types.NamespacedName]preflightResult{},
+	}
+}
+
+// Check lists, writes and deletes a probe object in the object storage of the TempoStack,
+// and returns the StorageReady condition.
+// The returned bool reports whether the current storage configuration was checked successfully before,
+// i.e. a failed check is a transient failure of a storage configuration which is already in use.
+// The storage parameters must be validated by GetStorageParamsForTempoStack before.
+func (p *Preflight) Check(ctx context.Context, tempo v1alpha1.TempoStack, params manifestutils.StorageParams) (metav1.Condition, bool) {
+	key := types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Name}
+
+	if reason, message, supported := preflightSupported(tempo, params); !supported {
+		condition := storageCondition(metav1.ConditionUnknown, reason, message)
+		p.store(key, preflightResult{condition: condition})
+		return condition, false
+	}
+
+	previous, ok := p.result(key)
+	credentials, checksum, err := p.getS3Credentials(ctx, tempo, params)
+	if err != nil {
+		condition := storageCondition(metav1.ConditionFalse, v1alpha1.ReasonStorageCheckFailed, err.Error())
+		p.store(key, preflightResult{condition: condition, verifiedChecksum: previous.verifiedChecksum})
+		return condition, false
+	}
+
+	if !ok && meta.IsStatusConditionTrue(tempo.Status.Conditions, string(v1alpha1.ConditionStorageReady)) {
+		// The operator restarted, the TempoStack already runs with a storage configuration which was checked successfully.
+		previous.verifiedChecksum = checksum
+	}
+
+	if previous.checksum == checksum {
+		if previous.condition.Status == metav1.ConditionTrue || p.now().Before(previous.nextCheck) {
+			return previous.condition, previous.verifiedChecksum == checksum
+		}
+	} else {
+		previous.failures = 0
+	}
+
+	result := preflightResult{
+		checksum:         checksum,
+		condition:        p.probe(ctx, *params.S3, credentials),
+		verifiedChecksum: previous.verifiedChecksum,
+	}
+	if result.condition.Status == metav1.ConditionTrue {
+		result.verifiedChecksum = checksum
+	} else {
+		result.failures = previous.failures + 1
+		result.nextCheck = p.now().Add(preflightBackoff(result.failures))
+	}
+	p.store(key, result)
+	return result.condition, result.verifiedChecksum == checksum
+}
+
+// preflightBackoff returns the delay before a failed check is repeated.
+func preflightBackoff(failures int) time.Duration {
+	backoff := preflightMinBackoff
+	for i := 1; i < failures && backoff < preflightMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, preflightMaxBackoff)
+}
+
+// Result returns the StorageReady condition of the last check of a TempoStack.
+func (p *Preflight) Result(key types.NamespacedName) (metav1.Condition, bool) {
+	result, ok := p.result(key)
+	return result.condition, ok
+}
+
+// Forget removes the cached result of a TempoStack, e.g. after it was deleted.
+func (p *Preflight) Forget(key types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.results, key)
+}
+
+func (p *Preflight) result(key types.NamespacedName) (preflightResult, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	result, ok := p.results[key]
+	return result, ok
+}
+
+func (p *Preflight) store(key types.NamespacedName, result preflightResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.results[key] = result
+}
+
+// preflightSupported returns false if the operator cannot access the object storage with the credentials of the Tempo pods.
+// Short-lived credentials are bound to the service account of the Tempo pods.
+func preflightSupported(tempo v1alpha1.TempoStack, params manifestutils.StorageParams) (v1alpha1.ConditionReason, string, bool) {
+	if tempo.Spec.Storage.Filesystem != nil {
+		return v1alpha1.ReasonStorageCheckSkipped, "the preflight check is not supported for filesystem storage", false
+	}
+
+	switch tempo.Spec.Storage.Secret.Type {
+	case v1alpha1.ObjectStorageSecretS3, v1alpha1.ObjectStorageSecretSwift:
+		if params.CredentialMode != v1alpha1.CredentialModeStatic {
+			return v1alpha1.ReasonStorageCheckSkipped,
+				fmt.Sprintf("the preflight check is not supported for the %s credential mode", params.CredentialMode), false
+		}
+		return "", "", true
+	default:
+		return v1alpha1.ReasonStorageCheckSkipped,
+			fmt.Sprintf("the preflight check is not supported for the %s storage secret type", tempo.Spec.Storage.Secret.Type), false
+	}
+}
+
+// getS3Credentials returns the credentials and TLS configuration of the Tempo pods,
+// and a checksum of the storage configuration.
+func (p *Preflight) getS3Credentials(ctx context.Context, tempo v1alpha1.TempoStack, params manifestutils.StorageParams) (S3Credentials, string, error) {
+	var storageSecret corev1.Secret
+	err := p.client.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Spec.Storage.Secret.Name}, &storageSecret)
+	if err != nil {
+		return S3Credentials{}, "", fmt.Errorf("%s: %w", ErrFetchingSecret, err)
+	}
+
+	credentials := S3Credentials{
+		AccessKeyID:     string(storageSecret.Data["access_key_id"]),
+		SecretAccessKey: string(storageSecret.Data["access_key_secret"]),
+	}
+
+	h := sha256.New()
+	secretHash, err := hashSecretData(&storageSecret)
+	if err != nil {
+		return S3Credentials{}, "", err
+	}
+	h.Write([]byte(secretHash))
+
+	paramsJSON, err := json.Marshal(params.S3)
+	if err != nil {
+		return S3Credentials{}, "", err
+	}
+	h.Write(paramsJSON)
+
+	tlsSpec := tempo.Spec.Storage.TLS
+	if tlsSpec.Enabled {
+		credentials.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if tlsSpec.CA != "" {
+			var caConfigMap corev1.ConfigMap
+			err := p.client.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: tlsSpec.CA}, &caConfigMap)
+			if err != nil {
+				return S3Credentials{}, "", fmt.Errorf("%s: %w", ErrFetchingConfigMap, err)
+			}
+
+			ca := caConfigMap.Data[params.S3.TLS.CAFilename]
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM([]byte(ca)) {
+				return S3Credentials{}, "", fmt.Errorf("CA ConfigMap %s does not contain a valid PEM certificate", tlsSpec.CA)
+			}
+			credentials.TLSConfig.RootCAs = pool
+			h.Write([]byte(ca))
+		}
+
+		if tlsSpec.Cert != "" {
+			var certSecret corev1.Secret
+			err := p.client.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: tlsSpec.Cert}, &certSecret)
+			if err != nil {
+				return S3Credentials{}, "", fmt.Errorf("%s: %w", ErrFetchingSecret, err)
+			}
+
+			cert, err := tls.X509KeyPair(certSecret.Data[corev1.TLSCertKey], certSecret.Data[corev1.TLSPrivateKeyKey])
+			if err != nil {
+				return S3Credentials{}, "", fmt.Errorf("invalid client certificate in Secret %s: %w", tlsSpec.Cert, err)
+			}
+			credentials.TLSConfig.Certificates = []tls.Certificate{cert}
+			h.Write(certSecret.Data[corev1.TLSCertKey])
+		}
+	}
+
+	return credentials, fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func (p *Preflight) probe(ctx context.Context, params manifestutils.S3, credentials S3Credentials) metav1.Condition {
+	storage, err := p.newS3Client(params, credentials)
+	if err != nil {
+		return storageCondition(metav1.ConditionFalse, v1alpha1.ReasonStorageCheckFailed, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, preflightTimeout)
+	defer cancel()
+
+	prefix := path.Join(params.Prefix, preflightObjectPrefix)
+	key := path.Join(prefix, "probe")
+
+	if err := storage.List(ctx, prefix); err != nil {
+		return probeErrorCondition("list objects", params.Bucket, err)
+	}
+	if err := storage.Put(ctx, key, []byte("tempo-operator storage preflight check")); err != nil {
+		return probeErrorCondition(fmt.Sprintf("write object %s", key), params.Bucket, err)
+	}
+	if err := storage.Delete(ctx, key); err != nil {
+		return probeErrorCondition(fmt.Sprintf("delete object %s", key), params.Bucket, err)
+	}
+
+	return storageCondition(metav1.ConditionTrue, v1alpha1.ReasonStorageReady,
+		fmt.Sprintf("listed, wrote and deleted a probe object in bucket %s", params.Bucket))
+}
+
+func probeErrorCondition(operation string, bucket string, err error) metav1.Condition {
+	message := fmt.Sprintf("could not %s in bucket %s: %v", operation, bucket, err)
+
+	var netErr net.Error
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	switch {
+	case errors.Is(err, ErrBucketNotFound):
+		return storageCondition(metav1.ConditionFalse, v1alpha1.ReasonStorageBucketNotFound, message)
+	case errors.Is(err, ErrAccessDenied):
+		return storageCondition(metav1.ConditionFalse, v1alpha1.ReasonStorageAccessDenied, message)
+	case errors.As(err, &certErr), errors.As(err, &unknownAuthorityErr), errors.As(err, &hostnameErr):
+		return storageCondition(metav1.ConditionFalse, v1alpha1.ReasonStorageTLSError, message)
+	case errors.As(err, &netErr):
+		return storageCondition(metav1.ConditionFalse, v1alpha1.ReasonStorageUnreachable, message)
+	default:
+		return storageCondition(metav1.ConditionFalse, v1alpha1.ReasonStorageCheckFailed, message)
+	}
+}
+
+func storageCondition(status metav1.ConditionStatus, reason v1alpha1.ConditionReason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    string(v1alpha1.ConditionStorageReady),
+		Status:  status,
+		Reason:  string(reason),
+		Message: message,
+	}
+}
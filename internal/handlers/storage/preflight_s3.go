@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+type s3ObjectStorage struct {
+	client *minio.Client
+	bucket string
+	sse    encrypt.ServerSide
+}
+
+// NewS3ObjectStorage creates an ObjectStorage for an S3-compatible object storage,
+// using the same endpoint, addressing style and server-side encryption settings as Tempo.
+func NewS3ObjectStorage(params manifestutils.S3, creds S3Credentials) (ObjectStorage, error) {
+	transport, err := minio.DefaultTransport(!params.Insecure)
+	if err != nil {
+		return nil, err
+	}
+	if creds.TLSConfig != nil {
+		transport.TLSClientConfig = creds.TLSConfig
+	}
+
+	bucketLookup := minio.BucketLookupAuto
+	if params.ForcePathStyle {
+		bucketLookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(params.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(creds.AccessKeyID, creds.SecretAccessKey, ""),
+		Secure:       !params.Insecure,
+		Transport:    transport,
+		Region:       params.Region,
+		BucketLookup: bucketLookup,
+		MaxRetries:   1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	storage := &s3ObjectStorage{
+		client: client,
+		bucket: params.Bucket,
+	}
+
+	if params.SSE != nil {
+		switch params.SSE.Type {
+		case s3SSETypeS3:
+			storage.sse = encrypt.NewSSE()
+		case s3SSETypeKMS:
+			storage.sse, err = encrypt.NewSSEKMS(params.SSE.KMSKeyID, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return storage, nil
+}
+
+func (s *s3ObjectStorage) List(ctx context.Context, prefix string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Only the first page is required to check the permissions.
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, MaxKeys: 1}) {
+		return s3Error(object.Err)
+	}
+	return nil
+}
+
+func (s *s3ObjectStorage) Put(ctx context.Context, key string, data []byte) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ServerSideEncryption: s.sse,
+	})
+	return s3Error(err)
+}
+
+func (s *s3ObjectStorage) Delete(ctx context.Context, key string) error {
+	return s3Error(s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}))
+}
+
+// s3Error maps the S3 error codes to the errors of the ObjectStorage interface.
+func s3Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	response := minio.ToErrorResponse(err)
+	switch {
+	case response.Code == "NoSuchBucket":
+		return fmt.Errorf("%w: %s", ErrBucketNotFound, response.Message)
+	case response.Code == "AccessDenied", response.Code == "InvalidAccessKeyId", response.Code == "SignatureDoesNotMatch",
+		response.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %s", ErrAccessDenied, response.Message)
+	case response.Code != "":
+		return fmt.Errorf("%s: %s", response.Code, response.Message)
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestS3ObjectStorageErrors(t *testing.T) {
+	tests := []struct {
+		code     string
+		status   int
+		expected error
+	}{
+		{code: "NoSuchBucket", status: http.StatusNotFound, expected: ErrBucketNotFound},
+		{code: "InvalidAccessKeyId", status: http.StatusForbidden, expected: ErrAccessDenied},
+		{code: "SignatureDoesNotMatch", status: http.StatusForbidden, expected: ErrAccessDenied},
+	}
+
+	for _, test := range tests {
+		t.Run(test.code, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// path-style request
+				assert.True(t, strings.HasPrefix(r.URL.Path, "/tempo"))
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(test.status)
+				fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>%s</Code><Message>error</Message></Error>`, test.code)
+			}))
+			defer server.Close()
+
+			storage, err := NewS3ObjectStorage(manifestutils.S3{
+				Endpoint:       strings.TrimPrefix(server.URL, "http://"),
+				Bucket:         "tempo",
+				Region:         "us-east-1",
+				Insecure:       true,
+				ForcePathStyle: true,
+			}, S3Credentials{AccessKeyID: "key", SecretAccessKey: "secret"})
+			require.NoError(t, err)
+
+			err = storage.Put(context.Background(), "probe", []byte("probe"))
+			assert.ErrorIs(t, err, test.expected)
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+type objectStorageStub struct {
+	objects   map[string][]byte
+	listErr   error
+	putErr    error
+	deleteErr error
+	calls     int
+}
+
+func (s *objectStorageStub) List(ctx context.Context, prefix string) error {
+	s.calls++
+	return s.listErr
+}
+
+func (s *objectStorageStub) Put(ctx context.Context, key string, data []byte) error {
+	if s.putErr != nil {
+		return s.putErr
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func (s *objectStorageStub) Delete(ctx context.Context, key string) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	delete(s.objects, key)
+	return nil
+}
+
+func preflightTempoStack() v1alpha1.TempoStack {
+	return v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "storage",
+					Type: v1alpha1.ObjectStorageSecretS3,
+				},
+			},
+		},
+	}
+}
+
+func preflightStorageSecret(accessKeyID string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "storage",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"endpoint":          []byte("http://minio:9000"),
+			"bucket":            []byte("tempo"),
+			"access_key_id":     []byte(accessKeyID),
+			"access_key_secret": []byte("secret"),
+		},
+	}
+}
+
+func TestPreflightCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		stub     objectStorageStub
+		status   metav1.ConditionStatus
+		reason   v1alpha1.ConditionReason
+		contains string
+	}{
+		{
+			name:     "ready",
+			status:   metav1.ConditionTrue,
+			reason:   v1alpha1.ReasonStorageReady,
+			contains: "bucket tempo",
+		},
+		{
+			name:     "bucket not found",
+			stub:     objectStorageStub{listErr: ErrBucketNotFound},
+			status:   metav1.ConditionFalse,
+			reason:   v1alpha1.ReasonStorageBucketNotFound,
+			contains: "could not list objects in bucket tempo",
+		},
+		{
+			name:     "access denied",
+			stub:     objectStorageStub{putErr: ErrAccessDenied},
+			status:   metav1.ConditionFalse,
+			reason:   v1alpha1.ReasonStorageAccessDenied,
+			contains: "could not write object prefix/tempo-operator-preflight/probe",
+		},
+		{
+			name:   "unreachable",
+			stub:   objectStorageStub{listErr: &net.OpError{Op: "dial", Err: errors.New("connection refused")}},
+			status: metav1.ConditionFalse,
+			reason: v1alpha1.ReasonStorageUnreachable,
+		},
+		{
+			name:     "other errors",
+			stub:     objectStorageStub{deleteErr: errors.New("InternalError: we encountered an internal error")},
+			status:   metav1.ConditionFalse,
+			reason:   v1alpha1.ReasonStorageCheckFailed,
+			contains: "InternalError",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := test.stub
+			stub.objects = map[string][]byte{}
+			k8sClient := fake.NewClientBuilder().WithObjects(preflightStorageSecret("key")).Build()
+			preflight := NewPreflight(k8sClient, func(params manifestutils.S3, credentials S3Credentials) (ObjectStorage, error) {
+				assert.Equal(t, "key", credentials.AccessKeyID)
+				assert.Equal(t, "secret", credentials.SecretAccessKey)
+				return &stub, nil
+			})
+
+			params := manifestutils.StorageParams{
+				CredentialMode: v1alpha1.CredentialModeStatic,
+				S3:             &manifestutils.S3{Endpoint: "minio:9000", Bucket: "tempo", Prefix: "prefix"},
+			}
+			condition, verified := preflight.Check(context.Background(), preflightTempoStack(), params)
+
+			assert.Equal(t, string(v1alpha1.ConditionStorageReady), condition.Type)
+			assert.Equal(t, test.status, condition.Status)
+			assert.Equal(t, string(test.reason), condition.Reason)
+			assert.Contains(t, condition.Message, test.contains)
+			assert.Equal(t, test.status == metav1.ConditionTrue, verified)
+			if test.stub.deleteErr == nil {
+				assert.Empty(t, stub.objects)
+			}
+
+			result, ok := preflight.Result(types.NamespacedName{Namespace: "default", Name: "test"})
+			require.True(t, ok)
+			assert.Equal(t, condition, result)
+		})
+	}
+}
+
+func TestPreflightCheckCached(t *testing.T) {
+	stub := objectStorageStub{objects: map[string][]byte{}}
+	k8sClient := fake.NewClientBuilder().WithObjects(preflightStorageSecret("key")).Build()
+	preflight := NewPreflight(k8sClient, func(params manifestutils.S3, credentials S3Credentials) (ObjectStorage, error) {
+		return &stub, nil
+	})
+	tempo := preflightTempoStack()
+	params := manifestutils.StorageParams{
+		CredentialMode: v1alpha1.CredentialModeStatic,
+		S3:             &manifestutils.S3{Endpoint: "minio:9000", Bucket: "tempo"},
+	}
+
+	preflight.Check(context.Background(), tempo, params)
+	preflight.Check(context.Background(), tempo, params)
+	assert.Equal(t, 1, stub.calls)
+
+	// the storage is checked again if the storage secret changes
+	require.NoError(t, k8sClient.Update(context.Background(), preflightStorageSecret("new-key")))
+	preflight.Check(context.Background(), tempo, params)
+	assert.Equal(t, 2, stub.calls)
+
+	// failed checks are repeated after a backoff
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	preflight.now = func() time.Time { return now }
+	stub.listErr = ErrAccessDenied
+	params.S3.Bucket = "other"
+	preflight.Check(context.Background(), tempo, params)
+	preflight.Check(context.Background(), tempo, params)
+	assert.Equal(t, 3, stub.calls)
+
+	now = now.Add(preflightMinBackoff)
+	preflight.Check(context.Background(), tempo, params)
+	assert.Equal(t, 4, stub.calls)
+
+	now = now.Add(preflightMinBackoff)
+	preflight.Check(context.Background(), tempo, params)
+	assert.Equal(t, 4, stub.calls)
+	now = now.Add(preflightMinBackoff)
+	preflight.Check(context.Background(), tempo, params)
+	assert.Equal(t, 5, stub.calls)
+
+	preflight.Forget(types.NamespacedName{Namespace: "default", Name: "test"})
+	_, ok := preflight.Result(types.NamespacedName{Namespace: "default", Name: "test"})
+	assert.False(t, ok)
+}
+
+func TestPreflightCheckSkipped(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().Build()
+	preflight := NewPreflight(k8sClient, func(params manifestutils.S3, credentials S3Credentials) (ObjectStorage, error) {
+		t.Fatal("the object storage must not be accessed")
+		return nil, nil
+	})
+
+	tempo := preflightTempoStack()
+	condition, _ := preflight.Check(context.Background(), tempo, manifestutils.StorageParams{
+		CredentialMode: v1alpha1.CredentialModeToken,
+		S3:             &manifestutils.S3{Bucket: "tempo", Region: "us-east-1"},
+	})
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, string(v1alpha1.ReasonStorageCheckSkipped), condition.Reason)
+	assert.Equal(t, "the preflight check is not supported for the token credential mode", condition.Message)
+
+	tempo.Spec.Storage.Secret.Type = v1alpha1.ObjectStorageSecretAzure
+	condition, _ = preflight.Check(context.Background(), tempo, manifestutils.StorageParams{
+		CredentialMode: v1alpha1.CredentialModeStatic,
+	})
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, "the preflight check is not supported for the azure storage secret type", condition.Message)
+}
+
+func TestPreflightCheckVerified(t *testing.T) {
+	stub := objectStorageStub{objects: map[string][]byte{}}
+	k8sClient := fake.NewClientBuilder().WithObjects(preflightStorageSecret("key")).Build()
+	preflight := NewPreflight(k8sClient, func(params manifestutils.S3, credentials S3Credentials) (ObjectStorage, error) {
+		return &stub, nil
+	})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	preflight.now = func() time.Time { return now }
+	tempo := preflightTempoStack()
+	params := manifestutils.StorageParams{
+		CredentialMode: v1alpha1.CredentialModeStatic,
+		S3:             &manifestutils.S3{Endpoint: "minio:9000", Bucket: "tempo"},
+	}
+
+	condition, verified := preflight.Check(context.Background(), tempo, params)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.True(t, verified)
+
+	// a transient failure of a verified storage configuration doesn't block the rollout
+	now = now.Add(time.Hour)
+	stub.listErr = &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	params.S3.Prefix = "prefix"
+	condition, verified = preflight.Check(context.Background(), tempo, params)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.False(t, verified, "the changed storage configuration was not verified")
+
+	params.S3.Prefix = ""
+	condition, verified = preflight.Check(context.Background(), tempo, params)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.True(t, verified, "the storage configuration was verified before")
+
+	// the storage configuration of a running TempoStack is verified after a restart of the operator
+	restarted := NewPreflight(k8sClient, func(params manifestutils.S3, credentials S3Credentials) (ObjectStorage, error) {
+		return &stub, nil
+	})
+	condition, verified = restarted.Check(context.Background(), tempo, params)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.False(t, verified)
+
+	restarted.Forget(types.NamespacedName{Namespace: "default", Name: "test"})
+	tempo.Status.Conditions = []metav1.Condition{{Type: string(v1alpha1.ConditionStorageReady), Status: metav1.ConditionTrue}}
+	condition, verified = restarted.Check(context.Background(), tempo, params)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.True(t, verified)
+}
+
+func TestPreflightBackoff(t *testing.T) {
+	assert.Equal(t, preflightMinBackoff, preflightBackoff(1))
+	assert.Equal(t, 2*preflightMinBackoff, preflightBackoff(2))
+	assert.Equal(t, 4*preflightMinBackoff, preflightBackoff(3))
+	assert.Equal(t, preflightMaxBackoff, preflightBackoff(100))
+}
@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -29,6 +31,11 @@ var s3LongLivedFields = []string{
 	"access_key_secret",
 }
 
+const (
+	s3SSETypeS3  = "SSE-S3"
+	s3SSETypeKMS = "SSE-KMS"
+)
+
 func discoverS3CredentialType(storageSecret corev1.Secret, path *field.Path) (v1alpha1.CredentialMode, field.ErrorList) {
 
 	var isShortLived bool
@@ -66,28 +73,103 @@ func validateS3Secret(storageSecret corev1.Secret, path *field.Path, credentialM
 	case v1alpha1.CredentialModeStatic:
 		var allErrs field.ErrorList
 		allErrs = append(allErrs, ensureNotEmpty(storageSecret, s3LongLivedFields, path)...)
-		if endpoint, ok := storageSecret.Data["endpoint"]; ok {
-			u, err := url.ParseRequestURI(string(endpoint))
-
-			// ParseRequestURI also accepts absolute paths, therefore we need to check if the URL scheme is set
-			if err != nil || u.Scheme == "" {
-				allErrs = append(allErrs, field.Invalid(
-					path,
-					storageSecret.Name,
-					"\"endpoint\" field of storage secret must be a valid URL",
-				))
-			}
-		}
+		allErrs = append(allErrs, validateURLField(storageSecret, "endpoint", path)...)
+		allErrs = append(allErrs, validateS3Options(storageSecret, path)...)
 		return allErrs
 	case v1alpha1.CredentialModeToken:
-		return ensureNotEmpty(storageSecret, s3ShortLivedFields, path)
+		var allErrs field.ErrorList
+		allErrs = append(allErrs, ensureNotEmpty(storageSecret, s3ShortLivedFields, path)...)
+		allErrs = append(allErrs, validateURLField(storageSecret, "s3_endpoint", path)...)
+		allErrs = append(allErrs, validateURLField(storageSecret, "sts_endpoint", path)...)
+		allErrs = append(allErrs, validateS3Options(storageSecret, path)...)
+		return allErrs
 	case v1alpha1.CredentialModeTokenCCO:
-		return ensureNotEmpty(storageSecret, s3CCOShortLivedFields, path)
+		var allErrs field.ErrorList
+		allErrs = append(allErrs, ensureNotEmpty(storageSecret, s3CCOShortLivedFields, path)...)
+		allErrs = append(allErrs, validateURLField(storageSecret, "s3_endpoint", path)...)
+		allErrs = append(allErrs, validateS3Options(storageSecret, path)...)
+		return allErrs
 	}
 
 	return field.ErrorList{}
 }
 
+func validateURLField(storageSecret corev1.Secret, key string, path *field.Path) field.ErrorList {
+	value, ok := storageSecret.Data[key]
+	if !ok {
+		return nil
+	}
+
+	u, err := url.ParseRequestURI(string(value))
+
+	// ParseRequestURI also accepts absolute paths, therefore we need to check if the URL scheme is set
+	if err != nil || u.Scheme == "" {
+		return field.ErrorList{field.Invalid(
+			path,
+			storageSecret.Name,
+			fmt.Sprintf("\"%s\" field of storage secret must be a valid URL", key),
+		)}
+	}
+	return nil
+}
+
+// validateS3Options validates the optional fields of the S3 storage secret,
+// which are supported by all credential modes.
+func validateS3Options(storageSecret corev1.Secret, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	sseType := string(storageSecret.Data["sse_type"])
+	_, hasKMSKeyID := storageSecret.Data["sse_kms_key_id"]
+	switch sseType {
+	case "", s3SSETypeS3:
+		if hasKMSKeyID {
+			allErrs = append(allErrs, field.Invalid(path, storageSecret.Name,
+				fmt.Sprintf("\"sse_kms_key_id\" field of storage secret requires \"sse_type\" %s", s3SSETypeKMS)))
+		}
+	case s3SSETypeKMS:
+		allErrs = append(allErrs, ensureNotEmpty(storageSecret, []string{"sse_kms_key_id"}, path)...)
+	default:
+		allErrs = append(allErrs, field.Invalid(path, storageSecret.Name,
+			fmt.Sprintf("\"sse_type\" field of storage secret must be %s or %s", s3SSETypeS3, s3SSETypeKMS)))
+	}
+
+	if forcePathStyle, ok := storageSecret.Data["forcepathstyle"]; ok {
+		if _, err := strconv.ParseBool(string(forcePathStyle)); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, storageSecret.Name,
+				"\"forcepathstyle\" field of storage secret must be a boolean"))
+		}
+	}
+
+	if prefix := string(storageSecret.Data["prefix"]); strings.HasPrefix(prefix, "/") || strings.HasSuffix(prefix, "/") {
+		allErrs = append(allErrs, field.Invalid(path, storageSecret.Name,
+			"\"prefix\" field of storage secret must not start or end with a slash"))
+	}
+
+	return allErrs
+}
+
+// getS3Options returns the optional S3 settings of the storage secret,
+// which are supported by all credential modes. The storage secret must be validated before.
+func getS3Options(storageSecret corev1.Secret, params *manifestutils.S3) {
+	params.Prefix = string(storageSecret.Data["prefix"])
+	params.ForcePathStyle, _ = strconv.ParseBool(string(storageSecret.Data["forcepathstyle"]))
+	if sseType := string(storageSecret.Data["sse_type"]); sseType != "" {
+		params.SSE = &manifestutils.S3SSE{
+			Type:     sseType,
+			KMSKeyID: string(storageSecret.Data["sse_kms_key_id"]),
+		}
+	}
+}
+
+// trimScheme removes the scheme of an endpoint URL,
+// and returns true if the endpoint doesn't use TLS.
+func trimScheme(endpoint string) (string, bool) {
+	insecure := !strings.HasPrefix(endpoint, "https://")
+	endpoint = strings.TrimPrefix(endpoint, "https://")
+	endpoint = strings.TrimPrefix(endpoint, "http://")
+	return endpoint, insecure
+}
+
 func getS3Params(storageSecret corev1.Secret, path *field.Path, mode v1alpha1.CredentialMode) (*manifestutils.S3, field.ErrorList) {
 
 	errs := validateS3Secret(storageSecret, path, mode)
@@ -95,28 +177,31 @@ func getS3Params(storageSecret corev1.Secret, path *field.Path, mode v1alpha1.Cr
 		return nil, errs
 	}
 
-	if mode == v1alpha1.CredentialModeStatic {
-		endpoint := string(storageSecret.Data["endpoint"])
-		insecure := !strings.HasPrefix(endpoint, "https://")
-		endpoint = strings.TrimPrefix(endpoint, "https://")
-		endpoint = strings.TrimPrefix(endpoint, "http://")
-		return &manifestutils.S3{
+	var params *manifestutils.S3
+	switch mode {
+	case v1alpha1.CredentialModeStatic:
+		endpoint, insecure := trimScheme(string(storageSecret.Data["endpoint"]))
+		params = &manifestutils.S3{
 			Insecure: insecure,
 			Endpoint: endpoint,
 			Bucket:   string(storageSecret.Data["bucket"]),
-		}, nil
-	}
-
-	if mode == v1alpha1.CredentialModeToken {
-		return &manifestutils.S3{
-			Bucket:  string(storageSecret.Data["bucket"]),
-			RoleARN: string(storageSecret.Data["role_arn"]),
-			Region:  string(storageSecret.Data["region"]),
-		}, nil
+		}
+	case v1alpha1.CredentialModeToken:
+		params = &manifestutils.S3{
+			Bucket:      string(storageSecret.Data["bucket"]),
+			RoleARN:     string(storageSecret.Data["role_arn"]),
+			Region:      string(storageSecret.Data["region"]),
+			STSEndpoint: string(storageSecret.Data["sts_endpoint"]),
+		}
+		params.Endpoint, _ = trimScheme(string(storageSecret.Data["s3_endpoint"]))
+	default:
+		params = &manifestutils.S3{
+			Bucket: string(storageSecret.Data["bucket"]),
+			Region: string(storageSecret.Data["region"]),
+		}
+		params.Endpoint, _ = trimScheme(string(storageSecret.Data["s3_endpoint"]))
 	}
 
-	return &manifestutils.S3{
-		Bucket: string(storageSecret.Data["bucket"]),
-		Region: string(storageSecret.Data["region"]),
-	}, nil
+	getS3Options(storageSecret, params)
+	return params, nil
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
@@ -65,6 +67,133 @@ func TestGetS3Params_short_lived(t *testing.T) {
 	}, s3)
 }
 
+func TestGetS3Params_options(t *testing.T) {
+	storageSecret := corev1.Secret{
+		Data: map[string][]byte{
+			"endpoint":          []byte("https://minio:9000"),
+			"bucket":            []byte("testbucket"),
+			"access_key_id":     []byte("abc"),
+			"access_key_secret": []byte("def"),
+			"forcepathstyle":    []byte("true"),
+			"prefix":            []byte("stacks/dev"),
+			"sse_type":          []byte("SSE-KMS"),
+			"sse_kms_key_id":    []byte("key"),
+		},
+	}
+
+	s3, errs := getS3Params(storageSecret, nil, v1alpha1.CredentialModeStatic)
+
+	require.Len(t, errs, 0)
+	require.Equal(t, &manifestutils.S3{
+		Endpoint:       "minio:9000",
+		Bucket:         "testbucket",
+		ForcePathStyle: true,
+		Prefix:         "stacks/dev",
+		SSE: &manifestutils.S3SSE{
+			Type:     "SSE-KMS",
+			KMSKeyID: "key",
+		},
+	}, s3)
+}
+
+func TestGetS3Params_short_lived_endpoints(t *testing.T) {
+	storageSecret := corev1.Secret{
+		Data: map[string][]byte{
+			"bucket":       []byte("testbucket"),
+			"role_arn":     []byte("abc"),
+			"region":       []byte("us-gov-west-1"),
+			"s3_endpoint":  []byte("https://s3-fips.us-gov-west-1.amazonaws.com"),
+			"sts_endpoint": []byte("https://sts-fips.us-gov-west-1.amazonaws.com"),
+		},
+	}
+
+	s3, errs := getS3Params(storageSecret, nil, v1alpha1.CredentialModeToken)
+
+	require.Len(t, errs, 0)
+	require.Equal(t, &manifestutils.S3{
+		Bucket:      "testbucket",
+		RoleARN:     "abc",
+		Region:      "us-gov-west-1",
+		Endpoint:    "s3-fips.us-gov-west-1.amazonaws.com",
+		STSEndpoint: "https://sts-fips.us-gov-west-1.amazonaws.com",
+	}, s3)
+}
+
+func TestGetS3Params_invalid_sts_endpoint(t *testing.T) {
+	storageSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+		Data: map[string][]byte{
+			"bucket":       []byte("testbucket"),
+			"role_arn":     []byte("abc"),
+			"region":       []byte("us-gov-west-1"),
+			"sts_endpoint": []byte("sts-fips.us-gov-west-1.amazonaws.com"),
+		},
+	}
+
+	_, errs := getS3Params(storageSecret, field.NewPath("spec", "storage", "secret"), v1alpha1.CredentialModeToken)
+	require.Equal(t, field.ErrorList{
+		field.Invalid(field.NewPath("spec", "storage", "secret"), "storage", "\"sts_endpoint\" field of storage secret must be a valid URL"),
+	}, errs)
+}
+
+func TestValidateS3Options(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected string
+	}{
+		{
+			name: "no options",
+		},
+		{
+			name: "SSE-S3",
+			data: map[string]string{"sse_type": "SSE-S3"},
+		},
+		{
+			name:     "invalid SSE type",
+			data:     map[string]string{"sse_type": "SSE-C"},
+			expected: "\"sse_type\" field of storage secret must be SSE-S3 or SSE-KMS",
+		},
+		{
+			name:     "SSE-KMS without key",
+			data:     map[string]string{"sse_type": "SSE-KMS"},
+			expected: "storage secret must contain \"sse_kms_key_id\" field",
+		},
+		{
+			name:     "KMS key without SSE-KMS",
+			data:     map[string]string{"sse_type": "SSE-S3", "sse_kms_key_id": "key"},
+			expected: "\"sse_kms_key_id\" field of storage secret requires \"sse_type\" SSE-KMS",
+		},
+		{
+			name:     "invalid forcepathstyle",
+			data:     map[string]string{"forcepathstyle": "yes please"},
+			expected: "\"forcepathstyle\" field of storage secret must be a boolean",
+		},
+		{
+			name:     "prefix with leading slash",
+			data:     map[string]string{"prefix": "/dev"},
+			expected: "\"prefix\" field of storage secret must not start or end with a slash",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storageSecret := corev1.Secret{Data: map[string][]byte{}}
+			for k, v := range test.data {
+				storageSecret.Data[k] = []byte(v)
+			}
+
+			errs := validateS3Options(storageSecret, nil)
+			if test.expected == "" {
+				require.Empty(t, errs)
+			} else {
+				require.Len(t, errs, 1)
+				require.Equal(t, test.expected, errs[0].Detail)
+			}
+		})
+	}
+}
+
 func TestGetGCSParams_short_lived(t *testing.T) {
 	storageSecret := corev1.Secret{
 		Data: map[string][]byte{
@@ -20,6 +20,31 @@ func GetStorageParamsForTempoStack(ctx context.Context, client client.Client, te
 	tlsPath := storagePath.Child("tls")
 	modePath := storagePath.Child("credentialMode")
 
+	if tempo.Spec.Storage.Filesystem != nil {
+		if tempo.Spec.Storage.Secret.Name != "" || tempo.Spec.Storage.Secret.Type != "" {
+			return manifestutils.StorageParams{}, field.ErrorList{field.Invalid(
+				secretPath,
+				tempo.Spec.Storage.Secret.Name,
+				"storage secret and filesystem storage are mutually exclusive",
+			)}
+		}
+		if tempo.Spec.Storage.TLS.Enabled {
+			return manifestutils.StorageParams{}, field.ErrorList{field.Invalid(
+				tlsPath.Child("enabled"),
+				tempo.Spec.Storage.TLS.Enabled,
+				"custom TLS settings are not supported for filesystem storage",
+			)}
+		}
+		return manifestutils.StorageParams{}, validateFilesystem(ctx, client, tempo.Namespace, *tempo.Spec.Storage.Filesystem, storagePath.Child("filesystem"))
+	}
+
+	if tempo.Spec.Storage.Secret.Name == "" {
+		return manifestutils.StorageParams{}, field.ErrorList{field.Required(
+			secretNamePath,
+			"storage secret is required if filesystem storage is not configured",
+		)}
+	}
+
 	storageSecret, errs := getSecret(ctx, client, tempo.Namespace, tempo.Spec.Storage.Secret.Name, secretNamePath)
 	if len(errs) > 0 {
 		return manifestutils.StorageParams{}, errs
@@ -130,6 +155,30 @@ func GetStorageParamsForTempoStack(ctx context.Context, client client.Client, te
 			)}
 		}
 
+	case v1alpha1.ObjectStorageSecretSwift:
+		credentialMode := tempo.Spec.Storage.Secret.CredentialMode
+		if credentialMode == "" {
+			credentialMode, errs = discoverSwiftCredentialType(storageSecret, secretNamePath)
+			if len(errs) > 0 {
+				return manifestutils.StorageParams{}, errs
+			}
+		}
+		storageParams.CredentialMode = credentialMode
+
+		storageParams.S3, errs = getSwiftParams(storageSecret, secretNamePath, credentialMode)
+		if len(errs) > 0 {
+			return manifestutils.StorageParams{}, errs
+		}
+
+		storageParams.S3.Insecure = !tempo.Spec.Storage.TLS.Enabled
+
+		if tempo.Spec.Storage.TLS.Enabled {
+			storageParams.S3.TLS, errs = getTLSParams(ctx, client, tempo.Namespace, tempo.Spec.Storage.TLS, tlsPath.Child("caName"))
+			if len(errs) > 0 {
+				return manifestutils.StorageParams{}, errs
+			}
+		}
+
 	case "":
 		return manifestutils.StorageParams{}, field.ErrorList{field.Invalid(
 			secretPath.Child("type"),
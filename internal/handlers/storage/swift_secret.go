@@ -0,0 +1,73 @@
+package storage
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// Tempo accesses Swift through the S3-compatible API of Swift (s3api middleware),
+// which requires EC2 credentials.
+var swiftEC2Fields = []string{
+	"container",
+	"endpoint",
+	"access_key_id",
+	"access_key_secret",
+}
+
+// Keystone credentials are used by the native Swift API, which is not supported by Tempo.
+var swiftKeystoneFields = []string{
+	"auth_url",
+	"username",
+	"password",
+	"application_credential_id",
+}
+
+func discoverSwiftCredentialType(storageSecret corev1.Secret, path *field.Path) (v1alpha1.CredentialMode, field.ErrorList) {
+	for _, v := range swiftKeystoneFields {
+		if _, ok := storageSecret.Data[v]; ok {
+			return "", field.ErrorList{field.Invalid(
+				path,
+				storageSecret.Name,
+				"storage secret contains Keystone credentials, Tempo requires EC2 credentials for the S3 API of Swift (openstack ec2 credentials create)",
+			)}
+		}
+	}
+
+	return v1alpha1.CredentialModeStatic, nil
+}
+
+func validateSwiftSecret(storageSecret corev1.Secret, path *field.Path, credentialMode v1alpha1.CredentialMode) field.ErrorList {
+	if credentialMode != v1alpha1.CredentialModeStatic {
+		return field.ErrorList{field.Invalid(
+			path,
+			storageSecret.Name,
+			"only the static credential mode is supported for Swift",
+		)}
+	}
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, ensureNotEmpty(storageSecret, swiftEC2Fields, path)...)
+	allErrs = append(allErrs, validateURLField(storageSecret, "endpoint", path)...)
+	return allErrs
+}
+
+// getSwiftParams returns the S3 parameters of the Swift S3 API.
+// Swift serves the containers as buckets, and doesn't support virtual-hosted-style requests.
+func getSwiftParams(storageSecret corev1.Secret, path *field.Path, mode v1alpha1.CredentialMode) (*manifestutils.S3, field.ErrorList) {
+	errs := validateSwiftSecret(storageSecret, path, mode)
+	if len(errs) != 0 {
+		return nil, errs
+	}
+
+	endpoint, insecure := trimScheme(string(storageSecret.Data["endpoint"]))
+	return &manifestutils.S3{
+		Insecure:       insecure,
+		Endpoint:       endpoint,
+		Bucket:         string(storageSecret.Data["container"]),
+		Region:         string(storageSecret.Data["region"]),
+		ForcePathStyle: true,
+	}, nil
+}
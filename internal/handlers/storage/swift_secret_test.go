@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestGetSwiftParams(t *testing.T) {
+	storageSecret := corev1.Secret{
+		Data: map[string][]byte{
+			"container":         []byte("tempo"),
+			"endpoint":          []byte("https://swift.example.com:8080"),
+			"region":            []byte("RegionOne"),
+			"access_key_id":     []byte("id"),
+			"access_key_secret": []byte("secret"),
+		},
+	}
+
+	opts, errs := getSwiftParams(storageSecret, nil, v1alpha1.CredentialModeStatic)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, &manifestutils.S3{
+		Endpoint:       "swift.example.com:8080",
+		Bucket:         "tempo",
+		Region:         "RegionOne",
+		ForcePathStyle: true,
+	}, opts)
+}
+
+func TestGetSwiftParams_token(t *testing.T) {
+	storageSecret := corev1.Secret{
+		Data: map[string][]byte{
+			"container": []byte("tempo"),
+		},
+	}
+
+	_, errs := getSwiftParams(storageSecret, field.NewPath("secret"), v1alpha1.CredentialModeToken)
+	assert.Equal(t, field.ErrorList{
+		field.Invalid(field.NewPath("secret"), "", "only the static credential mode is supported for Swift"),
+	}, errs)
+}
+
+func TestDiscoverSwiftCredentialType(t *testing.T) {
+	mode, errs := discoverSwiftCredentialType(corev1.Secret{
+		Data: map[string][]byte{
+			"container":         []byte("tempo"),
+			"endpoint":          []byte("https://swift.example.com"),
+			"access_key_id":     []byte("id"),
+			"access_key_secret": []byte("secret"),
+		},
+	}, nil)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, v1alpha1.CredentialModeStatic, mode)
+
+	_, errs = discoverSwiftCredentialType(corev1.Secret{
+		Data: map[string][]byte{
+			"container":                 []byte("tempo"),
+			"application_credential_id": []byte("id"),
+		},
+	}, nil)
+	assert.Len(t, errs, 1)
+}
@@ -0,0 +1,156 @@
+package tenants
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/version"
+)
+
+// GetTempoTenants returns all TempoTenants which reference the given TempoStack.
+func GetTempoTenants(ctx context.Context, k8sClient client.Client, tempo v1alpha1.TempoStack) ([]v1alpha1.TempoTenant, error) {
+	tenantList := &v1alpha1.TempoTenantList{}
+	err := k8sClient.List(ctx, tenantList, client.InNamespace(tempo.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("error listing tempo tenants: %w", err)
+	}
+
+	var tenants []v1alpha1.TempoTenant
+	for _, tenant := range tenantList.Items {
+		if tenant.Spec.TempoStack == tempo.Name {
+			tenants = append(tenants, tenant)
+		}
+	}
+	return tenants, nil
+}
+
+// Merge applies the configuration of the TempoTenants to a copy of the TempoStack.
+//
+// Tenants configured inline in the TempoStack take precedence over TempoTenants.
+// If multiple TempoTenants declare the same tenant, the oldest TempoTenant is applied.
+// The returned map contains the Accepted condition of every TempoTenant, keyed by the TempoTenant name.
+func Merge(tempo v1alpha1.TempoStack, tenants []v1alpha1.TempoTenant) (v1alpha1.TempoStack, map[string]metav1.Condition) {
+	merged := tempo.DeepCopy()
+	conditions := map[string]metav1.Condition{}
+
+	sorted := make([]v1alpha1.TempoTenant, len(tenants))
+	copy(sorted, tenants)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	// tenantNames and tenantIDs map the tenants configured so far to the resource declaring them.
+	tenantNames := map[string]string{}
+	tenantIDs := map[string]string{}
+	for name := range tempo.Spec.LimitSpec.PerTenant {
+		tenantNames[name] = "TempoStack " + tempo.Name
+	}
+	for name := range tempo.Spec.Retention.PerTenant {
+		tenantNames[name] = "TempoStack " + tempo.Name
+	}
+	if tempo.Spec.Tenants != nil {
+		for _, auth := range tempo.Spec.Tenants.Authentication {
+			tenantNames[auth.TenantName] = "TempoStack " + tempo.Name
+			tenantIDs[auth.TenantID] = "TempoStack " + tempo.Name
+		}
+	}
+
+	for _, tenant := range sorted {
+		reason, message := validate(tempo, tenant, tenantNames, tenantIDs)
+		if reason != v1alpha1.ReasonTenantAccepted {
+			conditions[tenant.Name] = condition(metav1.ConditionFalse, reason, message)
+			continue
+		}
+
+		apply(merged, tenant)
+		tenantNames[tenant.Spec.TenantName] = "TempoTenant " + tenant.Name
+		if tenant.Spec.Authentication != nil {
+			tenantIDs[tenant.Spec.Authentication.TenantID] = "TempoTenant " + tenant.Name
+		}
+		conditions[tenant.Name] = condition(metav1.ConditionTrue, v1alpha1.ReasonTenantAccepted,
+			fmt.Sprintf("Tenant %s is configured in TempoStack %s", tenant.Spec.TenantName, tempo.Name))
+	}
+
+	return *merged, conditions
+}
+
+// NotFoundCondition returns the Accepted condition of a TempoTenant which references a missing TempoStack.
+func NotFoundCondition(tenant v1alpha1.TempoTenant) metav1.Condition {
+	return condition(metav1.ConditionFalse, v1alpha1.ReasonTempoStackNotFound,
+		fmt.Sprintf("TempoStack %s not found", tenant.Spec.TempoStack))
+}
+
+func condition(status metav1.ConditionStatus, reason v1alpha1.ConditionReason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    string(v1alpha1.TempoTenantConditionAccepted),
+		Status:  status,
+		Reason:  string(reason),
+		Message: message,
+	}
+}
+
+func validate(tempo v1alpha1.TempoStack, tenant v1alpha1.TempoTenant, tenantNames, tenantIDs map[string]string) (v1alpha1.ConditionReason, string) {
+	if owner, ok := tenantNames[tenant.Spec.TenantName]; ok {
+		return v1alpha1.ReasonTenantConflict, fmt.Sprintf("Tenant %s is already configured in %s", tenant.Spec.TenantName, owner)
+	}
+
+	// An invalid Tempo version is reported by the TempoStack.
+	features, err := version.TempoFeaturesFor(tempo.Spec.TempoVersion)
+	if err == nil && !features.CostAttribution && tenant.Spec.Limits != nil && len(tenant.Spec.Limits.Ingestion.CostAttributionDimensions) > 0 {
+		return v1alpha1.ReasonTenantUnsupportedLimits, fmt.Sprintf("cost attribution is not supported by Tempo version %s", tempo.Spec.TempoVersion)
+	}
+
+	auth := tenant.Spec.Authentication
+	if auth == nil {
+		return v1alpha1.ReasonTenantAccepted, ""
+	}
+
+	if owner, ok := tenantIDs[auth.TenantID]; ok {
+		return v1alpha1.ReasonTenantConflict, fmt.Sprintf("Tenant ID %s is already configured in %s", auth.TenantID, owner)
+	}
+
+	switch {
+	case tempo.Spec.Tenants == nil:
+		return v1alpha1.ReasonTenantInvalidAuthentication, fmt.Sprintf("TempoStack %s does not have multi-tenancy enabled", tempo.Name)
+	case tempo.Spec.Tenants.Mode == v1alpha1.ModeStatic && auth.OIDC == nil:
+		return v1alpha1.ReasonTenantInvalidAuthentication, "spec.authentication.oidc is required in static mode"
+	case tempo.Spec.Tenants.Mode == v1alpha1.ModeOpenShift && auth.OIDC != nil:
+		return v1alpha1.ReasonTenantInvalidAuthentication, "spec.authentication.oidc should not be defined in openshift mode"
+	}
+
+	return v1alpha1.ReasonTenantAccepted, ""
+}
+
+func apply(tempo *v1alpha1.TempoStack, tenant v1alpha1.TempoTenant) {
+	name := tenant.Spec.TenantName
+
+	if tenant.Spec.Limits != nil {
+		if tempo.Spec.LimitSpec.PerTenant == nil {
+			tempo.Spec.LimitSpec.PerTenant = map[string]v1alpha1.RateLimitSpec{}
+		}
+		tempo.Spec.LimitSpec.PerTenant[name] = *tenant.Spec.Limits.DeepCopy()
+	}
+
+	if tenant.Spec.Retention != nil {
+		if tempo.Spec.Retention.PerTenant == nil {
+			tempo.Spec.Retention.PerTenant = map[string]v1alpha1.RetentionConfig{}
+		}
+		tempo.Spec.Retention.PerTenant[name] = *tenant.Spec.Retention
+	}
+
+	if tenant.Spec.Authentication != nil {
+		tempo.Spec.Tenants.Authentication = append(tempo.Spec.Tenants.Authentication, v1alpha1.AuthenticationSpec{
+			TenantName: name,
+			TenantID:   tenant.Spec.Authentication.TenantID,
+			OIDC:       tenant.Spec.Authentication.OIDC.DeepCopy(),
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package tenants
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+)
+
+func tempoTenant(name string, created time.Time, spec v1alpha1.TempoTenantSpec) v1alpha1.TempoTenant {
+	if spec.TempoStack == "" {
+		spec.TempoStack = "simplest"
+	}
+	return v1alpha1.TempoTenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "observability",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: spec,
+	}
+}
+
+func TestGetTempoTenants(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	now := time.Now()
+	team1 := tempoTenant("team-1", now, v1alpha1.TempoTenantSpec{TenantName: "team-1"})
+	team2 := tempoTenant("team-2", now, v1alpha1.TempoTenantSpec{TempoStack: "other", TenantName: "team-2"})
+	team3 := tempoTenant("team-3", now, v1alpha1.TempoTenantSpec{TenantName: "team-3"})
+	team3.Namespace = "other"
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&team1, &team2, &team3).Build()
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+	}
+
+	tenants, err := GetTempoTenants(context.Background(), k8sClient, tempo)
+	require.NoError(t, err)
+	require.Len(t, tenants, 1)
+	assert.Equal(t, "team-1", tenants[0].Name)
+}
+
+func TestMergeLimitsAndRetention(t *testing.T) {
+	now := time.Now()
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"platform": {Ingestion: v1alpha1.IngestionLimitSpec{IngestionRateLimitBytes: ptr.To(10)}},
+				},
+			},
+		},
+	}
+	tenants := []v1alpha1.TempoTenant{
+		tempoTenant("team-1", now, v1alpha1.TempoTenantSpec{
+			TenantName: "team-1",
+			Limits: &v1alpha1.RateLimitSpec{
+				Ingestion: v1alpha1.IngestionLimitSpec{IngestionRateLimitBytes: ptr.To(20)},
+			},
+			Retention: &v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: 24 * time.Hour}},
+		}),
+	}
+
+	merged, conditions := Merge(tempo, tenants)
+
+	assert.Equal(t, map[string]v1alpha1.RateLimitSpec{
+		"platform": {Ingestion: v1alpha1.IngestionLimitSpec{IngestionRateLimitBytes: ptr.To(10)}},
+		"team-1":   {Ingestion: v1alpha1.IngestionLimitSpec{IngestionRateLimitBytes: ptr.To(20)}},
+	}, merged.Spec.LimitSpec.PerTenant)
+	assert.Equal(t, map[string]v1alpha1.RetentionConfig{
+		"team-1": {Traces: metav1.Duration{Duration: 24 * time.Hour}},
+	}, merged.Spec.Retention.PerTenant)
+	assert.Equal(t, metav1.ConditionTrue, conditions["team-1"].Status)
+	assert.Equal(t, string(v1alpha1.ReasonTenantAccepted), conditions["team-1"].Reason)
+
+	// the original TempoStack must not be modified
+	assert.Len(t, tempo.Spec.LimitSpec.PerTenant, 1)
+	assert.Nil(t, tempo.Spec.Retention.PerTenant)
+}
+
+func TestMergeConflicts(t *testing.T) {
+	now := time.Now()
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Retention: v1alpha1.RetentionSpec{
+				PerTenant: map[string]v1alpha1.RetentionConfig{
+					"platform": {Traces: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+		},
+	}
+	tenants := []v1alpha1.TempoTenant{
+		tempoTenant("team-1-newer", now, v1alpha1.TempoTenantSpec{
+			TenantName: "team-1",
+			Retention:  &v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: 2 * time.Hour}},
+		}),
+		tempoTenant("team-1", now.Add(-time.Hour), v1alpha1.TempoTenantSpec{
+			TenantName: "team-1",
+			Retention:  &v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: 3 * time.Hour}},
+		}),
+		tempoTenant("platform", now, v1alpha1.TempoTenantSpec{
+			TenantName: "platform",
+			Retention:  &v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: 4 * time.Hour}},
+		}),
+	}
+
+	merged, conditions := Merge(tempo, tenants)
+
+	assert.Equal(t, map[string]v1alpha1.RetentionConfig{
+		"platform": {Traces: metav1.Duration{Duration: time.Hour}},
+		"team-1":   {Traces: metav1.Duration{Duration: 3 * time.Hour}},
+	}, merged.Spec.Retention.PerTenant)
+
+	assert.Equal(t, metav1.ConditionTrue, conditions["team-1"].Status)
+	assert.Equal(t, metav1.Condition{
+		Type:    string(v1alpha1.TempoTenantConditionAccepted),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1alpha1.ReasonTenantConflict),
+		Message: "Tenant team-1 is already configured in TempoTenant team-1",
+	}, conditions["team-1-newer"])
+	assert.Equal(t, metav1.Condition{
+		Type:    string(v1alpha1.TempoTenantConditionAccepted),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1alpha1.ReasonTenantConflict),
+		Message: "Tenant platform is already configured in TempoStack simplest",
+	}, conditions["platform"])
+}
+
+func TestMergeAuthentication(t *testing.T) {
+	now := time.Now()
+	oidc := &v1alpha1.OIDCSpec{
+		Secret:    &v1alpha1.TenantSecretSpec{Name: "team-1-oidc"},
+		IssuerURL: "https://dex.example.com",
+	}
+
+	tests := []struct {
+		name           string
+		tenants        *v1alpha1.TenantsSpec
+		auth           v1alpha1.TempoTenantAuthenticationSpec
+		expectedReason v1alpha1.ConditionReason
+		expectedAuth   []v1alpha1.AuthenticationSpec
+	}{
+		{
+			name: "static mode",
+			tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeStatic,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{TenantName: "platform", TenantID: "1610b0c3-c509-4592-a256-a1871353dbfa", OIDC: oidc},
+				},
+			},
+			auth:           v1alpha1.TempoTenantAuthenticationSpec{TenantID: "a3c4fc78-19c1-4bd1-a6b4-ef1a1c4e4c34", OIDC: oidc},
+			expectedReason: v1alpha1.ReasonTenantAccepted,
+			expectedAuth: []v1alpha1.AuthenticationSpec{
+				{TenantName: "platform", TenantID: "1610b0c3-c509-4592-a256-a1871353dbfa", OIDC: oidc},
+				{TenantName: "team-1", TenantID: "a3c4fc78-19c1-4bd1-a6b4-ef1a1c4e4c34", OIDC: oidc},
+			},
+		},
+		{
+			name:           "openshift mode",
+			tenants:        &v1alpha1.TenantsSpec{Mode: v1alpha1.ModeOpenShift},
+			auth:           v1alpha1.TempoTenantAuthenticationSpec{TenantID: "a3c4fc78-19c1-4bd1-a6b4-ef1a1c4e4c34"},
+			expectedReason: v1alpha1.ReasonTenantAccepted,
+			expectedAuth: []v1alpha1.AuthenticationSpec{
+				{TenantName: "team-1", TenantID: "a3c4fc78-19c1-4bd1-a6b4-ef1a1c4e4c34"},
+			},
+		},
+		{
+			name:           "multi-tenancy disabled",
+			auth:           v1alpha1.TempoTenantAuthenticationSpec{TenantID: "a3c4fc78-19c1-4bd1-a6b4-ef1a1c4e4c34"},
+			expectedReason: v1alpha1.ReasonTenantInvalidAuthentication,
+		},
+		{
+			name:           "static mode without oidc",
+			tenants:        &v1alpha1.TenantsSpec{Mode: v1alpha1.ModeStatic},
+			auth:           v1alpha1.TempoTenantAuthenticationSpec{TenantID: "a3c4fc78-19c1-4bd1-a6b4-ef1a1c4e4c34"},
+			expectedReason: v1alpha1.ReasonTenantInvalidAuthentication,
+		},
+		{
+			name:           "openshift mode with oidc",
+			tenants:        &v1alpha1.TenantsSpec{Mode: v1alpha1.ModeOpenShift},
+			auth:           v1alpha1.TempoTenantAuthenticationSpec{TenantID: "a3c4fc78-19c1-4bd1-a6b4-ef1a1c4e4c34", OIDC: oidc},
+			expectedReason: v1alpha1.ReasonTenantInvalidAuthentication,
+		},
+		{
+			name: "duplicate tenant id",
+			tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeOpenShift,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{TenantName: "platform", TenantID: "a3c4fc78-19c1-4bd1-a6b4-ef1a1c4e4c34"},
+				},
+			},
+			auth:           v1alpha1.TempoTenantAuthenticationSpec{TenantID: "a3c4fc78-19c1-4bd1-a6b4-ef1a1c4e4c34"},
+			expectedReason: v1alpha1.ReasonTenantConflict,
+			expectedAuth: []v1alpha1.AuthenticationSpec{
+				{TenantName: "platform", TenantID: "a3c4fc78-19c1-4bd1-a6b4-ef1a1c4e4c34"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := v1alpha1.TempoStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "simplest",
+					Namespace: "observability",
+				},
+				Spec: v1alpha1.TempoStackSpec{
+					Tenants: test.tenants,
+				},
+			}
+			auth := test.auth
+			tenants := []v1alpha1.TempoTenant{
+				tempoTenant("team-1", now, v1alpha1.TempoTenantSpec{
+					TenantName:     "team-1",
+					Authentication: &auth,
+				}),
+			}
+
+			merged, conditions := Merge(tempo, tenants)
+			assert.Equal(t, string(test.expectedReason), conditions["team-1"].Reason)
+			if merged.Spec.Tenants != nil {
+				assert.Equal(t, test.expectedAuth, merged.Spec.Tenants.Authentication)
+			}
+		})
+	}
+}
+
+func TestMergeUnsupportedLimits(t *testing.T) {
+	now := time.Now()
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			TempoVersion: "2.7.2",
+		},
+	}
+	tenants := []v1alpha1.TempoTenant{
+		tempoTenant("team-1", now, v1alpha1.TempoTenantSpec{
+			TenantName: "team-1",
+			Limits: &v1alpha1.RateLimitSpec{
+				Ingestion: v1alpha1.IngestionLimitSpec{CostAttributionDimensions: map[string]string{"service.name": "service"}},
+			},
+		}),
+	}
+
+	merged, conditions := Merge(tempo, tenants)
+
+	assert.Empty(t, merged.Spec.LimitSpec.PerTenant)
+	assert.Equal(t, metav1.Condition{
+		Type:    string(v1alpha1.TempoTenantConditionAccepted),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1alpha1.ReasonTenantUnsupportedLimits),
+		Message: "cost attribution is not supported by Tempo version 2.7.2",
+	}, conditions["team-1"])
+
+	tempo.Spec.TempoVersion = "2.8.1"
+	merged, conditions = Merge(tempo, tenants)
+	assert.Contains(t, merged.Spec.LimitSpec.PerTenant, "team-1")
+	assert.Equal(t, metav1.ConditionTrue, conditions["team-1"].Status)
+}
@@ -0,0 +1,81 @@
+package autoscaling
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// Enabled returns true if a HorizontalPodAutoscaler manages the replicas of a component.
+func Enabled(spec *v1alpha1.AutoscalingSpec) bool {
+	return spec != nil && spec.Enabled
+}
+
+// Replicas returns the replicas of a component, or nil if the replicas are managed by a HorizontalPodAutoscaler.
+func Replicas(replicas *int32, spec *v1alpha1.AutoscalingSpec) *int32 {
+	if Enabled(spec) {
+		return nil
+	}
+	return replicas
+}
+
+// BuildHorizontalPodAutoscaler creates a HorizontalPodAutoscaler for the Deployment of a component.
+func BuildHorizontalPodAutoscaler(tempo v1alpha1.TempoStack, componentName string, spec v1alpha1.AutoscalingSpec) *autoscalingv2.HorizontalPodAutoscaler {
+	labels := manifestutils.ComponentLabels(componentName, tempo.Name)
+	name := naming.Name(componentName, tempo.Name)
+
+	minReplicas := spec.MinReplicas
+	if minReplicas == nil {
+		minReplicas = ptr.To(int32(1))
+	}
+
+	var metrics []autoscalingv2.MetricSpec
+	if spec.TargetCPUUtilization != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, *spec.TargetCPUUtilization))
+	}
+	if spec.TargetMemoryUtilization != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *spec.TargetMemoryUtilization))
+	}
+	metrics = append(metrics, spec.Metrics...)
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+				Name:       name,
+			},
+			MinReplicas: minReplicas,
+			MaxReplicas: spec.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+func resourceMetric(name corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: ptr.To(utilization),
+			},
+		},
+	}
+}
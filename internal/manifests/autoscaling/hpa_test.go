@@ -0,0 +1,97 @@
+package autoscaling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestReplicas(t *testing.T) {
+	replicas := ptr.To(int32(3))
+	assert.Equal(t, replicas, Replicas(replicas, nil))
+
+	spec := &v1alpha1.AutoscalingSpec{MaxReplicas: 5}
+	assert.Equal(t, replicas, Replicas(replicas, spec))
+
+	spec.Enabled = true
+	assert.Nil(t, Replicas(replicas, spec))
+}
+
+func TestBuildHorizontalPodAutoscaler(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+	}
+	externalMetric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "spans_received"},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: ptr.To(resource.MustParse("10k")),
+			},
+		},
+	}
+
+	hpa := BuildHorizontalPodAutoscaler(tempo, manifestutils.DistributorComponentName, v1alpha1.AutoscalingSpec{
+		Enabled:                 true,
+		MaxReplicas:             10,
+		TargetCPUUtilization:    ptr.To(int32(70)),
+		TargetMemoryUtilization: ptr.To(int32(80)),
+		Metrics:                 []autoscalingv2.MetricSpec{externalMetric},
+	})
+
+	assert.Equal(t, &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling/v2",
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-distributor",
+			Namespace: "project1",
+			Labels:    manifestutils.ComponentLabels(manifestutils.DistributorComponentName, "test"),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "tempo-test-distributor",
+			},
+			MinReplicas: ptr.To(int32(1)),
+			MaxReplicas: 10,
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: ptr.To(int32(70)),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceMemory,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: ptr.To(int32(80)),
+						},
+					},
+				},
+				externalMetric,
+			},
+		},
+	}, hpa)
+}
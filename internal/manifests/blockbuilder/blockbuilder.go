@@ -0,0 +1,216 @@
+package blockbuilder
+
+import (
+	"fmt"
+
+	"github.com/operator-framework/operator-lib/proxy"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// BuildBlockBuilder creates block-builder objects.
+func BuildBlockBuilder(params manifestutils.Params) ([]client.Object, error) {
+	ss, err := statefulSet(params)
+	if err != nil {
+		return nil, err
+	}
+
+	gates := params.CtrlConfig.Gates
+	tempo := params.Tempo
+
+	if gates.HTTPEncryption || gates.GRPCEncryption {
+		caBundleName := naming.SigningCABundleName(tempo.Name)
+		if err := manifestutils.ConfigureServiceCA(&ss.Spec.Template.Spec, caBundleName); err != nil {
+			return nil, err
+		}
+
+		if err := manifestutils.ConfigureServicePKI(tempo.Name, manifestutils.BlockBuilderComponentName, &ss.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
+	}
+
+	return []client.Object{ss, service(tempo)}, nil
+}
+
+// AssignedPartitions assigns the Kafka partitions to the block-builder instances.
+// Every ingester consumes one partition, and the partitions are distributed round-robin across the block-builders.
+func AssignedPartitions(tempo v1alpha1.TempoStack) map[string][]int32 {
+	partitions := ptr.Deref(tempo.Spec.Template.Ingester.Replicas, 1)
+	blockBuilders := ptr.Deref(tempo.Spec.Template.BlockBuilder.Replicas, 1)
+	if blockBuilders < 1 {
+		return nil
+	}
+
+	assigned := map[string][]int32{}
+	for partition := int32(0); partition < partitions; partition++ {
+		instance := fmt.Sprintf("%s-%d", naming.Name(manifestutils.BlockBuilderComponentName, tempo.Name), partition%blockBuilders)
+		assigned[instance] = append(assigned[instance], partition)
+	}
+	return assigned
+}
+
+func resources(tempo v1alpha1.TempoStack) corev1.ResourceRequirements {
+	if tempo.Spec.Template.BlockBuilder.Resources == nil {
+		return manifestutils.Resources(tempo, manifestutils.BlockBuilderComponentName, tempo.Spec.Template.BlockBuilder.Replicas)
+	}
+	return *tempo.Spec.Template.BlockBuilder.Resources
+}
+
+func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
+	tempo := params.Tempo
+	labels := manifestutils.ComponentLabels(manifestutils.BlockBuilderComponentName, tempo.Name)
+	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
+	annotations = manifestutils.StorageSecretHash(params.StorageParams, annotations)
+	cfg := tempo.Spec.Template.BlockBuilder
+	image := tempo.Spec.Images.Tempo
+	if image == "" {
+		image = params.CtrlConfig.DefaultImages.Tempo
+	}
+
+	ss := &v1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.BlockBuilderComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: v1.StatefulSetSpec{
+			Replicas: cfg.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			ServiceName: naming.Name(manifestutils.BlockBuilderComponentName, tempo.Name),
+			// The block-builders consume independent partitions, there is no need to start them one by one.
+			PodManagementPolicy: v1.ParallelPodManagement,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					Affinity:                  manifestutils.ComponentAffinity(cfg, labels),
+					TopologySpreadConstraints: cfg.TopologySpreadConstraints,
+					Containers: []corev1.Container{
+						{
+							Name:  "tempo",
+							Image: image,
+							Env:   proxy.ReadProxyVarsFromEnv(),
+							Args: []string{
+								"-target=block-builder",
+								"-config.file=/conf/tempo.yaml",
+								"-log.level=info",
+								"-config.expand-env=true",
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          manifestutils.HttpPortName,
+									ContainerPort: manifestutils.PortHTTPServer,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: manifestutils.TempoReadinessProbe(params.CtrlConfig.Gates.HTTPEncryption),
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      manifestutils.ConfigVolumeName,
+									MountPath: "/conf",
+									ReadOnly:  true,
+								},
+								{
+									Name:      manifestutils.RuntimeOverridesVolumeName,
+									MountPath: manifestutils.RuntimeOverridesMountPath,
+									ReadOnly:  true,
+								},
+								{
+									Name:      manifestutils.TmpStorageVolumeName,
+									MountPath: manifestutils.TmpTempoStoragePath,
+								},
+							},
+							Resources:       resources(tempo),
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+					},
+					SecurityContext: cfg.PodSecurityContext,
+					Volumes: []corev1.Volume{
+						{
+							Name: manifestutils.ConfigVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name("", tempo.Name),
+									},
+								},
+							},
+						},
+						{
+							Name: manifestutils.RuntimeOverridesVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name(manifestutils.RuntimeOverridesComponentName, tempo.Name),
+									},
+								},
+							},
+						},
+						{
+							Name: manifestutils.TmpStorageVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := manifestutils.ConfigureStorage(params.StorageParams, tempo, &ss.Spec.Template.Spec, "tempo")
+	if err != nil {
+		return nil, err
+	}
+
+	err = manifestutils.ConfigureKafka(tempo, &ss.Spec.Template.Spec, "tempo")
+	if err != nil {
+		return nil, err
+	}
+
+	ss.Spec.Template, err = manifestutils.PatchTracingEnvConfiguration(tempo, ss.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestutils.SetGoMemLimit("tempo", &ss.Spec.Template.Spec)
+	return ss, nil
+}
+
+func service(tempo v1alpha1.TempoStack) *corev1.Service {
+	labels := manifestutils.ComponentLabels(manifestutils.BlockBuilderComponentName, tempo.Name)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.BlockBuilderComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       manifestutils.HttpPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortHTTPServer,
+					TargetPort: intstr.FromString(manifestutils.HttpPortName),
+				},
+			},
+			Selector: labels,
+		},
+	}
+}
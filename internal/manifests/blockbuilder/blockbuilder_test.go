@@ -0,0 +1,116 @@
+package blockbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func testTempoStack() v1alpha1.TempoStack {
+	return v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Images: configv1alpha1.ImagesSpec{
+				Tempo: "docker.io/grafana/tempo:2.8.0",
+			},
+			ServiceAccount: "tempo-test-serviceaccount",
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					CredentialMode: v1alpha1.CredentialModeStatic,
+					Name:           "test-storage-secret",
+					Type:           "s3",
+				},
+			},
+			Ingest: &v1alpha1.IngestSpec{
+				Kafka: &v1alpha1.KafkaIngestSpec{
+					Brokers: []string{"kafka-0:9092", "kafka-1:9092"},
+					Topic:   "tempo-ingest",
+					SASL:    &v1alpha1.KafkaSASLSpec{Secret: "kafka-credentials"},
+					TLS: v1alpha1.TLSSpec{
+						Enabled: true,
+						CA:      "kafka-ca",
+					},
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoComponentSpec{
+					Replicas: ptr.To(int32(5)),
+				},
+				BlockBuilder: v1alpha1.TempoComponentSpec{
+					Replicas:     ptr.To(int32(2)),
+					NodeSelector: map[string]string{"a": "b"},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildBlockBuilder(t *testing.T) {
+	objects, err := BuildBlockBuilder(manifestutils.Params{
+		Tempo: testTempoStack(),
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	labels := manifestutils.ComponentLabels(manifestutils.BlockBuilderComponentName, "test")
+
+	ss, ok := objects[0].(*v1.StatefulSet)
+	require.True(t, ok)
+	assert.Equal(t, "tempo-test-block-builder", ss.Name)
+	assert.Equal(t, ptr.To(int32(2)), ss.Spec.Replicas)
+	assert.Equal(t, map[string]string(labels), ss.Spec.Template.Labels)
+	assert.Equal(t, map[string]string{"a": "b"}, ss.Spec.Template.Spec.NodeSelector)
+
+	container := ss.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "docker.io/grafana/tempo:2.8.0", container.Image)
+	assert.Contains(t, container.Args, "-target=block-builder")
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name: manifestutils.KafkaSASLPasswordEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-credentials"},
+				Key:                  "password",
+			},
+		},
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "kafka-ca",
+		MountPath: manifestutils.KafkaTLSCADir,
+		ReadOnly:  true,
+	})
+
+	svc, ok := objects[1].(*corev1.Service)
+	require.True(t, ok)
+	assert.Equal(t, "tempo-test-block-builder", svc.Name)
+	assert.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+	assert.Equal(t, map[string]string(labels), svc.Spec.Selector)
+}
+
+func TestAssignedPartitions(t *testing.T) {
+	assert.Equal(t, map[string][]int32{
+		"tempo-test-block-builder-0": {0, 2, 4},
+		"tempo-test-block-builder-1": {1, 3},
+	}, AssignedPartitions(testTempoStack()))
+
+	tempo := testTempoStack()
+	tempo.Spec.Template.Ingester.Replicas = nil
+	tempo.Spec.Template.BlockBuilder.Replicas = nil
+	assert.Equal(t, map[string][]int32{
+		"tempo-test-block-builder-0": {0},
+	}, AssignedPartitions(tempo))
+}
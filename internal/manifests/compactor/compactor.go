@@ -84,9 +84,11 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					Affinity:                  cfg.Affinity,
+					TopologySpreadConstraints: cfg.TopologySpreadConstraints,
 					Containers: []corev1.Container{
 						{
 							Name:  "tempo",
@@ -117,6 +119,11 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 									MountPath: "/conf",
 									ReadOnly:  true,
 								},
+								{
+									Name:      manifestutils.RuntimeOverridesVolumeName,
+									MountPath: manifestutils.RuntimeOverridesMountPath,
+									ReadOnly:  true,
+								},
 								{
 									Name:      manifestutils.TmpStorageVolumeName,
 									MountPath: manifestutils.TmpTempoStoragePath,
@@ -138,6 +145,16 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 								},
 							},
 						},
+						{
+							Name: manifestutils.RuntimeOverridesVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name(manifestutils.RuntimeOverridesComponentName, tempo.Name),
+									},
+								},
+							},
+						},
 						{
 							Name: manifestutils.TmpStorageVolumeName,
 							VolumeSource: corev1.VolumeSource{
@@ -174,6 +174,11 @@ func TestBuildCompactor(t *testing.T) {
 											MountPath: "/conf",
 											ReadOnly:  true,
 										},
+										{
+											Name:      manifestutils.RuntimeOverridesVolumeName,
+											MountPath: manifestutils.RuntimeOverridesMountPath,
+											ReadOnly:  true,
+										},
 										{
 											Name:      manifestutils.TmpStorageVolumeName,
 											MountPath: manifestutils.TmpTempoStoragePath,
@@ -226,6 +231,16 @@ func TestBuildCompactor(t *testing.T) {
 										},
 									},
 								},
+								{
+									Name: manifestutils.RuntimeOverridesVolumeName,
+									VolumeSource: corev1.VolumeSource{
+										ConfigMap: &corev1.ConfigMapVolumeSource{
+											LocalObjectReference: corev1.LocalObjectReference{
+												Name: "tempo-test-overrides",
+											},
+										},
+									},
+								},
 								{
 									Name: manifestutils.TmpStorageVolumeName,
 									VolumeSource: corev1.VolumeSource{
@@ -3,51 +3,90 @@ package config
 import (
 	"bytes"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"io"
 	"path"
+	"strings"
 	"time"
 
 	"k8s.io/utils/ptr"
 
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/blockbuilder"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
+	"github.com/grafana/tempo-operator/internal/manifests/memcached"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/version"
 )
 
+const defaultMemcachedService = "memcached"
+
+var defaultCacheRoles = []v1alpha1.CacheRole{
+	v1alpha1.CacheRoleBloom,
+	v1alpha1.CacheRoleParquetFooter,
+	v1alpha1.CacheRoleFrontendSearch,
+}
+
+// templateFuncs are the functions available in the configuration templates.
+var templateFuncs = template.FuncMap{
+	"quote": quote,
+}
+
 var (
 	//go:embed tempo-config.yaml
 	tempoConfigYAMLTmplFile embed.FS
-	tempoConfigYAMLTmpl     = template.Must(template.ParseFS(tempoConfigYAMLTmplFile, "tempo-config.yaml"))
+	tempoConfigYAMLTmpl     = template.Must(template.New("tempo-config.yaml").Funcs(templateFuncs).ParseFS(tempoConfigYAMLTmplFile, "tempo-config.yaml"))
 
 	//go:embed tempo-overrides.yaml
 	tempoTenantsOverridesYAMLTmplFile embed.FS
-	tempoTenantsOverridesYAMLTmpl     = template.Must(template.ParseFS(tempoTenantsOverridesYAMLTmplFile, "tempo-overrides.yaml"))
+	tempoTenantsOverridesYAMLTmpl     = template.Must(template.New("tempo-overrides.yaml").Funcs(templateFuncs).ParseFS(tempoTenantsOverridesYAMLTmplFile, "tempo-overrides.yaml"))
 
 	//go:embed tempo-query.yaml
 	tempoQueryYAMLTmplFile embed.FS
 	tempoQueryYAMLTmpl     = template.Must(template.ParseFS(tempoQueryYAMLTmplFile, "tempo-query.yaml"))
 )
 
+// quote renders a string as a double-quoted YAML scalar.
+// The JSON encoding of a string is a valid YAML double-quoted scalar, and escapes quotes, backslashes and control characters.
+// The result is not HTML escaped by the template engine.
+func quote(s string) template.HTML {
+	quoted, _ := json.Marshal(s)
+	return template.HTML(quoted) // #nosec G203 -- the string is JSON encoded
+}
+
 func fromRateLimitSpecToTenantOverrides(spec v1alpha1.RateLimitSpec, retention *time.Duration) tenantOverrides {
 	return tenantOverrides{
-		IngestionRateLimitBytes: spec.Ingestion.IngestionRateLimitBytes,
-		IngestionBurstSizeBytes: spec.Ingestion.IngestionBurstSizeBytes,
-		MaxBytesPerTrace:        spec.Ingestion.MaxBytesPerTrace,
-		MaxTracesPerUser:        spec.Ingestion.MaxTracesPerUser,
-		MaxBytesPerTagValues:    spec.Query.MaxBytesPerTagValues,
-		MaxSearchDuration:       spec.Query.MaxSearchDuration.Duration.String(),
-		BlockRetention:          retention,
+		IngestionRateLimitBytes:    spec.Ingestion.IngestionRateLimitBytes,
+		IngestionBurstSizeBytes:    spec.Ingestion.IngestionBurstSizeBytes,
+		MaxBytesPerTrace:           spec.Ingestion.MaxBytesPerTrace,
+		MaxTracesPerUser:           spec.Ingestion.MaxTracesPerUser,
+		MaxBytesPerTagValues:       spec.Query.MaxBytesPerTagValues,
+		MaxSearchDuration:          spec.Query.MaxSearchDuration.Duration.String(),
+		MaxMetricsDuration:         spec.Query.MaxMetricsDuration.Duration.String(),
+		UnsafeQueryHints:           spec.Query.UnsafeQueryHints,
+		MaxAttributeBytes:          spec.Ingestion.MaxAttributeBytes,
+		Forwarders:                 spec.Ingestion.Forwarders,
+		MetricsGeneratorProcessors: metricsGeneratorProcessors(spec.Ingestion.MetricsGeneratorProcessors),
+		CostAttributionDimensions:  spec.Ingestion.CostAttributionDimensions,
+		BlockRetention:             retention,
+	}
+}
+
+func metricsGeneratorProcessors(processors []v1alpha1.MetricsGeneratorProcessor) []string {
+	var result []string
+	for _, processor := range processors {
+		result = append(result, string(processor))
 	}
+	return result
 }
 
 func fromRateLimitSpecToRateLimitOptionsMap(rateLimits map[string]v1alpha1.RateLimitSpec, retentions map[string]v1alpha1.RetentionConfig) map[string]tenantOverrides {
 	result := make(map[string]tenantOverrides, len(rateLimits))
 	for tenant, spec := range rateLimits {
 		retentionsSpec, ok := retentions[tenant]
-		delete(retentions, tenant)
 		var retention *time.Duration
 		if ok {
 			retention = &retentionsSpec.Traces.Duration
@@ -56,6 +95,9 @@ func fromRateLimitSpecToRateLimitOptionsMap(rateLimits map[string]v1alpha1.RateL
 		result[tenant] = fromRateLimitSpecToTenantOverrides(spec, retention)
 	}
 	for tenant, spec := range retentions {
+		if _, ok := rateLimits[tenant]; ok {
+			continue
+		}
 		result[tenant] = fromRateLimitSpecToTenantOverrides(v1alpha1.RateLimitSpec{}, &spec.Traces.Duration)
 	}
 	return result
@@ -81,8 +123,14 @@ func buildConfiguration(params manifestutils.Params) ([]byte, error) {
 		}
 	}
 
+	features, err := version.TempoFeaturesFor(tempo.Spec.TempoVersion)
+	if err != nil {
+		return []byte{}, err
+	}
+
 	opts := options{
-		StorageType:     string(tempo.Spec.Storage.Secret.Type),
+		Features:        features,
+		StorageType:     storageBackend(tempo.Spec.Storage),
 		StorageParams:   params.StorageParams,
 		GlobalRetention: tempo.Spec.Retention.Global.Traces.Duration.String(),
 		MemberList: memberlistOptions{
@@ -100,29 +148,216 @@ func buildConfiguration(params manifestutils.Params) ([]byte, error) {
 			GRPCEncryption: params.CtrlConfig.Gates.GRPCEncryption,
 			HTTPEncryption: params.CtrlConfig.Gates.HTTPEncryption,
 		},
-		TLS:          tlsopts,
-		ReceiverTLS:  buildReceiverTLSConfig(tempo),
-		S3StorageTLS: buildS3StorageTLSConfig(params),
-		Timeout:      params.Tempo.Spec.Timeout.Duration,
+		TLS:              tlsopts,
+		ReceiverTLS:      buildReceiverTLSConfig(tempo),
+		S3StorageTLS:     buildS3StorageTLSConfig(params),
+		Timeout:          params.Tempo.Spec.Timeout.Duration,
+		MetricsGenerator: buildMetricsGeneratorConfig(tempo.Spec.Template.MetricsGenerator),
+		Cache:            buildCacheConfig(tempo),
+		ZoneAwareness:    manifestutils.ZoneAwarenessEnabled(tempo),
+		Ingest:           buildIngestConfig(tempo),
+		StorageTuning:    buildStorageTuningConfig(tempo.Spec.Storage.Tuning, tempo.Spec.Storage.DedicatedColumns),
 	}
 
-	if isTenantOverridesConfigRequired(tempo.Spec.LimitSpec, tempo.Spec.Retention) {
+	if isTenantOverridesConfigRequired(tempo) {
 		opts.TenantRateLimitsPath = tenantOverridesMountPath
 	}
 
 	return renderTemplate(opts)
 }
 
-func isTenantOverridesConfigRequired(limitSpec v1alpha1.LimitSpec, retentionSpec v1alpha1.RetentionSpec) bool {
-	return len(limitSpec.PerTenant) > 0 || len(retentionSpec.PerTenant) > 0
+// storageBackend returns the Tempo storage backend of the TempoStack storage.
+// Swift is accessed through its S3-compatible API, and the filesystem storage uses the local backend
+// on a shared PersistentVolumeClaim.
+func storageBackend(storage v1alpha1.ObjectStorageSpec) string {
+	if storage.Filesystem != nil {
+		return "local"
+	}
+	if storage.Secret.Type == v1alpha1.ObjectStorageSecretSwift {
+		return string(v1alpha1.ObjectStorageSecretS3)
+	}
+	return string(storage.Secret.Type)
+}
+
+// isTenantOverridesConfigRequired returns true if the per-tenant overrides file should be configured.
+// With multi-tenancy enabled, the file is always configured, so that adding the first per-tenant
+// override doesn't change the main configuration file and doesn't restart the pods.
+// The dedicated attribute columns are part of the per-tenant overrides, because Tempo reloads
+// them at runtime.
+func isTenantOverridesConfigRequired(tempo v1alpha1.TempoStack) bool {
+	return len(tempo.Spec.LimitSpec.PerTenant) > 0 || len(tempo.Spec.Retention.PerTenant) > 0 || tempo.Spec.Tenants != nil ||
+		len(tempo.Spec.Storage.DedicatedColumns) > 0
 }
 
 func buildTenantOverrides(tempo v1alpha1.TempoStack) ([]byte, error) {
+	features, err := version.TempoFeaturesFor(tempo.Spec.TempoVersion)
+	if err != nil {
+		return []byte{}, err
+	}
+
 	return renderTenantOverridesTemplate(tenantOptions{
-		TenantOverrides: fromRateLimitSpecToRateLimitOptionsMap(tempo.Spec.LimitSpec.PerTenant, tempo.Spec.Retention.PerTenant),
+		TenantOverrides:  fromRateLimitSpecToRateLimitOptionsMap(tempo.Spec.LimitSpec.PerTenant, tempo.Spec.Retention.PerTenant),
+		DedicatedColumns: buildDedicatedColumns(tempo.Spec.Storage.DedicatedColumns),
+		Features:         features,
 	})
 }
 
+func buildMetricsGeneratorConfig(spec v1alpha1.TempoMetricsGeneratorSpec) metricsGeneratorOptions {
+	if !spec.Enabled {
+		return metricsGeneratorOptions{}
+	}
+
+	opts := metricsGeneratorOptions{
+		Enabled: true,
+	}
+	for _, processor := range spec.Processors {
+		opts.Processors = append(opts.Processors, string(processor))
+		switch processor {
+		case v1alpha1.MetricsGeneratorProcessorServiceGraphs:
+			opts.ServiceGraphs = true
+		case v1alpha1.MetricsGeneratorProcessorSpanMetrics:
+			opts.SpanMetrics = true
+		case v1alpha1.MetricsGeneratorProcessorLocalBlocks:
+			opts.LocalBlocks = true
+		}
+	}
+	for _, rw := range spec.RemoteWrite {
+		opts.RemoteWrite = append(opts.RemoteWrite, remoteWriteOptions{
+			URL:           rw.URL,
+			SendExemplars: rw.SendExemplars,
+			Headers:       rw.Headers,
+		})
+	}
+	return opts
+}
+
+func buildCacheConfig(tempo v1alpha1.TempoStack) cacheOptions {
+	cache := tempo.Spec.Cache
+	if cache == nil {
+		return cacheOptions{}
+	}
+
+	opts := cacheOptions{
+		Enabled: true,
+	}
+	roles := cache.Roles
+	if len(roles) == 0 {
+		roles = defaultCacheRoles
+	}
+	for _, role := range roles {
+		opts.Roles = append(opts.Roles, string(role))
+	}
+
+	switch cache.Backend {
+	case v1alpha1.CacheBackendMemcached:
+		if cache.Memcached == nil {
+			return cacheOptions{}
+		}
+		opts.Memcached = &memcachedOptions{
+			Host:    cache.Memcached.Host,
+			Service: cache.Memcached.Service,
+			Timeout: cache.Memcached.Timeout.Duration,
+		}
+		if cache.Memcached.Managed.Enabled {
+			opts.Memcached.Host = naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.MemcachedComponentName)
+			opts.Memcached.Service = memcached.PortName
+		}
+		if opts.Memcached.Service == "" {
+			opts.Memcached.Service = defaultMemcachedService
+		}
+	case v1alpha1.CacheBackendRedis:
+		if cache.Redis == nil {
+			return cacheOptions{}
+		}
+		opts.Redis = &redisOptions{
+			Endpoint: cache.Redis.Endpoint,
+			Timeout:  cache.Redis.Timeout.Duration,
+		}
+	default:
+		return cacheOptions{}
+	}
+	return opts
+}
+
+func buildStorageTuningConfig(spec *v1alpha1.StorageTuningSpec, columns []v1alpha1.DedicatedColumn) storageTuningOptions {
+	opts := storageTuningOptions{
+		BlocklistPoll:    "5m",
+		MaxBlockDuration: "10m",
+		DedicatedColumns: buildDedicatedColumns(columns),
+	}
+	opts.Block = len(opts.DedicatedColumns) > 0
+	if spec == nil {
+		return opts
+	}
+
+	if spec.BlocklistPoll != nil {
+		opts.BlocklistPoll = spec.BlocklistPoll.Duration.String()
+	}
+	if spec.Block != nil {
+		if spec.Block.MaxDuration != nil {
+			opts.MaxBlockDuration = spec.Block.MaxDuration.Duration.String()
+		}
+		opts.BlockVersion = string(spec.Block.Version)
+		opts.BloomFilterFalsePositive = spec.Block.BloomFilterFalsePositive
+		opts.BloomFilterShardSizeBytes = spec.Block.BloomFilterShardSizeBytes
+		opts.Block = opts.Block || opts.BlockVersion != "" || opts.BloomFilterFalsePositive != "" ||
+			opts.BloomFilterShardSizeBytes != nil
+	}
+	if spec.Compaction != nil {
+		if spec.Compaction.Window != nil {
+			opts.CompactionWindow = spec.Compaction.Window.Duration.String()
+		}
+		opts.MaxBlockBytes = spec.Compaction.MaxBlockBytes
+	}
+	if spec.WAL != nil {
+		opts.WALEncoding = string(spec.WAL.Encoding)
+	}
+	return opts
+}
+
+func buildDedicatedColumns(columns []v1alpha1.DedicatedColumn) []dedicatedColumnOptions {
+	var opts []dedicatedColumnOptions
+	for _, column := range columns {
+		columnType := string(column.Type)
+		if columnType == "" {
+			columnType = string(v1alpha1.DedicatedColumnTypeString)
+		}
+		opts = append(opts, dedicatedColumnOptions{
+			Scope: string(column.Scope),
+			Name:  column.Name,
+			Type:  columnType,
+		})
+	}
+	return opts
+}
+
+func buildIngestConfig(tempo v1alpha1.TempoStack) ingestOptions {
+	if !manifestutils.KafkaIngestEnabled(tempo) {
+		return ingestOptions{}
+	}
+	kafka := tempo.Spec.Ingest.Kafka
+
+	opts := ingestOptions{
+		Enabled:            true,
+		Address:            strings.Join(kafka.Brokers, ","),
+		Topic:              kafka.Topic,
+		SASL:               kafka.SASL != nil,
+		AssignedPartitions: blockbuilder.AssignedPartitions(tempo),
+		TLS: storageTLSOptions{
+			Enabled:       kafka.TLS.Enabled,
+			MinTLSVersion: kafka.TLS.MinVersion,
+		},
+	}
+	if kafka.TLS.CA != "" {
+		opts.TLS.CA = path.Join(manifestutils.KafkaTLSCADir, manifestutils.TLSCAFilename)
+	}
+	if kafka.TLS.Cert != "" {
+		opts.TLS.Certificate = path.Join(manifestutils.KafkaTLSCertDir, manifestutils.TLSCertFilename)
+		opts.TLS.Key = path.Join(manifestutils.KafkaTLSCertDir, manifestutils.TLSKeyFilename)
+	}
+	return opts
+}
+
 func buildReceiverTLSConfig(tempo v1alpha1.TempoStack) receiverTLSOptions {
 	return receiverTLSOptions{
 		Enabled:         tempo.Spec.Template.Distributor.TLS.Enabled,
@@ -165,8 +400,9 @@ func buildTLSConfig(params manifestutils.Params) (tlsOptions, error) {
 			Certificate: path.Join(manifestutils.TempoInternalTLSCertDir, manifestutils.TLSCertFilename),
 		},
 		ServerNames: serverNames{
-			QueryFrontend: naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.QueryFrontendComponentName),
-			Ingester:      naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.IngesterComponentName),
+			QueryFrontend:    naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.QueryFrontendComponentName),
+			Ingester:         naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.IngesterComponentName),
+			MetricsGenerator: naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.MetricsGeneratorComponentName),
 		},
 		Profile: tlsProfileOptions{
 			MinTLSVersion:      params.TLSProfile.MinTLSVersion,
@@ -183,6 +419,11 @@ func buildTempoQueryConfig(params manifestutils.Params) ([]byte, error) {
 		return []byte{}, err
 	}
 
+	features, err := version.TempoFeaturesFor(params.Tempo.Spec.TempoVersion)
+	if err != nil {
+		return []byte{}, err
+	}
+
 	findTracesConcurrentRequests := params.Tempo.Spec.Template.QueryFrontend.JaegerQuery.FindTracesConcurrentRequests
 	if findTracesConcurrentRequests == 0 {
 		querierReplicas := int32(1)
@@ -202,6 +443,7 @@ func buildTempoQueryConfig(params manifestutils.Params) ([]byte, error) {
 		Gateway:                      params.Tempo.Spec.Template.Gateway.Enabled,
 		ServicesQueryDuration:        params.Tempo.Spec.Template.QueryFrontend.JaegerQuery.ServicesQueryDuration.Duration.String(),
 		FindTracesConcurrentRequests: findTracesConcurrentRequests,
+		Features:                     features,
 	})
 }
 
@@ -6,6 +6,7 @@ import (
 
 	openshiftconfigv1 "github.com/openshift/api/config/v1"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
@@ -747,10 +748,17 @@ query_frontend:
 						IngestionRateLimitBytes: intToPointer(200),
 						MaxTracesPerUser:        intToPointer(300),
 						MaxBytesPerTrace:        intToPointer(400),
+						MaxAttributeBytes:       intToPointer(600),
+						Forwarders:              []string{"otel-collector"},
+						CostAttributionDimensions: map[string]string{
+							"service.name": "service",
+						},
 					},
 					Query: v1alpha1.QueryLimit{
 						MaxBytesPerTagValues: intToPointer(500),
 						MaxSearchDuration:    metav1.Duration{Duration: 24 * time.Hour},
+						MaxMetricsDuration:   metav1.Duration{Duration: 3 * time.Hour},
+						UnsafeQueryHints:     ptr.To(false),
 					},
 				},
 			},
@@ -805,6 +813,14 @@ overrides:
   max_bytes_per_trace: 400
   max_bytes_per_tag_values_query: 500
   max_search_duration: 24h0m0s
+  max_metrics_duration: 3h0m0s
+  unsafe_query_hints: false
+  ingestion_max_attribute_bytes: 600
+  forwarders:
+  - "otel-collector"
+  cost_attribution:
+    dimensions:
+      "service.name": "service"
 querier:
   max_concurrent_queries: 20
   frontend_worker:
@@ -901,7 +917,7 @@ memberlist:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
 overrides:
-  per_tenant_override_config: /conf/overrides.yaml
+  per_tenant_override_config: /runtime-config/overrides.yaml
 querier:
   max_concurrent_queries: 20
   frontend_worker:
@@ -1007,6 +1023,216 @@ overrides:
 	require.YAMLEq(t, expectedCfg, string(cfg))
 }
 
+func TestBuildTenantsOverrides_all(t *testing.T) {
+	expectedCfg := `
+---
+overrides:
+  "mytenant":
+    ingestion:
+      max_attribute_bytes: 2048
+    read:
+      max_metrics_duration: 3h0m0s
+      unsafe_query_hints: true
+    forwarders:
+    - "otel-collector"
+    metrics_generator:
+      processors:
+      - service-graphs
+      - local-blocks
+    cost_attribution:
+      dimensions:
+        "resource.team": ""
+        "service.name": "service"
+`
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"mytenant": {
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							MaxAttributeBytes: intToPointer(2048),
+							Forwarders:        []string{"otel-collector"},
+							MetricsGeneratorProcessors: []v1alpha1.MetricsGeneratorProcessor{
+								v1alpha1.MetricsGeneratorProcessorServiceGraphs,
+								v1alpha1.MetricsGeneratorProcessorLocalBlocks,
+							},
+							CostAttributionDimensions: map[string]string{
+								"service.name":  "service",
+								"resource.team": "",
+							},
+						},
+						Query: v1alpha1.QueryLimit{
+							MaxMetricsDuration: metav1.Duration{Duration: 3 * time.Hour},
+							UnsafeQueryHints:   ptr.To(true),
+						},
+					},
+				},
+			},
+		},
+	}
+	cfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	require.YAMLEq(t, expectedCfg, string(cfg))
+}
+
+func TestBuildTenantsOverrides_costAttributionEscaping(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"mytenant": {
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							CostAttributionDimensions: map[string]string{
+								`resource."team"`: "team\\name\ninjected: true",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+
+	var overrides struct {
+		Overrides map[string]struct {
+			CostAttribution struct {
+				Dimensions map[string]string `yaml:"dimensions"`
+			} `yaml:"cost_attribution"`
+		} `yaml:"overrides"`
+	}
+	require.NoError(t, yaml.Unmarshal(cfg, &overrides))
+	require.Equal(t, map[string]string{`resource."team"`: "team\\name\ninjected: true"}, overrides.Overrides["mytenant"].CostAttribution.Dimensions)
+
+	// Tempo versions without cost attribution reject the per-tenant setting
+	tempo.Spec.TempoVersion = "2.6.3"
+	cfg, err = buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	require.NotContains(t, string(cfg), "cost_attribution")
+}
+
+func TestBuildTenantsOverrides_dedicatedColumns(t *testing.T) {
+	expectedCfg := `
+---
+overrides:
+  "*":
+    storage:
+      parquet_dedicated_columns:
+      - scope: resource
+        name: "k8s.namespace.name"
+        type: string
+      - scope: span
+        name: "http.route"
+        type: string
+  "mytenant":
+    ingestion:
+    read:
+    compaction:
+      block_retention: 24h0m0s
+    storage:
+      parquet_dedicated_columns:
+      - scope: resource
+        name: "k8s.namespace.name"
+        type: string
+      - scope: span
+        name: "http.route"
+        type: string
+`
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				DedicatedColumns: []v1alpha1.DedicatedColumn{
+					{Scope: v1alpha1.DedicatedColumnScopeResource, Name: "k8s.namespace.name"},
+					{Scope: v1alpha1.DedicatedColumnScopeSpan, Name: "http.route", Type: v1alpha1.DedicatedColumnTypeString},
+				},
+			},
+			Retention: v1alpha1.RetentionSpec{
+				PerTenant: map[string]v1alpha1.RetentionConfig{
+					"mytenant": {
+						Traces: metav1.Duration{Duration: time.Hour * 24},
+					},
+				},
+			},
+		},
+	}
+	require.True(t, isTenantOverridesConfigRequired(tempo))
+	cfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	require.YAMLEq(t, expectedCfg, string(cfg))
+}
+
+func TestBuildTenantsOverrides_dedicatedColumnsQuoting(t *testing.T) {
+	name := `http.req&res's "quoted"`
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "nstest",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Type: v1alpha1.ObjectStorageSecretS3,
+				},
+				DedicatedColumns: []v1alpha1.DedicatedColumn{
+					{Scope: v1alpha1.DedicatedColumnScopeSpan, Name: name, Type: v1alpha1.DedicatedColumnTypeString},
+				},
+			},
+			ReplicationFactor: 1,
+			Retention: v1alpha1.RetentionSpec{
+				PerTenant: map[string]v1alpha1.RetentionConfig{
+					"mytenant": {
+						Traces: metav1.Duration{Duration: time.Hour * 24},
+					},
+				},
+			},
+		},
+	}
+
+	type dedicatedColumns struct {
+		Columns []struct {
+			Name string `yaml:"name"`
+		} `yaml:"parquet_dedicated_columns"`
+	}
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo:         tempo,
+		StorageParams: manifestutils.StorageParams{S3: &manifestutils.S3{}},
+	})
+	require.NoError(t, err)
+	var actual struct {
+		Storage struct {
+			Trace struct {
+				Block dedicatedColumns `yaml:"block"`
+			} `yaml:"trace"`
+		} `yaml:"storage"`
+	}
+	require.NoError(t, yaml.Unmarshal(cfg, &actual))
+	require.Len(t, actual.Storage.Trace.Block.Columns, 1)
+	require.Equal(t, name, actual.Storage.Trace.Block.Columns[0].Name)
+
+	overridesCfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	var overrides struct {
+		Overrides map[string]struct {
+			Storage dedicatedColumns `yaml:"storage"`
+		} `yaml:"overrides"`
+	}
+	require.NoError(t, yaml.Unmarshal(overridesCfg, &overrides))
+	for _, tenant := range []string{"*", "mytenant"} {
+		require.Len(t, overrides.Overrides[tenant].Storage.Columns, 1, tenant)
+		require.Equal(t, name, overrides.Overrides[tenant].Storage.Columns[0].Name, tenant)
+	}
+}
+
 func TestBuildTenantsOverrides_retention(t *testing.T) {
 	expectedCfg := `
 ---
@@ -1132,135 +1358,839 @@ query_frontend:
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "test",
 					},
-					Spec: v1alpha1.TempoStackSpec{
-						Timeout: metav1.Duration{Duration: time.Minute * 3},
-						Storage: v1alpha1.ObjectStorageSpec{
-							Secret: v1alpha1.ObjectStorageSecretSpec{
-								Type: v1alpha1.ObjectStorageSecretGCS,
-							},
+					Spec: v1alpha1.TempoStackSpec{
+						Timeout: metav1.Duration{Duration: time.Minute * 3},
+						Storage: v1alpha1.ObjectStorageSpec{
+							Secret: v1alpha1.ObjectStorageSecretSpec{
+								Type: v1alpha1.ObjectStorageSecretGCS,
+							},
+						},
+						ReplicationFactor: 1,
+						SearchSpec:        tc.spec,
+					},
+				},
+				StorageParams: manifestutils.StorageParams{
+					GCS: &manifestutils.GCS{
+						Bucket: "test-bucket",
+					},
+				},
+				TLSProfile: tlsprofile.TLSProfileOptions{
+					MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+				},
+			})
+			require.NoError(t, err)
+			require.YAMLEq(t, tc.expect, string(cfg))
+		})
+	}
+}
+
+func TestBuildConfiguration_ReplicationFactor(t *testing.T) {
+
+	replcationFactor := 10
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 10
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  http_server_read_timeout: 3m0s
+  http_server_write_timeout: 3m0s
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+    max_spans_per_span_set: 0
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Timeout: metav1.Duration{Duration: time.Minute * 3},
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: replcationFactor,
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_MetricsGenerator(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+metrics_generator:
+  ring:
+    kvstore:
+      store: memberlist
+  processor:
+    service_graphs: {}
+    span_metrics: {}
+  storage:
+    path: /var/tempo/generator/wal
+    remote_write:
+    - url: http://prometheus:9090/api/v1/write
+      send_exemplars: true
+      headers:
+        X-Scope-OrgID: dev
+  traces_storage:
+    path: /var/tempo/generator/traces
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+overrides:
+  metrics_generator_processors:
+  - service-graphs
+  - span-metrics
+querier:
+  max_concurrent_queries: 20
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  http_server_read_timeout: 3m0s
+  http_server_write_timeout: 3m0s
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+    max_spans_per_span_set: 0
+`
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Timeout: metav1.Duration{Duration: time.Minute * 3},
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+						Enabled: true,
+						Processors: []v1alpha1.MetricsGeneratorProcessor{
+							v1alpha1.MetricsGeneratorProcessorServiceGraphs,
+							v1alpha1.MetricsGeneratorProcessorSpanMetrics,
+						},
+						RemoteWrite: []v1alpha1.MetricsGeneratorRemoteWriteSpec{
+							{
+								URL:           "http://prometheus:9090/api/v1/write",
+								SendExemplars: true,
+								Headers:       map[string]string{"X-Scope-OrgID": "dev"},
+							},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_RemoteWriteQuoting(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+						Enabled: true,
+						RemoteWrite: []v1alpha1.MetricsGeneratorRemoteWriteSpec{
+							{
+								URL: "http://prometheus:9090/api/v1/write?a=1&b=2#fragment",
+								Headers: map[string]string{
+									"Authorization": "Bearer abc: #def \"quoted\"",
+									"*alias":        "&anchor",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{S3: &manifestutils.S3{}},
+	})
+	require.NoError(t, err)
+
+	var actual struct {
+		MetricsGenerator struct {
+			Storage struct {
+				RemoteWrite []struct {
+					URL     string            `yaml:"url"`
+					Headers map[string]string `yaml:"headers"`
+				} `yaml:"remote_write"`
+			} `yaml:"storage"`
+		} `yaml:"metrics_generator"`
+	}
+	require.NoError(t, yaml.Unmarshal(cfg, &actual))
+	require.Len(t, actual.MetricsGenerator.Storage.RemoteWrite, 1)
+	require.Equal(t, "http://prometheus:9090/api/v1/write?a=1&b=2#fragment", actual.MetricsGenerator.Storage.RemoteWrite[0].URL)
+	require.Equal(t, map[string]string{
+		"Authorization": "Bearer abc: #def \"quoted\"",
+		"*alias":        "&anchor",
+	}, actual.MetricsGenerator.Storage.RemoteWrite[0].Headers)
+}
+
+func TestBuildConfiguration_ForwardersQuoting(t *testing.T) {
+	forwarders := []string{"otel's", "a&b", `"quoted"`}
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "nstest",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Type: v1alpha1.ObjectStorageSecretS3,
+				},
+			},
+			ReplicationFactor: 1,
+			LimitSpec: v1alpha1.LimitSpec{
+				Global: v1alpha1.RateLimitSpec{
+					Ingestion: v1alpha1.IngestionLimitSpec{Forwarders: forwarders},
+				},
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"mytenant": {
+						Ingestion: v1alpha1.IngestionLimitSpec{Forwarders: forwarders},
+					},
+				},
+			},
+		},
+	}
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo:         tempo,
+		StorageParams: manifestutils.StorageParams{S3: &manifestutils.S3{}},
+	})
+	require.NoError(t, err)
+
+	var actual struct {
+		Overrides struct {
+			Forwarders []string `yaml:"forwarders"`
+		} `yaml:"overrides"`
+	}
+	require.NoError(t, yaml.Unmarshal(cfg, &actual))
+	require.Equal(t, forwarders, actual.Overrides.Forwarders)
+
+	overridesCfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+
+	var overrides struct {
+		Overrides map[string]struct {
+			Forwarders []string `yaml:"forwarders"`
+		} `yaml:"overrides"`
+	}
+	require.NoError(t, yaml.Unmarshal(overridesCfg, &overrides))
+	require.Equal(t, forwarders, overrides.Overrides["mytenant"].Forwarders)
+}
+
+func TestBuildConfiguration_Cache(t *testing.T) {
+	tests := []struct {
+		name   string
+		cache  *v1alpha1.CacheSpec
+		expect string
+	}{
+		{
+			name: "managed memcached",
+			cache: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Memcached: &v1alpha1.MemcachedSpec{
+					Managed: v1alpha1.ManagedMemcachedSpec{Enabled: true},
+				},
+			},
+			expect: `
+cache:
+  caches:
+  - roles:
+    - bloom
+    - parquet-footer
+    - frontend-search
+    memcached:
+      host: tempo-test-memcached.nstest.svc.cluster.local
+      service: memcached-client
+      consistent_hash: true
+`,
+		},
+		{
+			name: "external memcached",
+			cache: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Roles:   []v1alpha1.CacheRole{v1alpha1.CacheRoleBloom},
+				Memcached: &v1alpha1.MemcachedSpec{
+					Host:    "memcached.cache.svc.cluster.local",
+					Timeout: metav1.Duration{Duration: 500 * time.Millisecond},
+				},
+			},
+			expect: `
+cache:
+  caches:
+  - roles:
+    - bloom
+    memcached:
+      host: memcached.cache.svc.cluster.local
+      service: memcached
+      timeout: 500ms
+      consistent_hash: true
+`,
+		},
+		{
+			name: "redis",
+			cache: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendRedis,
+				Roles:   []v1alpha1.CacheRole{v1alpha1.CacheRoleParquetFooter, v1alpha1.CacheRoleFrontendSearch},
+				Redis: &v1alpha1.RedisSpec{
+					Endpoint: "redis:6379",
+					Timeout:  metav1.Duration{Duration: time.Second},
+				},
+			},
+			expect: `
+cache:
+  caches:
+  - roles:
+    - parquet-footer
+    - frontend-search
+    redis:
+      endpoint: redis:6379
+      timeout: 1s
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := buildConfiguration(manifestutils.Params{
+				Tempo: v1alpha1.TempoStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "nstest",
+					},
+					Spec: v1alpha1.TempoStackSpec{
+						Storage: v1alpha1.ObjectStorageSpec{
+							Secret: v1alpha1.ObjectStorageSecretSpec{
+								Type: v1alpha1.ObjectStorageSecretS3,
+							},
+						},
+						ReplicationFactor: 1,
+						Cache:             test.cache,
+					},
+				},
+				StorageParams: manifestutils.StorageParams{S3: &manifestutils.S3{}},
+			})
+			require.NoError(t, err)
+
+			var actual, expected map[string]interface{}
+			require.NoError(t, yaml.Unmarshal(cfg, &actual))
+			require.NoError(t, yaml.Unmarshal([]byte(test.expect), &expected))
+			require.Equal(t, expected["cache"], actual["cache"])
+		})
+	}
+}
+
+func TestBuildConfiguration_ZoneAwareness(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				ReplicationFactor: 3,
+				ZoneAwareness:     &v1alpha1.ZoneAwarenessSpec{Enabled: true},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{S3: &manifestutils.S3{}},
+	})
+	require.NoError(t, err)
+
+	expected := `
+lifecycler:
+  ring:
+    kvstore:
+      store: memberlist
+    replication_factor: 3
+    zone_awareness_enabled: true
+  tokens_file_path: /var/tempo/tokens.json
+  availability_zone: ${TEMPO_AVAILABILITY_ZONE}
+`
+	var actual, expectedIngester map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(cfg, &actual))
+	require.NoError(t, yaml.Unmarshal([]byte(expected), &expectedIngester))
+	ingester, ok := actual["ingester"].(map[interface{}]interface{})
+	require.True(t, ok)
+	require.Equal(t, expectedIngester["lifecycler"], ingester["lifecycler"])
+}
+
+func TestBuildConfiguration_KafkaIngest(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				ReplicationFactor: 1,
+				Ingest: &v1alpha1.IngestSpec{
+					Kafka: &v1alpha1.KafkaIngestSpec{
+						Brokers: []string{"kafka-0:9092", "kafka-1:9092"},
+						Topic:   "tempo-ingest",
+						SASL:    &v1alpha1.KafkaSASLSpec{Secret: "kafka-credentials"},
+						TLS: v1alpha1.TLSSpec{
+							Enabled: true,
+							CA:      "kafka-ca",
+							Cert:    "kafka-cert",
 						},
-						ReplicationFactor: 1,
-						SearchSpec:        tc.spec,
 					},
 				},
-				StorageParams: manifestutils.StorageParams{
-					GCS: &manifestutils.GCS{
-						Bucket: "test-bucket",
+				Template: v1alpha1.TempoTemplateSpec{
+					Ingester: v1alpha1.TempoComponentSpec{
+						Replicas: ptr.To(int32(3)),
+					},
+					BlockBuilder: v1alpha1.TempoComponentSpec{
+						Replicas: ptr.To(int32(2)),
 					},
 				},
-				TLSProfile: tlsprofile.TLSProfileOptions{
-					MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
-				},
-			})
-			require.NoError(t, err)
-			require.YAMLEq(t, tc.expect, string(cfg))
-		})
-	}
+			},
+		},
+		StorageParams: manifestutils.StorageParams{S3: &manifestutils.S3{}},
+	})
+	require.NoError(t, err)
+
+	expected := `
+ingest:
+  enabled: true
+  kafka:
+    address: kafka-0:9092,kafka-1:9092
+    topic: tempo-ingest
+    sasl_username: ${KAFKA_SASL_USERNAME}
+    sasl_password: ${KAFKA_SASL_PASSWORD}
+    tls_enabled: true
+    tls_ca_path: /var/run/tls/kafka/ca/service-ca.crt
+    tls_cert_path: /var/run/tls/kafka/cert/tls.crt
+    tls_key_path: /var/run/tls/kafka/cert/tls.key
+block_builder:
+  assigned_partitions:
+    tempo-test-block-builder-0:
+    - 0
+    - 2
+    tempo-test-block-builder-1:
+    - 1
+partition_ring:
+  kvstore:
+    store: memberlist
+`
+	var actual, expectedCfg map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(cfg, &actual))
+	require.NoError(t, yaml.Unmarshal([]byte(expected), &expectedCfg))
+	require.Equal(t, expectedCfg["ingest"], actual["ingest"])
+	require.Equal(t, expectedCfg["block_builder"], actual["block_builder"])
+	ingester, ok := actual["ingester"].(map[interface{}]interface{})
+	require.True(t, ok)
+	require.Equal(t, expectedCfg["partition_ring"], ingester["partition_ring"])
 }
 
-func TestBuildConfiguration_ReplicationFactor(t *testing.T) {
+func TestBuildConfiguration_StorageTuning(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+					Tuning: &v1alpha1.StorageTuningSpec{
+						BlocklistPoll: &metav1.Duration{Duration: 2 * time.Minute},
+						Block: &v1alpha1.BlockTuningSpec{
+							Version:                   v1alpha1.BlockVersionParquet4,
+							BloomFilterFalsePositive:  "0.05",
+							BloomFilterShardSizeBytes: ptr.To(102400),
+							MaxDuration:               &metav1.Duration{Duration: 5 * time.Minute},
+						},
+						Compaction: &v1alpha1.CompactionTuningSpec{
+							Window:        &metav1.Duration{Duration: 30 * time.Minute},
+							MaxBlockBytes: ptr.To(10737418240),
+						},
+						WAL: &v1alpha1.WALTuningSpec{Encoding: "zstd"},
+					},
+					DedicatedColumns: []v1alpha1.DedicatedColumn{
+						{Scope: v1alpha1.DedicatedColumnScopeResource, Name: "k8s.namespace.name"},
+						{Scope: v1alpha1.DedicatedColumnScopeSpan, Name: "http.route", Type: v1alpha1.DedicatedColumnTypeString},
+					},
+				},
+				Retention:         v1alpha1.RetentionSpec{Global: v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: 48 * time.Hour}}},
+				ReplicationFactor: 1,
+			},
+		},
+		StorageParams: manifestutils.StorageParams{S3: &manifestutils.S3{}},
+	})
+	require.NoError(t, err)
 
-	replcationFactor := 10
-	expect := `
----
+	expected := `
 compactor:
   compaction:
-    block_retention: 0s
-  ring:
-    kvstore:
-      store: memberlist
-distributor:
-  receivers:
-    jaeger:
-      protocols:
-        thrift_http:
-          endpoint: 0.0.0.0:14268
-        thrift_binary:
-          endpoint: 0.0.0.0:6832
-        thrift_compact:
-          endpoint: 0.0.0.0:6831
-        grpc:
-          endpoint: 0.0.0.0:14250
-    zipkin:
-      endpoint: 0.0.0.0:9411
-    otlp:
-      protocols:
-        grpc:
-          endpoint: "0.0.0.0:4317"
-        http:
-          endpoint: "0.0.0.0:4318"
+    block_retention: 48h0m0s
+    compaction_window: 30m0s
+    max_block_bytes: 10737418240
   ring:
     kvstore:
       store: memberlist
 ingester:
-  lifecycler:
-    ring:
-      kvstore:
-        store: memberlist
-      replication_factor: 10
-    tokens_file_path: /var/tempo/tokens.json
-  max_block_duration: 10m
-memberlist:
-  abort_if_cluster_join_fails: false
-  join_members:
-    - tempo-test-gossip-ring
-multitenancy_enabled: false
-querier:
-  max_concurrent_queries: 20
-  frontend_worker:
-    frontend_address: "tempo-test-query-frontend-discovery:9095"
-server:
-  grpc_server_max_recv_msg_size: 4194304
-  grpc_server_max_send_msg_size: 4194304
-  http_listen_port: 3200
-  http_server_read_timeout: 3m0s
-  http_server_write_timeout: 3m0s
-  log_format: logfmt
+  max_block_duration: 5m0s
 storage:
   trace:
-    backend: azure
-    blocklist_poll: 5m
+    backend: s3
+    blocklist_poll: 2m0s
+    block:
+      version: vParquet4
+      bloom_filter_false_positive: 0.05
+      bloom_filter_shard_size_bytes: 102400
+      parquet_dedicated_columns:
+      - scope: resource
+        name: k8s.namespace.name
+        type: string
+      - scope: span
+        name: http.route
+        type: string
     local:
       path: /var/tempo/traces
-    azure:
-      container_name: "container-test"
     wal:
       path: /var/tempo/wal
-usage_report:
-  reporting_enabled: false
-query_frontend:
-  search:
-    concurrent_jobs: 2000
-    max_duration: 0s
-    max_spans_per_span_set: 0
-      `
+      v2_encoding: zstd
+`
+	var actual, expectedCfg map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(cfg, &actual))
+	require.NoError(t, yaml.Unmarshal([]byte(expected), &expectedCfg))
+	require.Equal(t, expectedCfg["compactor"], actual["compactor"])
+	require.Equal(t, expectedCfg["storage"], actual["storage"])
+	ingester, ok := actual["ingester"].(map[interface{}]interface{})
+	require.True(t, ok)
+	require.Equal(t, "5m0s", ingester["max_block_duration"])
+}
 
-	cfg, err := buildConfiguration(manifestutils.Params{
-		Tempo: v1alpha1.TempoStack{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: "test",
+func TestBuildConfiguration_StorageBackends(t *testing.T) {
+	tests := []struct {
+		name          string
+		storage       v1alpha1.ObjectStorageSpec
+		storageParams manifestutils.StorageParams
+		expected      string
+	}{
+		{
+			name: "swift",
+			storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Type: v1alpha1.ObjectStorageSecretSwift,
+				},
 			},
-			Spec: v1alpha1.TempoStackSpec{
-				Timeout: metav1.Duration{Duration: time.Minute * 3},
-				Storage: v1alpha1.ObjectStorageSpec{
-					Secret: v1alpha1.ObjectStorageSecretSpec{
-						Type: v1alpha1.ObjectStorageSecretAzure,
+			storageParams: manifestutils.StorageParams{
+				CredentialMode: v1alpha1.CredentialModeStatic,
+				S3: &manifestutils.S3{
+					Endpoint:       "swift.example.com",
+					Bucket:         "tempo",
+					Region:         "RegionOne",
+					ForcePathStyle: true,
+				},
+			},
+			expected: `
+storage:
+  trace:
+    backend: s3
+    blocklist_poll: 5m
+    s3:
+      endpoint: swift.example.com
+      bucket: tempo
+      insecure: false
+      region: RegionOne
+      forcepathstyle: true
+    local:
+      path: /var/tempo/traces
+    wal:
+      path: /var/tempo/wal
+`,
+		},
+		{
+			name: "s3 with SSE-KMS and prefix",
+			storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Type: v1alpha1.ObjectStorageSecretS3,
+				},
+			},
+			storageParams: manifestutils.StorageParams{
+				CredentialMode: v1alpha1.CredentialModeStatic,
+				S3: &manifestutils.S3{
+					Endpoint:       "minio:9000",
+					Bucket:         "tempo",
+					Insecure:       true,
+					ForcePathStyle: true,
+					Prefix:         "*stacks/a&b #test",
+					SSE: &manifestutils.S3SSE{
+						Type:     "SSE-KMS",
+						KMSKeyID: "alias/a&b",
 					},
 				},
-				ReplicationFactor: replcationFactor,
 			},
+			expected: `
+storage:
+  trace:
+    backend: s3
+    blocklist_poll: 5m
+    s3:
+      endpoint: minio:9000
+      bucket: tempo
+      insecure: true
+      forcepathstyle: true
+      prefix: "*stacks/a&b #test"
+      sse:
+        type: SSE-KMS
+        kms_key_id: "alias/a&b"
+    local:
+      path: /var/tempo/traces
+    wal:
+      path: /var/tempo/wal
+`,
 		},
-		StorageParams: manifestutils.StorageParams{
-			AzureStorage: &manifestutils.AzureStorage{
-				Container: "container-test",
+		{
+			name: "s3 short lived with custom endpoint",
+			storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Type: v1alpha1.ObjectStorageSecretS3,
+				},
 			},
+			storageParams: manifestutils.StorageParams{
+				CredentialMode: v1alpha1.CredentialModeToken,
+				S3: &manifestutils.S3{
+					Endpoint: "s3-fips.us-gov-west-1.amazonaws.com",
+					Bucket:   "tempo",
+					Region:   "us-gov-west-1",
+					RoleARN:  "arn:aws-us-gov:iam::123456789012:role/tempo",
+					SSE: &manifestutils.S3SSE{
+						Type: "SSE-S3",
+					},
+					STSEndpoint: "https://sts-fips.us-gov-west-1.amazonaws.com",
+				},
+			},
+			expected: `
+storage:
+  trace:
+    backend: s3
+    blocklist_poll: 5m
+    s3:
+      endpoint: s3-fips.us-gov-west-1.amazonaws.com
+      region: us-gov-west-1
+      native_aws_auth_enabled: true
+      bucket: tempo
+      insecure: false
+      sse:
+        type: SSE-S3
+    local:
+      path: /var/tempo/traces
+    wal:
+      path: /var/tempo/wal
+`,
 		},
-		TLSProfile: tlsprofile.TLSProfileOptions{
-			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		{
+			name: "filesystem",
+			storage: v1alpha1.ObjectStorageSpec{
+				Filesystem: &v1alpha1.FilesystemStorageSpec{
+					ClaimName: "traces",
+				},
+			},
+			expected: `
+storage:
+  trace:
+    backend: local
+    blocklist_poll: 5m
+    local:
+      path: /var/tempo/traces
+    wal:
+      path: /var/tempo/wal
+`,
 		},
-	})
-	require.NoError(t, err)
-	require.YAMLEq(t, expect, string(cfg))
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := buildConfiguration(manifestutils.Params{
+				Tempo: v1alpha1.TempoStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "nstest",
+					},
+					Spec: v1alpha1.TempoStackSpec{
+						Storage:           test.storage,
+						ReplicationFactor: 1,
+					},
+				},
+				StorageParams: test.storageParams,
+			})
+			require.NoError(t, err)
+
+			var actual, expected map[string]interface{}
+			require.NoError(t, yaml.Unmarshal(cfg, &actual))
+			require.NoError(t, yaml.Unmarshal([]byte(test.expected), &expected))
+			require.Equal(t, expected["storage"], actual["storage"])
+		})
+	}
 }
 
 func TestBuildConfiguration_Multitenancy(t *testing.T) {
@@ -1308,6 +2238,8 @@ memberlist:
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: true
+overrides:
+  per_tenant_override_config: /runtime-config/overrides.yaml
 querier:
   max_concurrent_queries: 20
   frontend_worker:
@@ -2742,3 +3674,67 @@ query_frontend:
 	require.NoError(t, err)
 	require.YAMLEq(t, expCfg, string(cfg))
 }
+
+func TestBuildConfiguration_TempoVersion(t *testing.T) {
+	params := manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				TempoVersion: "2.6.3",
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				ReplicationFactor: 1,
+				Ingest: &v1alpha1.IngestSpec{
+					Kafka: &v1alpha1.KafkaIngestSpec{
+						Brokers: []string{"kafka-0:9092"},
+						Topic:   "tempo-ingest",
+					},
+				},
+				Template: v1alpha1.TempoTemplateSpec{
+					QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+						JaegerQuery: v1alpha1.JaegerQuerySpec{
+							ServicesQueryDuration: &metav1.Duration{Duration: 72 * time.Hour},
+						},
+					},
+				},
+				LimitSpec: v1alpha1.LimitSpec{
+					Global: v1alpha1.RateLimitSpec{
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							CostAttributionDimensions: map[string]string{"service.name": "service"},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{S3: &manifestutils.S3{}},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	}
+
+	cfg, err := buildConfiguration(params)
+	require.NoError(t, err)
+	var actual map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(cfg, &actual))
+	require.NotContains(t, actual, "ingest")
+	require.NotContains(t, actual, "block_builder")
+	require.NotContains(t, actual, "overrides")
+	ingester, ok := actual["ingester"].(map[interface{}]interface{})
+	require.True(t, ok)
+	require.NotContains(t, ingester, "partition_ring")
+
+	tempoQueryCfg, err := buildTempoQueryConfig(params)
+	require.NoError(t, err)
+	require.NotContains(t, string(tempoQueryCfg), "services_query_duration")
+	require.NotContains(t, string(tempoQueryCfg), "find_traces_concurrent_requests")
+
+	params.Tempo.Spec.TempoVersion = "2.5.0"
+	_, err = buildConfiguration(params)
+	require.EqualError(t, err, "unsupported Tempo version 2.5.0, the supported versions of this operator version are 2.6.x, 2.7.x, 2.8.x")
+}
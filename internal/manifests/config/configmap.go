@@ -3,23 +3,24 @@ package config
 import (
 	"crypto/sha256"
 	"fmt"
+	"path"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
 )
 
-const tenantOverridesMountPath = "/conf/overrides.yaml"
 const tempoConfigKey = "tempo.yaml"
 const tempoQueryFrontendConfigKey = "tempo-query-frontend.yaml"
 const tempoQueryConfigKey = "tempo-query.yaml"
-const overridesConfigKey = "overrides.yaml"
 
-// BuildConfigMap builds the tempo configuration file and the tenant-specific overrides configuration.
-// It returns a ConfigMap containing both configuration files and the checksum of the main configuration file
-// (the tenant-specific configuration gets reloaded automatically, therefore no checksum is required).
+var tenantOverridesMountPath = path.Join(manifestutils.RuntimeOverridesMountPath, manifestutils.RuntimeOverridesFileName)
+
+// BuildConfigMap builds the tempo configuration files.
+// It returns a ConfigMap containing the configuration files and the checksum of the main configuration file.
 func BuildConfigMap(params manifestutils.Params) (*corev1.ConfigMap, string, error) {
 	tempo := params.Tempo
 
@@ -28,11 +29,6 @@ func BuildConfigMap(params manifestutils.Params) (*corev1.ConfigMap, string, err
 		return nil, "", err
 	}
 
-	overridesConfig, err := buildTenantOverrides(tempo)
-	if err != nil {
-		return nil, "", err
-	}
-
 	frontendConfig, err := buildQueryFrontEndConfig(params)
 	if err != nil {
 		return nil, "", err
@@ -62,7 +58,6 @@ func BuildConfigMap(params manifestutils.Params) (*corev1.ConfigMap, string, err
 		Data: map[string]string{
 			tempoConfigKey:              string(config),
 			tempoQueryFrontendConfigKey: string(frontendConfig),
-			overridesConfigKey:          string(overridesConfig),
 		},
 	}
 	if tempo.Spec.Template.QueryFrontend.JaegerQuery.Enabled {
@@ -74,9 +69,30 @@ func BuildConfigMap(params manifestutils.Params) (*corev1.ConfigMap, string, err
 	}
 
 	// We only need to hash the main ConfigMap, the per-tenant overrides
-	// is reloaded by tempo without requiring a restart
+	// are stored in a separate ConfigMap and reloaded by tempo without requiring a restart
 	h := sha256.Sum256(config)
 	checksum := fmt.Sprintf("%x", h)
 
 	return configMap, checksum, nil
 }
+
+// BuildOverridesConfigMap builds the tenant-specific overrides configuration.
+// The ConfigMap is mounted as a separate volume and is not part of the configuration checksum,
+// because tempo reloads the file at runtime and the kubelet updates the mounted file in place.
+func BuildOverridesConfigMap(tempo v1alpha1.TempoStack) (*corev1.ConfigMap, error) {
+	overridesConfig, err := buildTenantOverrides(tempo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.RuntimeOverridesComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    manifestutils.ComponentLabels(manifestutils.RuntimeOverridesComponentName, tempo.Name),
+		},
+		Data: map[string]string{
+			manifestutils.RuntimeOverridesFileName: string(overridesConfig),
+		},
+	}, nil
+}
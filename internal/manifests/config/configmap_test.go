@@ -43,6 +43,54 @@ func TestConfigmap(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cm.Data)
 	require.NotNil(t, cm.Data["tempo.yaml"])
-	require.NotNil(t, cm.Data["overrides.yaml"])
+	require.NotContains(t, cm.Data, "overrides.yaml")
 	require.Equal(t, fmt.Sprintf("%x", sha256.Sum256([]byte(cm.Data["tempo.yaml"]))), checksum)
 }
+
+func TestOverridesConfigmap(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "nstest",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Retention: v1alpha1.RetentionSpec{
+				PerTenant: map[string]v1alpha1.RetentionConfig{
+					"dev": {Traces: metav1.Duration{Duration: 48 * time.Hour}},
+				},
+			},
+		},
+	}
+	params := manifestutils.Params{
+		Tempo: tempo,
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{
+				Endpoint: "http://minio:9000",
+				Bucket:   "tempo",
+			},
+		},
+	}
+
+	cm, err := BuildOverridesConfigMap(tempo)
+	require.NoError(t, err)
+	require.Equal(t, "tempo-test-overrides", cm.Name)
+	require.Equal(t, "nstest", cm.Namespace)
+	require.YAMLEq(t, `
+overrides:
+  "dev":
+    ingestion:
+    read:
+    compaction:
+      block_retention: 48h0m0s
+`, cm.Data["overrides.yaml"])
+
+	// changing a per-tenant override must not change the checksum of the main configuration
+	_, checksum, err := BuildConfigMap(params)
+	require.NoError(t, err)
+	params.Tempo.Spec.Retention.PerTenant = map[string]v1alpha1.RetentionConfig{
+		"dev": {Traces: metav1.Duration{Duration: 24 * time.Hour}},
+	}
+	_, newChecksum, err := BuildConfigMap(params)
+	require.NoError(t, err)
+	require.Equal(t, checksum, newChecksum)
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/version"
 )
 
 // options holds the configuration template options.
@@ -24,6 +25,12 @@ type options struct {
 	ReceiverTLS            receiverTLSOptions
 	S3StorageTLS           storageTLSOptions
 	Timeout                time.Duration
+	MetricsGenerator       metricsGeneratorOptions
+	Cache                  cacheOptions
+	ZoneAwareness          bool
+	Ingest                 ingestOptions
+	StorageTuning          storageTuningOptions
+	Features               version.TempoFeatures
 }
 
 type tempoQueryOptions struct {
@@ -34,6 +41,68 @@ type tempoQueryOptions struct {
 	Gateway                      bool
 	ServicesQueryDuration        string
 	FindTracesConcurrentRequests int
+	Features                     version.TempoFeatures
+}
+
+type metricsGeneratorOptions struct {
+	Enabled       bool
+	Processors    []string
+	ServiceGraphs bool
+	SpanMetrics   bool
+	LocalBlocks   bool
+	RemoteWrite   []remoteWriteOptions
+}
+
+type remoteWriteOptions struct {
+	URL           string
+	SendExemplars bool
+	Headers       map[string]string
+}
+
+type cacheOptions struct {
+	Enabled   bool
+	Roles     []string
+	Memcached *memcachedOptions
+	Redis     *redisOptions
+}
+
+type memcachedOptions struct {
+	Host    string
+	Service string
+	Timeout time.Duration
+}
+
+type redisOptions struct {
+	Endpoint string
+	Timeout  time.Duration
+}
+
+type ingestOptions struct {
+	Enabled            bool
+	Address            string
+	Topic              string
+	SASL               bool
+	TLS                storageTLSOptions
+	AssignedPartitions map[string][]int32
+}
+
+type storageTuningOptions struct {
+	BlocklistPoll             string
+	MaxBlockDuration          string
+	Block                     bool
+	BlockVersion              string
+	BloomFilterFalsePositive  string
+	BloomFilterShardSizeBytes *int
+	DedicatedColumns          []dedicatedColumnOptions
+	CompactionWindow          string
+	MaxBlockBytes             *int
+	WALEncoding               string
+}
+
+type dedicatedColumnOptions struct {
+	Scope string
+	Name  string
+	Type  string
 }
 
 type featureGates struct {
@@ -42,17 +111,25 @@ type featureGates struct {
 }
 
 type tenantOptions struct {
-	TenantOverrides map[string]tenantOverrides
+	TenantOverrides  map[string]tenantOverrides
+	DedicatedColumns []dedicatedColumnOptions
+	Features         version.TempoFeatures
 }
 
 type tenantOverrides struct {
-	IngestionBurstSizeBytes *int
-	IngestionRateLimitBytes *int
-	MaxBytesPerTrace        *int
-	MaxTracesPerUser        *int
-	MaxBytesPerTagValues    *int
-	MaxSearchDuration       string
-	BlockRetention          *time.Duration
+	IngestionBurstSizeBytes    *int
+	IngestionRateLimitBytes    *int
+	MaxBytesPerTrace           *int
+	MaxTracesPerUser           *int
+	MaxBytesPerTagValues       *int
+	MaxSearchDuration          string
+	MaxMetricsDuration         string
+	UnsafeQueryHints           *bool
+	MaxAttributeBytes          *int
+	Forwarders                 []string
+	MetricsGeneratorProcessors []string
+	CostAttributionDimensions  map[string]string
+	BlockRetention             *time.Duration
 }
 
 type searchOptions struct {
@@ -110,8 +187,9 @@ type tlsFilePaths struct {
 }
 
 type serverNames struct {
-	Compactor     string
-	Ingester      string
-	QueryFrontend string
-	Querier       string
+	Compactor        string
+	Ingester         string
+	QueryFrontend    string
+	Querier          string
+	MetricsGenerator string
 }
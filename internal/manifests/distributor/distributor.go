@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/autoscaling"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
@@ -45,11 +46,19 @@ func BuildDistributor(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
+	if err := manifestutils.ConfigureKafka(tempo, &dep.Spec.Template.Spec, "tempo"); err != nil {
+		return nil, err
+	}
+
 	manifestutils.SetGoMemLimit("tempo", &dep.Spec.Template.Spec)
 
 	distributorService := service(tempo)
 	objects := []client.Object{dep, distributorService}
 
+	if hpa := tempo.Spec.Template.Distributor.Autoscaling; autoscaling.Enabled(hpa) {
+		objects = append(objects, autoscaling.BuildHorizontalPodAutoscaler(tempo, manifestutils.DistributorComponentName, *hpa))
+	}
+
 	if tempo.Spec.Template.Distributor.TLS.Enabled {
 		if params.CtrlConfig.Gates.OpenShift.ServingCertsService && tempo.Spec.Template.Distributor.TLS.CA == "" &&
 			tempo.Spec.Template.Distributor.TLS.Cert == "" {
@@ -228,7 +237,7 @@ func deployment(params manifestutils.Params) *v1.Deployment {
 			Labels:    labels,
 		},
 		Spec: v1.DeploymentSpec{
-			Replicas: tempo.Spec.Template.Distributor.Replicas,
+			Replicas: autoscaling.Replicas(tempo.Spec.Template.Distributor.Replicas, tempo.Spec.Template.Distributor.Autoscaling),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -239,10 +248,11 @@ func deployment(params manifestutils.Params) *v1.Deployment {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
-					Affinity:           manifestutils.DefaultAffinity(labels),
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					Affinity:                  manifestutils.ComponentAffinity(cfg.TempoComponentSpec, labels),
+					TopologySpreadConstraints: cfg.TopologySpreadConstraints,
 					Containers: []corev1.Container{
 						{
 							Name:  "tempo",
@@ -262,6 +272,11 @@ func deployment(params manifestutils.Params) *v1.Deployment {
 									MountPath: "/conf",
 									ReadOnly:  true,
 								},
+								{
+									Name:      manifestutils.RuntimeOverridesVolumeName,
+									MountPath: manifestutils.RuntimeOverridesMountPath,
+									ReadOnly:  true,
+								},
 								{
 									Name:      manifestutils.TmpStorageVolumeName,
 									MountPath: manifestutils.TmpTempoStoragePath,
@@ -282,6 +297,16 @@ func deployment(params manifestutils.Params) *v1.Deployment {
 								},
 							},
 						},
+						{
+							Name: manifestutils.RuntimeOverridesVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name(manifestutils.RuntimeOverridesComponentName, tempo.Name),
+									},
+								},
+							},
+						},
 						{
 							Name: manifestutils.TmpStorageVolumeName,
 							VolumeSource: corev1.VolumeSource{
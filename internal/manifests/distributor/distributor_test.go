@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -158,6 +159,16 @@ func TestBuildDistributor(t *testing.T) {
 						},
 					},
 				},
+				{
+					Name: manifestutils.RuntimeOverridesVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "tempo-test-overrides",
+							},
+						},
+					},
+				},
 				{
 					Name: manifestutils.TmpStorageVolumeName,
 					VolumeSource: corev1.VolumeSource{
@@ -171,6 +182,11 @@ func TestBuildDistributor(t *testing.T) {
 					MountPath: "/conf",
 					ReadOnly:  true,
 				},
+				{
+					Name:      manifestutils.RuntimeOverridesVolumeName,
+					MountPath: manifestutils.RuntimeOverridesMountPath,
+					ReadOnly:  true,
+				},
 				{
 					Name:      manifestutils.TmpStorageVolumeName,
 					MountPath: manifestutils.TmpTempoStoragePath,
@@ -310,6 +326,16 @@ func TestBuildDistributor(t *testing.T) {
 						},
 					},
 				},
+				{
+					Name: manifestutils.RuntimeOverridesVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "tempo-test-overrides",
+							},
+						},
+					},
+				},
 				{
 					Name: manifestutils.TmpStorageVolumeName,
 					VolumeSource: corev1.VolumeSource{
@@ -341,6 +367,11 @@ func TestBuildDistributor(t *testing.T) {
 					MountPath: "/conf",
 					ReadOnly:  true,
 				},
+				{
+					Name:      manifestutils.RuntimeOverridesVolumeName,
+					MountPath: manifestutils.RuntimeOverridesMountPath,
+					ReadOnly:  true,
+				},
 				{
 					Name:      manifestutils.TmpStorageVolumeName,
 					MountPath: manifestutils.TmpTempoStoragePath,
@@ -491,6 +522,16 @@ func TestBuildDistributor(t *testing.T) {
 						},
 					},
 				},
+				{
+					Name: manifestutils.RuntimeOverridesVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "tempo-test-overrides",
+							},
+						},
+					},
+				},
 				{
 					Name: manifestutils.TmpStorageVolumeName,
 					VolumeSource: corev1.VolumeSource{
@@ -522,6 +563,11 @@ func TestBuildDistributor(t *testing.T) {
 					MountPath: "/conf",
 					ReadOnly:  true,
 				},
+				{
+					Name:      manifestutils.RuntimeOverridesVolumeName,
+					MountPath: manifestutils.RuntimeOverridesMountPath,
+					ReadOnly:  true,
+				},
 				{
 					Name:      manifestutils.TmpStorageVolumeName,
 					MountPath: manifestutils.TmpTempoStoragePath,
@@ -674,6 +720,16 @@ func TestBuildDistributor(t *testing.T) {
 						},
 					},
 				},
+				{
+					Name: manifestutils.RuntimeOverridesVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "tempo-test-overrides",
+							},
+						},
+					},
+				},
 				{
 					Name: manifestutils.TmpStorageVolumeName,
 					VolumeSource: corev1.VolumeSource{
@@ -705,6 +761,11 @@ func TestBuildDistributor(t *testing.T) {
 					MountPath: "/conf",
 					ReadOnly:  true,
 				},
+				{
+					Name:      manifestutils.RuntimeOverridesVolumeName,
+					MountPath: manifestutils.RuntimeOverridesMountPath,
+					ReadOnly:  true,
+				},
 				{
 					Name:      manifestutils.TmpStorageVolumeName,
 					MountPath: manifestutils.TmpTempoStoragePath,
@@ -794,6 +855,16 @@ func TestBuildDistributor(t *testing.T) {
 						},
 					},
 				},
+				{
+					Name: manifestutils.RuntimeOverridesVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "tempo-test-overrides",
+							},
+						},
+					},
+				},
 				{
 					Name: manifestutils.TmpStorageVolumeName,
 					VolumeSource: corev1.VolumeSource{
@@ -807,6 +878,11 @@ func TestBuildDistributor(t *testing.T) {
 					MountPath: "/conf",
 					ReadOnly:  true,
 				},
+				{
+					Name:      manifestutils.RuntimeOverridesVolumeName,
+					MountPath: manifestutils.RuntimeOverridesMountPath,
+					ReadOnly:  true,
+				},
 				{
 					Name:      manifestutils.TmpStorageVolumeName,
 					MountPath: manifestutils.TmpTempoStoragePath,
@@ -941,6 +1017,16 @@ func TestBuildDistributor(t *testing.T) {
 						},
 					},
 				},
+				{
+					Name: manifestutils.RuntimeOverridesVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "tempo-test-overrides",
+							},
+						},
+					},
+				},
 				{
 					Name: manifestutils.TmpStorageVolumeName,
 					VolumeSource: corev1.VolumeSource{
@@ -954,6 +1040,11 @@ func TestBuildDistributor(t *testing.T) {
 					MountPath: "/conf",
 					ReadOnly:  true,
 				},
+				{
+					Name:      manifestutils.RuntimeOverridesVolumeName,
+					MountPath: manifestutils.RuntimeOverridesMountPath,
+					ReadOnly:  true,
+				},
 				{
 					Name:      manifestutils.TmpStorageVolumeName,
 					MountPath: manifestutils.TmpTempoStoragePath,
@@ -1182,3 +1273,37 @@ func TestOverrideResources(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, dep.Spec.Template.Spec.Containers[0].Resources, overrideResources)
 }
+
+func TestBuildDistributorAutoscaling(t *testing.T) {
+	objects, err := BuildDistributor(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Distributor: v1alpha1.TempoDistributorSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas: ptr.To(int32(1)),
+					},
+					Autoscaling: &v1alpha1.AutoscalingSpec{
+						Enabled:                 true,
+						MaxReplicas:             10,
+						TargetMemoryUtilization: ptr.To(int32(80)),
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, objects, 3)
+
+	dep, ok := objects[0].(*v1.Deployment)
+	require.True(t, ok)
+	assert.Nil(t, dep.Spec.Replicas)
+
+	hpa, ok := objects[2].(*autoscalingv2.HorizontalPodAutoscaler)
+	require.True(t, ok)
+	assert.Equal(t, "tempo-test-distributor", hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+}
@@ -3,6 +3,7 @@ package gateway
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/url"
 	"path"
@@ -18,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/gatewayapi"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
 )
@@ -117,6 +119,12 @@ func BuildGateway(params manifestutils.Params) ([]client.Object, error) {
 			return nil, err
 		}
 		objs = append(objs, routeObj)
+	} else if params.Tempo.Spec.Template.Gateway.Ingress.Type == v1alpha1.IngressTypeGatewayAPI {
+		routes, err := gatewayAPIRoutes(params.Tempo)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, routes...)
 	}
 
 	dep.Spec.Template, err = patchTraceReadEndpoint(params, dep.Spec.Template)
@@ -240,9 +248,11 @@ func deployment(params manifestutils.Params, rbacCfgHash string, tenantsCfgHash
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					Affinity:                  cfg.Affinity,
+					TopologySpreadConstraints: cfg.TopologySpreadConstraints,
 					Containers: []corev1.Container{
 						{
 							Name:  containerNameTempoGateway,
@@ -506,6 +516,34 @@ func NewTenantsSecret(cfgOpts options, namespace string, name string, labels lab
 	return secret, checksum, nil
 }
 
+// gatewayAPIRoutes creates a HTTPRoute for the gateway HTTP endpoint and a GRPCRoute for the OTLP gRPC ingestion.
+// The GRPCRoute uses its own hostname and listener, because it must not share a hostname with the HTTPRoute on the same listener.
+func gatewayAPIRoutes(tempo v1alpha1.TempoStack) ([]client.Object, error) {
+	ingressSpec := tempo.Spec.Template.Gateway.Ingress
+	if ingressSpec.GatewayAPI == nil {
+		return nil, errors.New("the gatewayAPI configuration is required for the ingress type gateway-api")
+	}
+
+	opts := gatewayapi.RouteOptions{
+		Name:        naming.Name(manifestutils.GatewayComponentName, tempo.Name),
+		Namespace:   tempo.Namespace,
+		Labels:      manifestutils.ComponentLabels(manifestutils.GatewayComponentName, tempo.Name),
+		Annotations: ingressSpec.Annotations,
+		Host:        ingressSpec.Host,
+		ParentRef:   ingressSpec.GatewayAPI.ParentRef,
+		ServiceName: naming.Name(manifestutils.GatewayComponentName, tempo.Name),
+		ServicePort: manifestutils.GatewayPortHTTPServer,
+	}
+	httpRoute := gatewayapi.HTTPRoute(opts)
+
+	opts.Host = ingressSpec.GatewayAPI.GRPCHost
+	opts.ParentRef = ingressSpec.GatewayAPI.GRPCParentRef()
+	opts.ServicePort = manifestutils.GatewayPortGRPCServer
+	grpcRoute := gatewayapi.GRPCRoute(opts)
+
+	return []client.Object{httpRoute, grpcRoute}, nil
+}
+
 func ingress(tempo v1alpha1.TempoStack) *networkingv1.Ingress {
 	ingressName := naming.Name(manifestutils.GatewayComponentName, tempo.Name)
 	labels := manifestutils.ComponentLabels(manifestutils.GatewayComponentName, tempo.Name)
@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
@@ -913,6 +914,89 @@ func TestRoute(t *testing.T) {
 	}, objects[3].(*routev1.Route))
 }
 
+func TestGatewayAPIRoutes(t *testing.T) {
+	objects, err := BuildGateway(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					Enabled: true,
+					Ingress: v1alpha1.IngressSpec{
+						Type: v1alpha1.IngressTypeGatewayAPI,
+						Host: "tempo.example.com",
+						GatewayAPI: &v1alpha1.GatewayAPISpec{
+							ParentRef: v1alpha1.GatewayParentReference{
+								Name:        "public",
+								Namespace:   "gateways",
+								SectionName: "https",
+							},
+							GRPCHost:        "otlp.tempo.example.com",
+							GRPCSectionName: "grpc",
+						},
+					},
+				},
+			},
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: "static",
+				Authorization: &v1alpha1.AuthorizationSpec{
+					RoleBindings: []v1alpha1.RoleBindingsSpec{
+						{
+							Name:  "test",
+							Roles: []string{"read-write"},
+							Subjects: []v1alpha1.Subject{
+								{
+									Name: "admin@example.com",
+									Kind: v1alpha1.User,
+								},
+							},
+						},
+					},
+					Roles: []v1alpha1.RoleSpec{{
+						Name: "read-write",
+						Resources: []string{
+							"logs", "metrics", "traces",
+						},
+						Tenants: []string{
+							"test-oidc",
+						},
+						Permissions: []v1alpha1.PermissionType{v1alpha1.Write, v1alpha1.Read},
+					},
+					},
+				},
+			},
+		},
+	}})
+
+	require.NoError(t, err)
+	require.Equal(t, 6, len(objects))
+
+	parentRefs := []gatewayv1.ParentReference{{
+		Name:        "public",
+		Namespace:   ptr.To(gatewayv1.Namespace("gateways")),
+		SectionName: ptr.To(gatewayv1.SectionName("https")),
+	}}
+	httpRoute := objects[3].(*gatewayv1.HTTPRoute)
+	assert.Equal(t, naming.Name(manifestutils.GatewayComponentName, "test"), httpRoute.Name)
+	assert.Equal(t, parentRefs, httpRoute.Spec.ParentRefs)
+	assert.Equal(t, []gatewayv1.Hostname{"tempo.example.com"}, httpRoute.Spec.Hostnames)
+	assert.Equal(t, gatewayv1.ObjectName("tempo-test-gateway"), httpRoute.Spec.Rules[0].BackendRefs[0].Name)
+	assert.Equal(t, ptr.To(gatewayv1.PortNumber(8080)), httpRoute.Spec.Rules[0].BackendRefs[0].Port)
+
+	grpcRoute := objects[4].(*gatewayv1.GRPCRoute)
+	assert.Equal(t, naming.Name(manifestutils.GatewayComponentName, "test"), grpcRoute.Name)
+	assert.Equal(t, []gatewayv1.ParentReference{{
+		Name:        "public",
+		Namespace:   ptr.To(gatewayv1.Namespace("gateways")),
+		SectionName: ptr.To(gatewayv1.SectionName("grpc")),
+	}}, grpcRoute.Spec.ParentRefs)
+	assert.Equal(t, []gatewayv1.Hostname{"otlp.tempo.example.com"}, grpcRoute.Spec.Hostnames)
+	assert.Equal(t, gatewayv1.ObjectName("tempo-test-gateway"), grpcRoute.Spec.Rules[0].BackendRefs[0].Name)
+	assert.Equal(t, ptr.To(gatewayv1.PortNumber(8090)), grpcRoute.Spec.Rules[0].BackendRefs[0].Port)
+}
+
 func TestOverrideResources(t *testing.T) {
 	overrideResources := corev1.ResourceRequirements{
 		Limits: corev1.ResourceList{
@@ -0,0 +1,108 @@
+package gatewayapi
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+)
+
+// RouteOptions defines the options of a Gateway API route.
+type RouteOptions struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+	Host        string
+	ParentRef   v1alpha1.GatewayParentReference
+	ServiceName string
+	ServicePort int32
+}
+
+// HTTPRoute creates a HTTPRoute which routes all requests to the service.
+func HTTPRoute(opts RouteOptions) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1.GroupVersion.String(),
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: objectMeta(opts),
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: commonRouteSpec(opts),
+			Hostnames:       hostnames(opts),
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{{
+					Path: &gatewayv1.HTTPPathMatch{
+						Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+						Value: ptr.To("/"),
+					},
+				}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					BackendRef: backendRef(opts),
+				}},
+			}},
+		},
+	}
+}
+
+// GRPCRoute creates a GRPCRoute which routes all requests to the service.
+func GRPCRoute(opts RouteOptions) *gatewayv1.GRPCRoute {
+	return &gatewayv1.GRPCRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1.GroupVersion.String(),
+			Kind:       "GRPCRoute",
+		},
+		ObjectMeta: objectMeta(opts),
+		Spec: gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: commonRouteSpec(opts),
+			Hostnames:       hostnames(opts),
+			Rules: []gatewayv1.GRPCRouteRule{{
+				BackendRefs: []gatewayv1.GRPCBackendRef{{
+					BackendRef: backendRef(opts),
+				}},
+			}},
+		},
+	}
+}
+
+func objectMeta(opts RouteOptions) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        opts.Name,
+		Namespace:   opts.Namespace,
+		Labels:      opts.Labels,
+		Annotations: opts.Annotations,
+	}
+}
+
+func commonRouteSpec(opts RouteOptions) gatewayv1.CommonRouteSpec {
+	parentRef := gatewayv1.ParentReference{
+		Name: gatewayv1.ObjectName(opts.ParentRef.Name),
+	}
+	if opts.ParentRef.Namespace != "" {
+		parentRef.Namespace = ptr.To(gatewayv1.Namespace(opts.ParentRef.Namespace))
+	}
+	if opts.ParentRef.SectionName != "" {
+		parentRef.SectionName = ptr.To(gatewayv1.SectionName(opts.ParentRef.SectionName))
+	}
+
+	return gatewayv1.CommonRouteSpec{
+		ParentRefs: []gatewayv1.ParentReference{parentRef},
+	}
+}
+
+func hostnames(opts RouteOptions) []gatewayv1.Hostname {
+	if opts.Host == "" {
+		return nil
+	}
+	return []gatewayv1.Hostname{gatewayv1.Hostname(opts.Host)}
+}
+
+func backendRef(opts RouteOptions) gatewayv1.BackendRef {
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(opts.ServiceName),
+			Port: ptr.To(gatewayv1.PortNumber(opts.ServicePort)),
+		},
+	}
+}
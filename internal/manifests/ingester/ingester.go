@@ -19,34 +19,59 @@ const (
 	dataVolumeName = "data"
 )
 
-// BuildIngester creates distributor objects.
+// BuildIngester creates ingester objects.
+// If ingester zones are configured, one StatefulSet is created per zone.
 func BuildIngester(params manifestutils.Params) ([]client.Object, error) {
-	ss, err := statefulSet(params)
-
-	if err != nil {
-		return nil, err
-	}
+	tempo := params.Tempo
+	zones := manifestutils.IngesterZones(tempo)
 
-	if err := memberlist.ConfigureHashRingEnv(&ss.Spec.Template.Spec, params.Tempo); err != nil {
-		return nil, err
+	var statefulSets []*v1.StatefulSet
+	if len(zones) == 0 {
+		ss, err := statefulSet(params)
+		if err != nil {
+			return nil, err
+		}
+		statefulSets = append(statefulSets, ss)
+	} else {
+		for _, zone := range zones {
+			ss, err := zoneStatefulSet(params, zone.Name)
+			if err != nil {
+				return nil, err
+			}
+			statefulSets = append(statefulSets, ss)
+		}
 	}
 
 	gates := params.CtrlConfig.Gates
-	tempo := params.Tempo
-
-	if gates.HTTPEncryption || gates.GRPCEncryption {
-		caBundleName := naming.SigningCABundleName(tempo.Name)
-		if err := manifestutils.ConfigureServiceCA(&ss.Spec.Template.Spec, caBundleName); err != nil {
+	objects := make([]client.Object, 0, len(statefulSets)+1)
+	for _, ss := range statefulSets {
+		if err := memberlist.ConfigureHashRingEnv(&ss.Spec.Template.Spec, tempo); err != nil {
 			return nil, err
 		}
 
-		err := manifestutils.ConfigureServicePKI(tempo.Name, manifestutils.IngesterComponentName, &ss.Spec.Template.Spec)
-		if err != nil {
-			return nil, err
+		if gates.HTTPEncryption || gates.GRPCEncryption {
+			caBundleName := naming.SigningCABundleName(tempo.Name)
+			if err := manifestutils.ConfigureServiceCA(&ss.Spec.Template.Spec, caBundleName); err != nil {
+				return nil, err
+			}
+
+			err := manifestutils.ConfigureServicePKI(tempo.Name, manifestutils.IngesterComponentName, &ss.Spec.Template.Spec)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(zones) > 0 {
+			hash, err := templateHash(ss.Spec.Template)
+			if err != nil {
+				return nil, err
+			}
+			ss.Annotations = map[string]string{manifestutils.ZoneTemplateHashAnnotation: hash}
 		}
+		objects = append(objects, ss)
 	}
 
-	return []client.Object{ss, service(tempo)}, nil
+	return append(objects, service(tempo)), nil
 }
 
 func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
@@ -87,10 +112,11 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
-					Affinity:           manifestutils.DefaultAffinity(labels),
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					Affinity:                  manifestutils.ComponentAffinity(cfg, labels),
+					TopologySpreadConstraints: cfg.TopologySpreadConstraints,
 					Containers: []corev1.Container{
 						{
 							Name:  "tempo",
@@ -108,6 +134,11 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 									MountPath: "/conf",
 									ReadOnly:  true,
 								},
+								{
+									Name:      manifestutils.RuntimeOverridesVolumeName,
+									MountPath: manifestutils.RuntimeOverridesMountPath,
+									ReadOnly:  true,
+								},
 								{
 									Name:      dataVolumeName,
 									MountPath: "/var/tempo",
@@ -146,6 +177,16 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 								},
 							},
 						},
+						{
+							Name: manifestutils.RuntimeOverridesVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name(manifestutils.RuntimeOverridesComponentName, tempo.Name),
+									},
+								},
+							},
+						},
 					},
 					SecurityContext: tempo.Spec.Template.Ingester.PodSecurityContext,
 				},
@@ -175,11 +216,20 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 		return nil, err
 	}
 
+	err = manifestutils.ConfigureKafka(tempo, &ss.Spec.Template.Spec, "tempo")
+	if err != nil {
+		return nil, err
+	}
+
 	ss.Spec.Template, err = manifestutils.PatchTracingEnvConfiguration(tempo, ss.Spec.Template)
 	if err != nil {
 		return nil, err
 	}
 
+	if manifestutils.ZoneAwarenessEnabled(tempo) && len(manifestutils.IngesterZones(tempo)) == 0 {
+		manifestutils.ConfigureZoneAwareness(tempo, image, labels, &ss.Spec.Template)
+	}
+
 	manifestutils.SetGoMemLimit("tempo", &ss.Spec.Template.Spec)
 	return ss, nil
 }
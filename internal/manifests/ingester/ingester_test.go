@@ -199,6 +199,11 @@ func TestBuildIngester(t *testing.T) {
 											MountPath: "/conf",
 											ReadOnly:  true,
 										},
+										{
+											Name:      manifestutils.RuntimeOverridesVolumeName,
+											MountPath: manifestutils.RuntimeOverridesMountPath,
+											ReadOnly:  true,
+										},
 										{
 											Name:      dataVolumeName,
 											MountPath: "/var/tempo",
@@ -256,6 +261,16 @@ func TestBuildIngester(t *testing.T) {
 										},
 									},
 								},
+								{
+									Name: manifestutils.RuntimeOverridesVolumeName,
+									VolumeSource: corev1.VolumeSource{
+										ConfigMap: &corev1.ConfigMapVolumeSource{
+											LocalObjectReference: corev1.LocalObjectReference{
+												Name: "tempo-test-overrides",
+											},
+										},
+									},
+								},
 							},
 						},
 					},
@@ -358,3 +373,59 @@ func TestOverrideResources(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, ss.Spec.Template.Spec.Containers[0].Resources, overrideResources)
 }
+
+func TestBuildIngester_ZoneAwareness(t *testing.T) {
+	objects, err := BuildIngester(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Images: configv1alpha1.ImagesSpec{
+				Tempo: "docker.io/grafana/tempo:1.5.0",
+			},
+			ServiceAccount: "tempo-test-serviceaccount",
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					CredentialMode: v1alpha1.CredentialModeStatic,
+					Name:           "test-storage-secret",
+					Type:           "s3",
+				},
+			},
+			StorageSize:       resource.MustParse("10Gi"),
+			ReplicationFactor: 3,
+			ZoneAwareness: &v1alpha1.ZoneAwarenessSpec{
+				Enabled:     true,
+				TopologyKey: "example.com/zone",
+				MaxSkew:     2,
+			},
+		},
+	}})
+	require.NoError(t, err)
+	ss, ok := objects[0].(*v1.StatefulSet)
+	require.True(t, ok)
+
+	template := ss.Spec.Template
+	assert.Equal(t, "true", template.Labels[manifestutils.ZoneAwarePodLabel])
+	assert.Equal(t, "example.com/zone", template.Annotations[manifestutils.ZoneTopologyKeyAnnotation])
+	assert.Equal(t, []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           2,
+			TopologyKey:       "example.com/zone",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: manifestutils.ComponentLabels(manifestutils.IngesterComponentName, "test"),
+			},
+		},
+	}, template.Spec.TopologySpreadConstraints)
+	require.Len(t, template.Spec.InitContainers, 1)
+	assert.Equal(t, "docker.io/grafana/tempo:1.5.0", template.Spec.InitContainers[0].Image)
+	assert.Contains(t, template.Spec.Containers[0].Env, corev1.EnvVar{
+		Name: manifestutils.AvailabilityZoneEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.annotations['tempo.grafana.com/availability-zone']",
+			},
+		},
+	})
+}
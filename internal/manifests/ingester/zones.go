@@ -0,0 +1,161 @@
+package ingester
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// ZoneStatefulSetName returns the name of the ingester StatefulSet of an availability zone.
+func ZoneStatefulSetName(tempoStackName string, zone string) string {
+	return naming.Name(fmt.Sprintf("%s-%s", manifestutils.IngesterComponentName, zone), tempoStackName)
+}
+
+// zoneStatefulSet creates the ingester StatefulSet of an availability zone.
+// The pods are scheduled on the nodes of the zone, and the zone is passed to the
+// availability_zone setting of the lifecycler through the TEMPO_AVAILABILITY_ZONE environment variable.
+func zoneStatefulSet(params manifestutils.Params, zone string) (*v1.StatefulSet, error) {
+	ss, err := statefulSet(params)
+	if err != nil {
+		return nil, err
+	}
+
+	tempo := params.Tempo
+	zoneLabels := k8slabels.Merge(ss.Labels, k8slabels.Set{manifestutils.ZoneLabel: zone})
+
+	ss.Name = ZoneStatefulSetName(tempo.Name, zone)
+	ss.Labels = zoneLabels
+	ss.Spec.Selector.MatchLabels = zoneLabels
+	ss.Spec.Template.Labels = k8slabels.Merge(ss.Spec.Template.Labels, zoneLabels)
+	ss.Spec.Template.Spec.NodeSelector = k8slabels.Merge(ss.Spec.Template.Spec.NodeSelector, k8slabels.Set{
+		manifestutils.ZoneTopologyKey(tempo): zone,
+	})
+
+	for i := range ss.Spec.Template.Spec.Containers {
+		ss.Spec.Template.Spec.Containers[i].Env = append(ss.Spec.Template.Spec.Containers[i].Env, corev1.EnvVar{
+			Name:  manifestutils.AvailabilityZoneEnvVar,
+			Value: zone,
+		})
+	}
+
+	return ss, nil
+}
+
+func templateHash(template corev1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// DeferZoneUpdates updates the ingester zones one at a time.
+//
+// The pod template of a zone StatefulSet is only updated once all previous zones are fully rolled out,
+// i.e. all pods of the previous zones run the latest revision and are ready.
+// The readiness probe of an ingester only succeeds once the ingester is ACTIVE in a healthy ring,
+// therefore a zone is only updated while the ring is healthy.
+// Until then, the pod template of the existing StatefulSet is kept in the desired object.
+//
+// The existing StatefulSets are indexed by name. DeferZoneUpdates returns the names of the deferred zones.
+func DeferZoneUpdates(desired []client.Object, existing map[string]*v1.StatefulSet) []string {
+	var deferred []string
+	rolling := false
+
+	for _, obj := range desired {
+		ss, ok := obj.(*v1.StatefulSet)
+		if !ok {
+			continue
+		}
+		zone, ok := ss.Labels[manifestutils.ZoneLabel]
+		if !ok {
+			continue
+		}
+
+		current, ok := existing[ss.Name]
+		if !ok {
+			// a new zone does not affect the availability of the other zones
+			continue
+		}
+
+		currentHash := current.Annotations[manifestutils.ZoneTemplateHashAnnotation]
+		changed := currentHash != ss.Annotations[manifestutils.ZoneTemplateHashAnnotation]
+		if changed && rolling {
+			ss.Spec.Template = *current.Spec.Template.DeepCopy()
+			ss.Annotations[manifestutils.ZoneTemplateHashAnnotation] = currentHash
+			deferred = append(deferred, zone)
+			continue
+		}
+
+		if changed || !zoneRolledOut(current) {
+			rolling = true
+		}
+	}
+
+	return deferred
+}
+
+func zoneRolledOut(ss *v1.StatefulSet) bool {
+	replicas := ptr.Deref(ss.Spec.Replicas, 1)
+	return ss.Status.ObservedGeneration >= ss.Generation &&
+		ss.Status.CurrentRevision == ss.Status.UpdateRevision &&
+		ss.Status.UpdatedReplicas == replicas &&
+		ss.Status.ReadyReplicas == replicas
+}
+
+// RetainIngesterWithoutZones keeps the ingester StatefulSet without zones while switching a TempoStack to ingester zones,
+// i.e. the existing ingesters keep serving the ring until the ingesters of all zones are available.
+//
+// The StatefulSet is kept unchanged until all zone StatefulSets are rolled out, then it is scaled down.
+// Once all of its pods are terminated, it is not retained anymore and gets pruned.
+// The existing StatefulSets are indexed by name. RetainIngesterWithoutZones returns the desired objects
+// including the retained StatefulSet.
+func RetainIngesterWithoutZones(desired []client.Object, existing map[string]*v1.StatefulSet, tempoStackName string) []client.Object {
+	current, ok := existing[naming.Name(manifestutils.IngesterComponentName, tempoStackName)]
+	if !ok {
+		return desired
+	}
+
+	zonesRolledOut := true
+	for _, obj := range desired {
+		ss, ok := obj.(*v1.StatefulSet)
+		if !ok {
+			continue
+		}
+		if _, ok := ss.Labels[manifestutils.ZoneLabel]; !ok {
+			continue
+		}
+		if zone, ok := existing[ss.Name]; !ok || !zoneRolledOut(zone) {
+			zonesRolledOut = false
+		}
+	}
+
+	if zonesRolledOut && ptr.Deref(current.Spec.Replicas, 1) == 0 && current.Status.Replicas == 0 {
+		return desired
+	}
+
+	retained := &v1.StatefulSet{
+		TypeMeta: current.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        current.Name,
+			Namespace:   current.Namespace,
+			Labels:      current.Labels,
+			Annotations: current.Annotations,
+		},
+		Spec: *current.Spec.DeepCopy(),
+	}
+	if zonesRolledOut {
+		retained.Spec.Replicas = ptr.To(int32(0))
+	}
+	return append(desired, retained)
+}
@@ -0,0 +1,255 @@
+package ingester
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func zonedTempo() v1alpha1.TempoStack {
+	return v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Images: configv1alpha1.ImagesSpec{
+				Tempo: "docker.io/grafana/tempo:1.5.0",
+			},
+			ServiceAccount: "tempo-test-serviceaccount",
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					CredentialMode: v1alpha1.CredentialModeStatic,
+					Name:           "test-storage-secret",
+					Type:           "s3",
+				},
+			},
+			StorageSize:       resource.MustParse("10Gi"),
+			ReplicationFactor: 3,
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoComponentSpec{
+					Replicas:     ptr.To(int32(2)),
+					NodeSelector: map[string]string{"a": "b"},
+				},
+			},
+			ZoneAwareness: &v1alpha1.ZoneAwarenessSpec{
+				Enabled: true,
+				Zones:   []v1alpha1.ZoneSpec{{Name: "zone-a"}, {Name: "zone-b"}, {Name: "zone-c"}},
+			},
+		},
+	}
+}
+
+func TestBuildIngester_Zones(t *testing.T) {
+	objects, err := BuildIngester(manifestutils.Params{Tempo: zonedTempo()})
+	require.NoError(t, err)
+	require.Len(t, objects, 4)
+
+	for i, zone := range []string{"zone-a", "zone-b", "zone-c"} {
+		ss, ok := objects[i].(*v1.StatefulSet)
+		require.True(t, ok)
+
+		assert.Equal(t, "tempo-test-ingester-"+zone, ss.Name)
+		assert.Equal(t, ptr.To(int32(2)), ss.Spec.Replicas)
+		assert.Equal(t, zone, ss.Spec.Selector.MatchLabels[manifestutils.ZoneLabel])
+		assert.Equal(t, zone, ss.Spec.Template.Labels[manifestutils.ZoneLabel])
+		assert.Equal(t, map[string]string{
+			"a":                           "b",
+			"topology.kubernetes.io/zone": zone,
+		}, ss.Spec.Template.Spec.NodeSelector)
+		assert.Contains(t, ss.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  manifestutils.AvailabilityZoneEnvVar,
+			Value: zone,
+		})
+		assert.Empty(t, ss.Spec.Template.Spec.InitContainers)
+		assert.NotEmpty(t, ss.Annotations[manifestutils.ZoneTemplateHashAnnotation])
+	}
+
+	svc, ok := objects[3].(*corev1.Service)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string(manifestutils.ComponentLabels(manifestutils.IngesterComponentName, "test")), svc.Spec.Selector)
+}
+
+func rolledOut(ss *v1.StatefulSet) *v1.StatefulSet {
+	ss = ss.DeepCopy()
+	ss.Status = v1.StatefulSetStatus{
+		Replicas:        2,
+		ReadyReplicas:   2,
+		UpdatedReplicas: 2,
+		CurrentRevision: "rev-1",
+		UpdateRevision:  "rev-1",
+	}
+	return ss
+}
+
+func TestDeferZoneUpdates(t *testing.T) {
+	previous, err := BuildIngester(manifestutils.Params{Tempo: zonedTempo()})
+	require.NoError(t, err)
+
+	updatedTempo := zonedTempo()
+	updatedTempo.Spec.Images.Tempo = "docker.io/grafana/tempo:1.6.0"
+	updated, err := BuildIngester(manifestutils.Params{Tempo: updatedTempo})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		existing map[string]*v1.StatefulSet
+		deferred []string
+		images   []string
+	}{
+		{
+			name:     "new zones",
+			existing: map[string]*v1.StatefulSet{},
+			images:   []string{"docker.io/grafana/tempo:1.6.0", "docker.io/grafana/tempo:1.6.0", "docker.io/grafana/tempo:1.6.0"},
+		},
+		{
+			name: "update first zone",
+			existing: map[string]*v1.StatefulSet{
+				"tempo-test-ingester-zone-a": rolledOut(previous[0].(*v1.StatefulSet)),
+				"tempo-test-ingester-zone-b": rolledOut(previous[1].(*v1.StatefulSet)),
+				"tempo-test-ingester-zone-c": rolledOut(previous[2].(*v1.StatefulSet)),
+			},
+			deferred: []string{"zone-b", "zone-c"},
+			images:   []string{"docker.io/grafana/tempo:1.6.0", "docker.io/grafana/tempo:1.5.0", "docker.io/grafana/tempo:1.5.0"},
+		},
+		{
+			name: "first zone not ready",
+			existing: map[string]*v1.StatefulSet{
+				"tempo-test-ingester-zone-a": func() *v1.StatefulSet {
+					ss := rolledOut(updated[0].(*v1.StatefulSet))
+					ss.Status.ReadyReplicas = 1
+					return ss
+				}(),
+				"tempo-test-ingester-zone-b": rolledOut(previous[1].(*v1.StatefulSet)),
+				"tempo-test-ingester-zone-c": rolledOut(previous[2].(*v1.StatefulSet)),
+			},
+			deferred: []string{"zone-b", "zone-c"},
+			images:   []string{"docker.io/grafana/tempo:1.6.0", "docker.io/grafana/tempo:1.5.0", "docker.io/grafana/tempo:1.5.0"},
+		},
+		{
+			name: "update second zone",
+			existing: map[string]*v1.StatefulSet{
+				"tempo-test-ingester-zone-a": rolledOut(updated[0].(*v1.StatefulSet)),
+				"tempo-test-ingester-zone-b": rolledOut(previous[1].(*v1.StatefulSet)),
+				"tempo-test-ingester-zone-c": rolledOut(previous[2].(*v1.StatefulSet)),
+			},
+			deferred: []string{"zone-c"},
+			images:   []string{"docker.io/grafana/tempo:1.6.0", "docker.io/grafana/tempo:1.6.0", "docker.io/grafana/tempo:1.5.0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			desired, err := BuildIngester(manifestutils.Params{Tempo: updatedTempo})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.deferred, DeferZoneUpdates(desired, test.existing))
+			assert.Equal(t, test.images, zoneImages(desired))
+		})
+	}
+}
+
+func zoneImages(objects []client.Object) []string {
+	var images []string
+	for _, obj := range objects {
+		if ss, ok := obj.(*v1.StatefulSet); ok {
+			images = append(images, ss.Spec.Template.Spec.Containers[0].Image)
+		}
+	}
+	return images
+}
+
+func TestRetainIngesterWithoutZones(t *testing.T) {
+	unzonedTempo := zonedTempo()
+	unzonedTempo.Spec.ZoneAwareness = nil
+	unzoned, err := BuildIngester(manifestutils.Params{Tempo: unzonedTempo})
+	require.NoError(t, err)
+	zoned, err := BuildIngester(manifestutils.Params{Tempo: zonedTempo()})
+	require.NoError(t, err)
+
+	zones := map[string]*v1.StatefulSet{
+		"tempo-test-ingester-zone-a": rolledOut(zoned[0].(*v1.StatefulSet)),
+		"tempo-test-ingester-zone-b": rolledOut(zoned[1].(*v1.StatefulSet)),
+		"tempo-test-ingester-zone-c": rolledOut(zoned[2].(*v1.StatefulSet)),
+	}
+	withIngester := func(existing map[string]*v1.StatefulSet, ingester *v1.StatefulSet) map[string]*v1.StatefulSet {
+		merged := map[string]*v1.StatefulSet{"tempo-test-ingester": ingester}
+		for name, ss := range existing {
+			merged[name] = ss
+		}
+		return merged
+	}
+
+	tests := []struct {
+		name     string
+		existing map[string]*v1.StatefulSet
+		replicas *int32
+	}{
+		{
+			name:     "no ingester without zones",
+			existing: zones,
+		},
+		{
+			name:     "zones not created",
+			existing: withIngester(nil, rolledOut(unzoned[0].(*v1.StatefulSet))),
+			replicas: ptr.To(int32(2)),
+		},
+		{
+			name: "zone not ready",
+			existing: withIngester(map[string]*v1.StatefulSet{
+				"tempo-test-ingester-zone-a": zones["tempo-test-ingester-zone-a"],
+				"tempo-test-ingester-zone-b": zones["tempo-test-ingester-zone-b"],
+				"tempo-test-ingester-zone-c": func() *v1.StatefulSet {
+					ss := zones["tempo-test-ingester-zone-c"].DeepCopy()
+					ss.Status.ReadyReplicas = 1
+					return ss
+				}(),
+			}, rolledOut(unzoned[0].(*v1.StatefulSet))),
+			replicas: ptr.To(int32(2)),
+		},
+		{
+			name:     "all zones ready",
+			existing: withIngester(zones, rolledOut(unzoned[0].(*v1.StatefulSet))),
+			replicas: ptr.To(int32(0)),
+		},
+		{
+			name: "scaled down",
+			existing: withIngester(zones, func() *v1.StatefulSet {
+				ss := unzoned[0].(*v1.StatefulSet).DeepCopy()
+				ss.Spec.Replicas = ptr.To(int32(0))
+				return ss
+			}()),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			desired, err := BuildIngester(manifestutils.Params{Tempo: zonedTempo()})
+			require.NoError(t, err)
+
+			objects := RetainIngesterWithoutZones(desired, test.existing, "test")
+			if test.replicas == nil {
+				assert.Len(t, objects, 4)
+				return
+			}
+
+			require.Len(t, objects, 5)
+			retained, ok := objects[4].(*v1.StatefulSet)
+			require.True(t, ok)
+			assert.Equal(t, "tempo-test-ingester", retained.Name)
+			assert.Equal(t, test.replicas, retained.Spec.Replicas)
+			assert.Equal(t, unzoned[0].(*v1.StatefulSet).Spec.Template, retained.Spec.Template)
+		})
+	}
+}
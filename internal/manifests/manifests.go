@@ -1,9 +1,12 @@
 package manifests
 
 import (
+	"fmt"
+
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/tempo-operator/internal/manifests/alerts"
+	"github.com/grafana/tempo-operator/internal/manifests/blockbuilder"
 	"github.com/grafana/tempo-operator/internal/manifests/compactor"
 	"github.com/grafana/tempo-operator/internal/manifests/config"
 	"github.com/grafana/tempo-operator/internal/manifests/distributor"
@@ -12,7 +15,11 @@ import (
 	"github.com/grafana/tempo-operator/internal/manifests/ingester"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
+	"github.com/grafana/tempo-operator/internal/manifests/memcached"
+	"github.com/grafana/tempo-operator/internal/manifests/metricsgenerator"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/networkpolicy"
+	"github.com/grafana/tempo-operator/internal/manifests/poddisruptionbudget"
 	"github.com/grafana/tempo-operator/internal/manifests/querier"
 	"github.com/grafana/tempo-operator/internal/manifests/queryfrontend"
 	"github.com/grafana/tempo-operator/internal/manifests/serviceaccount"
@@ -21,12 +28,22 @@ import (
 
 // BuildAll creates objects for Tempo deployment.
 func BuildAll(params manifestutils.Params) ([]client.Object, error) {
+	err := pinTempoVersion(&params)
+	if err != nil {
+		return nil, err
+	}
+
 	configMaps, configChecksum, err := config.BuildConfigMap(params)
 	if err != nil {
 		return nil, err
 	}
 	params.ConfigChecksum = configChecksum
 
+	overridesConfigMap, err := config.BuildOverridesConfigMap(params.Tempo)
+	if err != nil {
+		return nil, err
+	}
+
 	ingesterObjs, err := ingester.BuildIngester(params)
 	if err != nil {
 		return nil, err
@@ -52,7 +69,7 @@ func BuildAll(params manifestutils.Params) ([]client.Object, error) {
 	}
 
 	var manifests []client.Object
-	manifests = append(manifests, configMaps)
+	manifests = append(manifests, configMaps, overridesConfigMap)
 	if params.Tempo.Spec.ServiceAccount == naming.DefaultServiceAccountName(params.Tempo.Name) {
 		manifests = append(manifests, serviceaccount.BuildDefaultServiceAccount(params))
 	}
@@ -63,6 +80,33 @@ func BuildAll(params manifestutils.Params) ([]client.Object, error) {
 	manifests = append(manifests, querierObjs...)
 	manifests = append(manifests, compactorObjs...)
 
+	if params.Tempo.Spec.Template.MetricsGenerator.Enabled {
+		metricsGeneratorObjs, err := metricsgenerator.BuildMetricsGenerator(params)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, metricsGeneratorObjs...)
+	}
+
+	if manifestutils.KafkaIngestEnabled(params.Tempo) {
+		blockBuilderObjs, err := blockbuilder.BuildBlockBuilder(params)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, blockBuilderObjs...)
+	}
+
+	manifests = append(manifests, poddisruptionbudget.BuildPodDisruptionBudgets(params)...)
+	manifests = append(manifests, networkpolicy.BuildNetworkPolicies(params)...)
+
+	if memcached.ManagedEnabled(params.Tempo) {
+		memcachedObjs, err := memcached.BuildMemcached(params)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, memcachedObjs...)
+	}
+
 	if params.Tempo.Spec.Template.Gateway.Enabled {
 		gw, err := gateway.BuildGateway(params)
 		if err != nil {
@@ -89,3 +133,27 @@ func BuildAll(params manifestutils.Params) ([]client.Object, error) {
 
 	return manifests, nil
 }
+
+// pinTempoVersion replaces the default Tempo and Tempo Query images with the images of the pinned Tempo version.
+// Images set in the CR take precedence over the pinned version.
+func pinTempoVersion(params *manifestutils.Params) error {
+	tempoVersion := params.Tempo.Spec.TempoVersion
+	if tempoVersion == "" {
+		return nil
+	}
+
+	var err error
+	if params.Tempo.Spec.Images.Tempo == "" {
+		params.CtrlConfig.DefaultImages.Tempo, err = manifestutils.ImageWithTag(params.CtrlConfig.DefaultImages.Tempo, tempoVersion)
+		if err != nil {
+			return fmt.Errorf("cannot select the image of Tempo version %s: %w", tempoVersion, err)
+		}
+	}
+	if params.Tempo.Spec.Images.TempoQuery == "" {
+		params.CtrlConfig.DefaultImages.TempoQuery, err = manifestutils.ImageWithTag(params.CtrlConfig.DefaultImages.TempoQuery, tempoVersion)
+		if err != nil {
+			return fmt.Errorf("cannot select the image of Tempo version %s: %w", tempoVersion, err)
+		}
+	}
+	return nil
+}
@@ -4,12 +4,17 @@ import (
 	"testing"
 	"time"
 
+	openshiftconfigv1 "github.com/openshift/api/config/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/tlsprofile"
 )
 
 func TestBuildAll(t *testing.T) {
@@ -81,5 +86,67 @@ func TestBuildAll(t *testing.T) {
 		},
 	})
 	require.NoError(t, err)
-	assert.Len(t, objects, 17)
+	assert.Len(t, objects, 24)
+}
+
+func TestBuildAllTempoVersion(t *testing.T) {
+	objects, err := BuildAll(manifestutils.Params{
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{
+				Endpoint: "https://localhost",
+				Bucket:   "test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Tempo:        "docker.io/grafana/tempo:2.8.1",
+				TempoQuery:   "docker.io/grafana/tempo-query:2.8.1",
+				TempoGateway: "quay.io/observatorium/api:latest",
+			},
+		},
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				TempoVersion: "2.7.2",
+				Timeout:      metav1.Duration{Duration: time.Second * 5},
+				Images: configv1alpha1.ImagesSpec{
+					TempoQuery: "registry.example.com/tempo-query:custom",
+				},
+				Template: v1alpha1.TempoTemplateSpec{
+					QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+						JaegerQuery: v1alpha1.JaegerQuerySpec{
+							Enabled:               true,
+							ServicesQueryDuration: &metav1.Duration{Duration: 72 * time.Hour},
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	images := map[string]string{}
+	for _, obj := range objects {
+		var template *corev1.PodTemplateSpec
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			template = &o.Spec.Template
+		case *appsv1.StatefulSet:
+			template = &o.Spec.Template
+		default:
+			continue
+		}
+
+		for _, container := range template.Spec.Containers {
+			images[container.Name] = container.Image
+		}
+	}
+	assert.Equal(t, "docker.io/grafana/tempo:2.7.2", images["tempo"])
+	assert.Equal(t, "registry.example.com/tempo-query:custom", images["tempo-query"])
 }
@@ -4,8 +4,19 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 )
 
+// ComponentAffinity returns the affinity configured for a component,
+// or the default affinity if the component does not define one.
+func ComponentAffinity(spec v1alpha1.TempoComponentSpec, labels labels.Set) *corev1.Affinity {
+	if spec.Affinity != nil {
+		return spec.Affinity
+	}
+	return DefaultAffinity(labels)
+}
+
 // DefaultAffinity returns the default affinity for Tempo components.
 // It defines that two pods with the same labels (i.e. same component)
 // should not be scheduled on the same node or failure domain.
@@ -0,0 +1,33 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+)
+
+func TestComponentAffinity(t *testing.T) {
+	labels := ComponentLabels("querier", "test")
+	assert.Equal(t, DefaultAffinity(labels), ComponentAffinity(v1alpha1.TempoComponentSpec{}, labels))
+
+	affinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      "node-role.kubernetes.io/tracing",
+								Operator: corev1.NodeSelectorOpExists,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, affinity, ComponentAffinity(v1alpha1.TempoComponentSpec{Affinity: affinity}, labels))
+}
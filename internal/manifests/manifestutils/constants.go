@@ -10,6 +10,18 @@ const (
 	// ConfigVolumeName declares the name of the volume containing the tempo configuration.
 	ConfigVolumeName = "tempo-conf"
 
+	// RuntimeOverridesVolumeName declares the name of the volume containing the per-tenant overrides.
+	RuntimeOverridesVolumeName = "tempo-runtime-overrides"
+
+	// RuntimeOverridesMountPath declares the mount path of the volume containing the per-tenant overrides.
+	RuntimeOverridesMountPath = "/runtime-config"
+
+	// RuntimeOverridesFileName the name of the per-tenant overrides file in the ConfigMap.
+	RuntimeOverridesFileName = "overrides.yaml"
+
+	// RuntimeOverridesComponentName declares the name suffix of the ConfigMap containing the per-tenant overrides.
+	RuntimeOverridesComponentName = "overrides"
+
 	// GatewayRBACFileName the name of the RBAC config file in the ConfigMap.
 	GatewayRBACFileName = "rbac.yaml"
 	// GatewayTenantFileName the name of the tenant config file in the secret.
@@ -23,6 +35,11 @@ const (
 	// TmpStoragePath   declares generic default /tmp storage path.
 	TmpStoragePath = "/tmp"
 
+	// FilesystemStorageVolumeName declares the name of the volume containing the traces of the filesystem storage.
+	FilesystemStorageVolumeName = "tempo-filesystem-storage"
+	// FilesystemStoragePath declares the path of the traces of the filesystem storage.
+	FilesystemStoragePath = "/var/tempo/traces"
+
 	// HttpPortName declares the name of the tempo http port.
 	HttpPortName = "http"
 	// PortHTTPServer declares the port number of the tempo http port.
@@ -141,6 +158,14 @@ const (
 	IngesterComponentName = "ingester"
 	// GatewayComponentName declares the internal name of the gateway component.
 	GatewayComponentName = "gateway"
+	// MetricsGeneratorComponentName declares the internal name of the metrics-generator component.
+	MetricsGeneratorComponentName = "metrics-generator"
+	// MemcachedComponentName declares the internal name of the operator-managed memcached component.
+	MemcachedComponentName = "memcached"
+	// BlockBuilderComponentName declares the internal name of the block-builder component.
+	BlockBuilderComponentName = "block-builder"
+	// StorageMigrationComponentName declares the internal name of the storage migration Jobs.
+	StorageMigrationComponentName = "storage-migration"
 
 	// TempoMonolithComponentName declares the internal name of the Tempo Monolith component.
 	TempoMonolithComponentName = "tempo"
@@ -162,6 +187,13 @@ const (
 	// StorageTLSCAFilename is the key name of the CA file in the ConfigMap for accessing object storage.
 	StorageTLSCAFilename = "ca.crt"
 
+	// RemoteWriteUsernameKey is the key name of the username in the remote-write basic auth Secret.
+	RemoteWriteUsernameKey = "username"
+	// RemoteWritePasswordKey is the key name of the password in the remote-write basic auth Secret.
+	RemoteWritePasswordKey = "password" //#nosec G101 -- False positive
+	// RemoteWriteTokenKey is the key name of the token in the remote-write bearer token Secret.
+	RemoteWriteTokenKey = "token" //#nosec G101 -- False positive
+
 	tokenAuthConfigVolumeName       = "token-auth-config"       //#nosec G101 -- False positive
 	tokenAuthConfigDirectory        = "/etc/storage/token-auth" //#nosec G101 -- False positive
 	awsDefaultAudience              = "sts.amazonaws.com"
@@ -0,0 +1,63 @@
+package manifestutils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+)
+
+const (
+	// KafkaSASLUsernameEnvVar is the environment variable which contains the SASL username of the Kafka client.
+	KafkaSASLUsernameEnvVar = "KAFKA_SASL_USERNAME"
+	// KafkaSASLPasswordEnvVar is the environment variable which contains the SASL password of the Kafka client.
+	KafkaSASLPasswordEnvVar = "KAFKA_SASL_PASSWORD"
+
+	kafkaSASLUsernameKey = "username"
+	kafkaSASLPasswordKey = "password"
+)
+
+// KafkaIngestEnabled returns true if the distributors write traces to Kafka.
+func KafkaIngestEnabled(tempo v1alpha1.TempoStack) bool {
+	return tempo.Spec.Ingest != nil && tempo.Spec.Ingest.Kafka != nil
+}
+
+// ConfigureKafka mounts the Kafka TLS certificates and SASL credentials in a pod.
+func ConfigureKafka(tempo v1alpha1.TempoStack, pod *corev1.PodSpec, containerName string) error {
+	if !KafkaIngestEnabled(tempo) {
+		return nil
+	}
+	kafka := tempo.Spec.Ingest.Kafka
+
+	if kafka.SASL != nil {
+		containerIdx, err := findContainerIndex(pod, containerName)
+		if err != nil {
+			return err
+		}
+
+		pod.Containers[containerIdx].Env = append(pod.Containers[containerIdx].Env,
+			corev1.EnvVar{
+				Name: KafkaSASLUsernameEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: kafka.SASL.Secret},
+						Key:                  kafkaSASLUsernameKey,
+					},
+				},
+			},
+			corev1.EnvVar{
+				Name: KafkaSASLPasswordEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: kafka.SASL.Secret},
+						Key:                  kafkaSASLPasswordKey,
+					},
+				},
+			},
+		)
+	}
+
+	if kafka.TLS.Enabled {
+		return MountTLSSpecVolumes(pod, containerName, kafka.TLS, KafkaTLSCADir, KafkaTLSCertDir)
+	}
+	return nil
+}
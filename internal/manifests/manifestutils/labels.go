@@ -14,9 +14,16 @@ func ComponentLabels(component, instanceName string) labels.Set {
 
 // CommonLabels returns common labels for each object created by the operator.
 func CommonLabels(instanceName string) map[string]string {
+	return labels.Merge(ManagedByOperatorLabels(), map[string]string{
+		"app.kubernetes.io/name":     "tempo",
+		"app.kubernetes.io/instance": instanceName,
+	})
+}
+
+// ManagedByOperatorLabels returns the label which is set on each object created by the operator,
+// including the objects of TempoMonolithic instances.
+func ManagedByOperatorLabels() map[string]string {
 	return map[string]string{
-		"app.kubernetes.io/name":       "tempo",
-		"app.kubernetes.io/instance":   instanceName,
 		"app.kubernetes.io/managed-by": "tempo-operator",
 	}
 }
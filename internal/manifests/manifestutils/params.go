@@ -51,12 +51,22 @@ type GCS struct {
 
 // S3 holds S3 configuration.
 type S3 struct {
-	Endpoint string
-	TLS      StorageTLS
-	Bucket   string
-	RoleARN  string
-	Region   string
-	Insecure bool
+	Endpoint       string
+	TLS            StorageTLS
+	Bucket         string
+	RoleARN        string
+	Region         string
+	Insecure       bool
+	ForcePathStyle bool
+	Prefix         string
+	SSE            *S3SSE
+	STSEndpoint    string
+}
+
+// S3SSE holds the S3 server-side encryption configuration.
+type S3SSE struct {
+	Type     string
+	KMSKeyID string
 }
 
 // StorageTLS holds StorageTLS configuration.
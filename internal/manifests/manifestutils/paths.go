@@ -28,4 +28,16 @@ const (
 	StorageTLSCADir = TLSDir + "/storage/ca"
 	// StorageTLSCertDir contains the certificate and key file for accessing object storage.
 	StorageTLSCertDir = TLSDir + "/storage/cert"
+
+	// KafkaTLSCADir contains the CA file for accessing the Kafka brokers.
+	KafkaTLSCADir = TLSDir + "/kafka/ca"
+	// KafkaTLSCertDir contains the certificate and key file for accessing the Kafka brokers.
+	KafkaTLSCertDir = TLSDir + "/kafka/cert"
+
+	// RemoteWriteTLSCADir contains the CA files for accessing Prometheus remote-write endpoints.
+	RemoteWriteTLSCADir = TLSDir + "/remote-write/ca"
+	// RemoteWriteTLSCertDir contains the certificate and key files for accessing Prometheus remote-write endpoints.
+	RemoteWriteTLSCertDir = TLSDir + "/remote-write/cert"
+	// RemoteWriteAuthDir contains the credentials for accessing Prometheus remote-write endpoints.
+	RemoteWriteAuthDir = "/var/run/secrets/remote-write"
 )
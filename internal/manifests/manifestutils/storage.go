@@ -261,8 +261,63 @@ func ConfigureS3Storage(pod *corev1.PodSpec, containerName string, storageSecret
 	return nil
 }
 
+// ConfigureS3STS configures the AWS STS endpoint for short-lived S3 credentials.
+// The S3 client of Tempo requests the credentials from the regional STS endpoint of the AWS_REGION environment variable,
+// e.g. sts.us-gov-west-1.amazonaws.com, and falls back to the global STS endpoint otherwise.
+// A custom STS endpoint, e.g. a FIPS endpoint, is used by the AWS SDK, which is enabled in the Tempo configuration.
+func ConfigureS3STS(pod *corev1.PodSpec, containerName string, params *S3) error {
+	if params == nil || (params.Region == "" && params.STSEndpoint == "") {
+		return nil
+	}
+
+	containerIdx, err := findContainerIndex(pod, containerName)
+	if err != nil {
+		return err
+	}
+
+	if params.Region != "" {
+		pod.Containers[containerIdx].Env = append(pod.Containers[containerIdx].Env, corev1.EnvVar{
+			Name:  "AWS_REGION",
+			Value: params.Region,
+		})
+	}
+	if params.STSEndpoint != "" {
+		pod.Containers[containerIdx].Env = append(pod.Containers[containerIdx].Env, corev1.EnvVar{
+			Name:  "AWS_ENDPOINT_URL_STS",
+			Value: params.STSEndpoint,
+		})
+	}
+	return nil
+}
+
+// ConfigureFilesystemStorage mounts the shared PersistentVolumeClaim of the filesystem storage in a pod.
+func ConfigureFilesystemStorage(pod *corev1.PodSpec, containerName string, claimName string) error {
+	containerIdx, err := findContainerIndex(pod, containerName)
+	if err != nil {
+		return err
+	}
+
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: FilesystemStorageVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		},
+	})
+	pod.Containers[containerIdx].VolumeMounts = append(pod.Containers[containerIdx].VolumeMounts, corev1.VolumeMount{
+		Name:      FilesystemStorageVolumeName,
+		MountPath: FilesystemStoragePath,
+	})
+	return nil
+}
+
 // ConfigureStorage configures storage.
 func ConfigureStorage(storage StorageParams, tempo v1alpha1.TempoStack, pod *corev1.PodSpec, containerName string) error {
+	if tempo.Spec.Storage.Filesystem != nil {
+		return ConfigureFilesystemStorage(pod, containerName, tempo.Spec.Storage.Filesystem.ClaimName)
+	}
+
 	if tempo.Spec.Storage.Secret.Name != "" {
 		switch tempo.Spec.Storage.Secret.Type {
 		case v1alpha1.ObjectStorageSecretAzure:
@@ -271,8 +326,19 @@ func ConfigureStorage(storage StorageParams, tempo v1alpha1.TempoStack, pod *cor
 			return ConfigureGCS(pod, containerName, tempo.Spec.Storage.Secret.Name, storage.GCS.Audience,
 				storage.CredentialMode)
 		case v1alpha1.ObjectStorageSecretS3:
-			return ConfigureS3Storage(pod, containerName, tempo.Spec.Storage.Secret.Name, &tempo.Spec.Storage.TLS,
+			err := ConfigureS3Storage(pod, containerName, tempo.Spec.Storage.Secret.Name, &tempo.Spec.Storage.TLS,
 				storage.CredentialMode, tempo.Name, storage.CloudCredentials.Environment)
+			if err != nil {
+				return err
+			}
+			if storage.CredentialMode == v1alpha1.CredentialModeToken {
+				return ConfigureS3STS(pod, containerName, storage.S3)
+			}
+			return nil
+		case v1alpha1.ObjectStorageSecretSwift:
+			// Swift is accessed through its S3-compatible API with EC2 credentials.
+			return ConfigureS3Storage(pod, containerName, tempo.Spec.Storage.Secret.Name, &tempo.Spec.Storage.TLS,
+				v1alpha1.CredentialModeStatic, tempo.Name, storage.CloudCredentials.Environment)
 		}
 	}
 	return nil
@@ -1,10 +1,16 @@
 package manifestutils
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
@@ -134,6 +140,98 @@ func TestGetS3Storage_short_lived(t *testing.T) {
 	assert.Len(t, pod.Containers[0].Args, 0)
 }
 
+func TestConfigureS3STS(t *testing.T) {
+	pod := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "ingester",
+			},
+		},
+	}
+
+	assert.NoError(t, ConfigureS3STS(&pod, "ingester", &S3{}))
+	assert.Len(t, pod.Containers[0].Env, 0)
+
+	assert.NoError(t, ConfigureS3STS(&pod, "ingester", &S3{Region: "us-gov-west-1"}))
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name:  "AWS_REGION",
+			Value: "us-gov-west-1",
+		},
+	}, pod.Containers[0].Env)
+
+	pod.Containers[0].Env = nil
+	assert.NoError(t, ConfigureS3STS(&pod, "ingester", &S3{
+		Region:      "us-gov-west-1",
+		STSEndpoint: "https://sts-fips.us-gov-west-1.amazonaws.com",
+	}))
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name:  "AWS_REGION",
+			Value: "us-gov-west-1",
+		},
+		{
+			Name:  "AWS_ENDPOINT_URL_STS",
+			Value: "https://sts-fips.us-gov-west-1.amazonaws.com",
+		},
+	}, pod.Containers[0].Env)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// TestConfigureS3STSRegionalEndpoint verifies that the S3 client of Tempo (minio-go)
+// requests the short-lived credentials from the regional STS endpoint.
+func TestConfigureS3STSRegionalEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		region   string
+		expected string
+	}{
+		{name: "global", region: "", expected: "sts.amazonaws.com"},
+		{name: "GovCloud", region: "us-gov-west-1", expected: "sts.us-gov-west-1.amazonaws.com"},
+		{name: "China", region: "cn-north-1", expected: "sts.cn-north-1.amazonaws.com.cn"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "tempo",
+					},
+				},
+			}
+			require.NoError(t, ConfigureS3STS(&pod, "tempo", &S3{Region: test.region}))
+
+			tokenFile := filepath.Join(t.TempDir(), "token")
+			require.NoError(t, os.WriteFile(tokenFile, []byte("token"), 0600))
+			t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", tokenFile)
+			t.Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/tempo")
+			t.Setenv("AWS_REGION", "")
+			for _, env := range pod.Containers[0].Env {
+				t.Setenv(env.Name, env.Value)
+			}
+
+			var host string
+			iam := &credentials.IAM{
+				Client: &http.Client{
+					Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+						host = req.URL.Host
+						return nil, errors.New("not reachable")
+					}),
+				},
+			}
+			_, err := iam.Retrieve()
+			require.Error(t, err)
+			assert.Equal(t, test.expected, host)
+		})
+	}
+}
+
 func TestGetS3StorageWithCA(t *testing.T) {
 	tempo := v1alpha1.TempoStack{
 		Spec: v1alpha1.TempoStackSpec{
@@ -255,6 +353,28 @@ func TestConfigureStorage(t *testing.T) {
 			},
 			envName: "S3_SECRET_KEY",
 		},
+		{
+			name: "Swift configuration",
+			tempo: v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Storage: v1alpha1.ObjectStorageSpec{
+						Secret: v1alpha1.ObjectStorageSecretSpec{
+							Name:           "test",
+							Type:           v1alpha1.ObjectStorageSecretSwift,
+							CredentialMode: v1alpha1.CredentialModeStatic,
+						},
+					},
+				},
+			},
+			pod: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "ingester",
+					},
+				},
+			},
+			envName: "S3_ACCESS_KEY",
+		},
 	}
 
 	for _, test := range tests {
@@ -406,3 +526,41 @@ func TestAzureStorage_short_lived(t *testing.T) {
 	assert.Contains(t, pod.Containers[0].Args, "--storage.trace.azure.storage_account_name=$(AZURE_ACCOUNT_NAME)")
 
 }
+
+func TestConfigureFilesystemStorage(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Filesystem: &v1alpha1.FilesystemStorageSpec{
+					ClaimName: "traces",
+				},
+			},
+		},
+	}
+	pod := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "ingester",
+			},
+		},
+	}
+
+	assert.NoError(t, ConfigureStorage(StorageParams{}, tempo, &pod, "ingester"))
+	assert.Equal(t, []corev1.Volume{
+		{
+			Name: FilesystemStorageVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "traces",
+				},
+			},
+		},
+	}, pod.Volumes)
+	assert.Equal(t, []corev1.VolumeMount{
+		{
+			Name:      FilesystemStorageVolumeName,
+			MountPath: "/var/tempo/traces",
+		},
+	}, pod.Containers[0].VolumeMounts)
+	assert.Empty(t, pod.Containers[0].Env)
+}
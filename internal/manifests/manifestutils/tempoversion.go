@@ -0,0 +1,31 @@
+package manifestutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ImageWithTag replaces the tag of a container image.
+func ImageWithTag(image string, tag string) (string, error) {
+	if strings.Contains(image, "@") {
+		return "", fmt.Errorf("cannot change the tag of the image %s, the image is pinned by digest", image)
+	}
+
+	// the last colon after the last slash separates the tag, other colons separate the port of the registry
+	repository := image
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		repository = image[:i]
+	}
+	return repository + ":" + tag, nil
+}
+
+// ImageTag returns the tag of a container image, or an empty string if the image has no tag.
+func ImageTag(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageWithTag(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected string
+		err      string
+	}{
+		{image: "docker.io/grafana/tempo:2.8.1", expected: "docker.io/grafana/tempo:2.7.2"},
+		{image: "localhost:5000/grafana/tempo:2.8.1", expected: "localhost:5000/grafana/tempo:2.7.2"},
+		{image: "localhost:5000/grafana/tempo", expected: "localhost:5000/grafana/tempo:2.7.2"},
+		{image: "grafana/tempo", expected: "grafana/tempo:2.7.2"},
+		{
+			image: "quay.io/grafana/tempo@sha256:0a4b1d5e2e0c9e9a0b3f1d6b1f1a3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f3a5b",
+			err:   "cannot change the tag of the image quay.io/grafana/tempo@sha256:0a4b1d5e2e0c9e9a0b3f1d6b1f1a3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f3a5b, the image is pinned by digest",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			image, err := ImageWithTag(test.image, "2.7.2")
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, image)
+		})
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{image: "docker.io/grafana/tempo:2.8.1", expected: "2.8.1"},
+		{image: "localhost:5000/grafana/tempo:2.8.1", expected: "2.8.1"},
+		{image: "localhost:5000/grafana/tempo", expected: ""},
+		{image: "quay.io/grafana/tempo:2.8.1@sha256:0a4b1d5e2e0c9e9a0b3f1d6b1f1a3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f3a5b", expected: "2.8.1"},
+		{image: "quay.io/grafana/tempo@sha256:0a4b1d5e2e0c9e9a0b3f1d6b1f1a3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f3a5b", expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			assert.Equal(t, test.expected, ImageTag(test.image))
+		})
+	}
+}
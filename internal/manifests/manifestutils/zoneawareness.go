@@ -0,0 +1,131 @@
+package manifestutils
+
+import (
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+)
+
+const (
+	// ZoneAwarePodLabel marks pods which require the availability zone annotation.
+	ZoneAwarePodLabel = "tempo.grafana.com/zone-aware"
+	// AvailabilityZoneAnnotation contains the availability zone of the node a pod is scheduled on.
+	AvailabilityZoneAnnotation = "tempo.grafana.com/availability-zone"
+	// ZoneTopologyKeyAnnotation contains the node label which identifies the availability zone.
+	ZoneTopologyKeyAnnotation = "tempo.grafana.com/zone-topology-key"
+	// ZoneLabel contains the availability zone of an ingester zone StatefulSet.
+	ZoneLabel = "tempo.grafana.com/zone"
+	// ZoneTemplateHashAnnotation contains the hash of the pod template of an ingester zone StatefulSet.
+	ZoneTemplateHashAnnotation = "tempo.grafana.com/zone-template-hash"
+	// AvailabilityZoneEnvVar is the environment variable which contains the availability zone of a pod.
+	AvailabilityZoneEnvVar = "TEMPO_AVAILABILITY_ZONE"
+
+	defaultZoneTopologyKey        = "topology.kubernetes.io/zone"
+	availabilityZoneVolumeName    = "availability-zone"
+	availabilityZoneMountPath     = "/etc/tempo-availability-zone"
+	availabilityZoneFileName      = "availability-zone"
+	availabilityZoneInitContainer = "wait-for-availability-zone"
+)
+
+// ZoneAwarenessEnabled returns true if the zone-aware replication is enabled.
+func ZoneAwarenessEnabled(tempo v1alpha1.TempoStack) bool {
+	return tempo.Spec.ZoneAwareness != nil && tempo.Spec.ZoneAwareness.Enabled
+}
+
+// IngesterZones returns the availability zones which have a dedicated ingester StatefulSet.
+func IngesterZones(tempo v1alpha1.TempoStack) []v1alpha1.ZoneSpec {
+	if !ZoneAwarenessEnabled(tempo) {
+		return nil
+	}
+	return tempo.Spec.ZoneAwareness.Zones
+}
+
+// ZoneTopologyKey returns the node label which identifies the availability zone.
+func ZoneTopologyKey(tempo v1alpha1.TempoStack) string {
+	if tempo.Spec.ZoneAwareness != nil && tempo.Spec.ZoneAwareness.TopologyKey != "" {
+		return tempo.Spec.ZoneAwareness.TopologyKey
+	}
+	return defaultZoneTopologyKey
+}
+
+// ConfigureZoneAwareness spreads the pods across availability zones and exposes the availability zone
+// of the node a pod is scheduled on in the TEMPO_AVAILABILITY_ZONE environment variable.
+//
+// The availability zone is not available through the downward API, therefore the operator copies the
+// zone label of the node to an annotation of the pod, and an init container waits until the annotation is set.
+func ConfigureZoneAwareness(tempo v1alpha1.TempoStack, image string, selector labels.Set, template *corev1.PodTemplateSpec) {
+	topologyKey := ZoneTopologyKey(tempo)
+	maxSkew := tempo.Spec.ZoneAwareness.MaxSkew
+	if maxSkew == 0 {
+		maxSkew = 1
+	}
+
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	template.Labels[ZoneAwarePodLabel] = "true"
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[ZoneTopologyKeyAnnotation] = topologyKey
+
+	spec := &template.Spec
+	spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+		MaxSkew:           maxSkew,
+		TopologyKey:       topologyKey,
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: selector,
+		},
+	})
+
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: availabilityZoneVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{
+					{
+						Path: availabilityZoneFileName,
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: fmt.Sprintf("metadata.annotations['%s']", AvailabilityZoneAnnotation),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	spec.InitContainers = append(spec.InitContainers, corev1.Container{
+		Name:  availabilityZoneInitContainer,
+		Image: image,
+		Command: []string{
+			"sh",
+			"-c",
+			fmt.Sprintf("until [ -s %s ]; do echo waiting for the availability zone annotation; sleep 2; done", path.Join(availabilityZoneMountPath, availabilityZoneFileName)),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      availabilityZoneVolumeName,
+				MountPath: availabilityZoneMountPath,
+				ReadOnly:  true,
+			},
+		},
+		SecurityContext: TempoContainerSecurityContext(),
+	})
+
+	for i := range spec.Containers {
+		spec.Containers[i].Env = append(spec.Containers[i].Env, corev1.EnvVar{
+			Name: AvailabilityZoneEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", AvailabilityZoneAnnotation),
+				},
+			},
+		})
+	}
+}
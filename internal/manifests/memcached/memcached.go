@@ -0,0 +1,169 @@
+package memcached
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	// PortName declares the name of the memcached client port.
+	PortName = "memcached-client"
+	// Port declares the port number of the memcached client port.
+	Port = 11211
+
+	defaultMemoryLimitMB = 1024
+	// memcached needs some headroom on top of the item memory for connections and internal structures.
+	memoryOverheadMB = 100
+)
+
+// ManagedEnabled returns true if the operator deploys a memcached StatefulSet for this TempoStack.
+func ManagedEnabled(tempo v1alpha1.TempoStack) bool {
+	cache := tempo.Spec.Cache
+	return cache != nil &&
+		cache.Backend == v1alpha1.CacheBackendMemcached &&
+		cache.Memcached != nil &&
+		cache.Memcached.Managed.Enabled
+}
+
+// BuildMemcached creates the objects of the operator-managed memcached.
+func BuildMemcached(params manifestutils.Params) ([]client.Object, error) {
+	ss, err := statefulSet(params)
+	if err != nil {
+		return nil, err
+	}
+	return []client.Object{ss, service(params.Tempo)}, nil
+}
+
+func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
+	tempo := params.Tempo
+	cfg := tempo.Spec.Cache.Memcached.Managed
+	labels := manifestutils.ComponentLabels(manifestutils.MemcachedComponentName, tempo.Name)
+
+	image := tempo.Spec.Images.Memcached
+	if image == "" {
+		image = params.CtrlConfig.DefaultImages.Memcached
+	}
+	if image == "" {
+		return nil, fmt.Errorf("memcached image is not set, please set the %s environment variable of the operator or spec.images.memcached", configv1alpha1.EnvRelatedImageMemcached)
+	}
+
+	memoryLimitMB := cfg.MemoryLimitMB
+	if memoryLimitMB == 0 {
+		memoryLimitMB = defaultMemoryLimitMB
+	}
+
+	replicas := cfg.Replicas
+	if replicas == nil {
+		replicas = ptr.To(int32(1))
+	}
+
+	return &v1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.MemcachedComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: v1.StatefulSetSpec{
+			Replicas:            replicas,
+			ServiceName:         naming.Name(manifestutils.MemcachedComponentName, tempo.Name),
+			PodManagementPolicy: v1.ParallelPodManagement,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					Affinity:                  manifestutils.ComponentAffinity(cfg.TempoComponentSpec, labels),
+					TopologySpreadConstraints: cfg.TopologySpreadConstraints,
+					Containers: []corev1.Container{
+						{
+							Name:  "memcached",
+							Image: image,
+							Args: []string{
+								fmt.Sprintf("--memory-limit=%d", memoryLimitMB),
+								"--max-item-size=1m",
+								"--conn-limit=1024",
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          PortName,
+									ContainerPort: Port,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.FromString(PortName),
+									},
+								},
+								InitialDelaySeconds: 5,
+								TimeoutSeconds:      1,
+							},
+							Resources:       resources(tempo, memoryLimitMB),
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+					},
+					SecurityContext: cfg.PodSecurityContext,
+				},
+			},
+		},
+	}, nil
+}
+
+func resources(tempo v1alpha1.TempoStack, memoryLimitMB int) corev1.ResourceRequirements {
+	if res := tempo.Spec.Cache.Memcached.Managed.Resources; res != nil {
+		return *res
+	}
+
+	memory := resource.MustParse(fmt.Sprintf("%dMi", memoryLimitMB+memoryOverheadMB))
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: memory,
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: memory,
+		},
+	}
+}
+
+func service(tempo v1alpha1.TempoStack) *corev1.Service {
+	labels := manifestutils.ComponentLabels(manifestutils.MemcachedComponentName, tempo.Name)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.MemcachedComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			// Tempo discovers the memcached instances with a DNS SRV lookup, therefore the Service must be headless.
+			ClusterIP: corev1.ClusterIPNone,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       PortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       Port,
+					TargetPort: intstr.FromString(PortName),
+				},
+			},
+			Selector: labels,
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package memcached
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func testTempoStack() v1alpha1.TempoStack {
+	return v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ServiceAccount: "tempo-test-serviceaccount",
+			Cache: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Memcached: &v1alpha1.MemcachedSpec{
+					Managed: v1alpha1.ManagedMemcachedSpec{
+						Enabled: true,
+						TempoComponentSpec: v1alpha1.TempoComponentSpec{
+							Replicas:     ptr.To(int32(2)),
+							NodeSelector: map[string]string{"a": "b"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestManagedEnabled(t *testing.T) {
+	assert.True(t, ManagedEnabled(testTempoStack()))
+	assert.False(t, ManagedEnabled(v1alpha1.TempoStack{}))
+
+	tempo := testTempoStack()
+	tempo.Spec.Cache.Backend = v1alpha1.CacheBackendRedis
+	assert.False(t, ManagedEnabled(tempo))
+
+	tempo = testTempoStack()
+	tempo.Spec.Cache.Memcached.Managed.Enabled = false
+	assert.False(t, ManagedEnabled(tempo))
+}
+
+func TestBuildMemcached(t *testing.T) {
+	objects, err := BuildMemcached(manifestutils.Params{
+		Tempo: testTempoStack(),
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Memcached: "docker.io/library/memcached:1.6.38-alpine",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	labels := manifestutils.ComponentLabels("memcached", "test")
+
+	ss, ok := objects[0].(*v1.StatefulSet)
+	require.True(t, ok)
+	assert.Equal(t, "tempo-test-memcached", ss.Name)
+	assert.Equal(t, "tempo-test-memcached", ss.Spec.ServiceName)
+	assert.Equal(t, ptr.To(int32(2)), ss.Spec.Replicas)
+	assert.Equal(t, map[string]string{"a": "b"}, ss.Spec.Template.Spec.NodeSelector)
+	require.Len(t, ss.Spec.Template.Spec.Containers, 1)
+	container := ss.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "docker.io/library/memcached:1.6.38-alpine", container.Image)
+	assert.Equal(t, []string{
+		"--memory-limit=1024",
+		"--max-item-size=1m",
+		"--conn-limit=1024",
+	}, container.Args)
+	assert.Equal(t, resource.MustParse("1124Mi"), container.Resources.Limits[corev1.ResourceMemory])
+
+	assert.Equal(t, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-memcached",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "memcached-client",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       11211,
+					TargetPort: intstr.FromString("memcached-client"),
+				},
+			},
+			Selector: labels,
+		},
+	}, objects[1])
+}
+
+func TestBuildMemcachedMemoryLimit(t *testing.T) {
+	tempo := testTempoStack()
+	tempo.Spec.Images.Memcached = "memcached:custom"
+	tempo.Spec.Cache.Memcached.Managed.MemoryLimitMB = 256
+
+	objects, err := BuildMemcached(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	ss, ok := objects[0].(*v1.StatefulSet)
+	require.True(t, ok)
+	assert.Equal(t, "memcached:custom", ss.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "--memory-limit=256", ss.Spec.Template.Spec.Containers[0].Args[0])
+	assert.Equal(t, resource.MustParse("356Mi"), ss.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceMemory])
+}
+
+func TestBuildMemcachedMissingImage(t *testing.T) {
+	_, err := BuildMemcached(manifestutils.Params{Tempo: testTempoStack()})
+	require.Error(t, err)
+}
@@ -0,0 +1,135 @@
+package metricsgenerator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func testTempoStack() v1alpha1.TempoStack {
+	return v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Images: configv1alpha1.ImagesSpec{
+				Tempo: "docker.io/grafana/tempo:1.5.0",
+			},
+			ServiceAccount: "tempo-test-serviceaccount",
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					CredentialMode: v1alpha1.CredentialModeStatic,
+					Name:           "test-storage-secret",
+					Type:           "s3",
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+					Enabled: true,
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas:     ptr.To(int32(2)),
+						NodeSelector: map[string]string{"a": "b"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildMetricsGenerator(t *testing.T) {
+	objects, err := BuildMetricsGenerator(manifestutils.Params{Tempo: testTempoStack()})
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	labels := manifestutils.ComponentLabels("metrics-generator", "test")
+
+	ss, ok := objects[0].(*v1.StatefulSet)
+	require.True(t, ok)
+	assert.Equal(t, "tempo-test-metrics-generator", ss.Name)
+	assert.Equal(t, labels, k8slabels.Set(ss.Labels))
+	assert.Equal(t, ptr.To(int32(2)), ss.Spec.Replicas)
+	assert.Equal(t, map[string]string{"a": "b"}, ss.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, "true", ss.Spec.Template.Labels["tempo-gossip-member"])
+	assert.Equal(t, []string{
+		"-target=metrics-generator",
+		"-config.file=/conf/tempo.yaml",
+		"-log.level=info",
+		"-config.expand-env=true",
+		"--storage.trace.s3.secret_key=$(S3_SECRET_KEY)",
+		"--storage.trace.s3.access_key=$(S3_ACCESS_KEY)",
+	}, ss.Spec.Template.Spec.Containers[0].Args)
+	require.Len(t, ss.Spec.VolumeClaimTemplates, 1)
+	assert.Equal(t, resource.MustParse("10Gi"), ss.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage])
+
+	assert.Equal(t, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-metrics-generator",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       manifestutils.HttpPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortHTTPServer,
+					TargetPort: intstr.FromString(manifestutils.HttpPortName),
+				},
+				{
+					Name:       manifestutils.GrpcPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortGRPCServer,
+					TargetPort: intstr.FromString(manifestutils.GrpcPortName),
+				},
+			},
+			Selector: labels,
+		},
+	}, objects[1])
+}
+
+func TestBuildMetricsGeneratorStorageSize(t *testing.T) {
+	tempo := testTempoStack()
+	tempo.Spec.Template.MetricsGenerator.StorageSize = ptr.To(resource.MustParse("5Gi"))
+
+	objects, err := BuildMetricsGenerator(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	ss, ok := objects[0].(*v1.StatefulSet)
+	require.True(t, ok)
+	assert.Equal(t, resource.MustParse("5Gi"), ss.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage])
+}
+
+func TestBuildMetricsGeneratorWithTLS(t *testing.T) {
+	objects, err := BuildMetricsGenerator(manifestutils.Params{
+		Tempo: testTempoStack(),
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{
+				GRPCEncryption: true,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	ss, ok := objects[0].(*v1.StatefulSet)
+	require.True(t, ok)
+
+	volumes := map[string]bool{}
+	for _, v := range ss.Spec.Template.Spec.Volumes {
+		volumes[v.Name] = true
+	}
+	assert.True(t, volumes["tempo-test-ca-bundle"])
+	assert.True(t, volumes["tempo-test-metrics-generator-mtls"])
+}
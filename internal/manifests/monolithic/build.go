@@ -1,6 +1,7 @@
 package monolithic
 
 import (
+	"fmt"
 	"maps"
 
 	corev1 "k8s.io/api/core/v1"
@@ -9,6 +10,7 @@ import (
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/networkpolicy"
 	"github.com/grafana/tempo-operator/internal/manifests/oauthproxy"
 )
 
@@ -27,6 +29,11 @@ func getJaegerUIService(services []client.Object, tempo v1alpha1.TempoMonolithic
 
 // BuildAll generates all manifests.
 func BuildAll(opts Options) ([]client.Object, error) {
+	err := pinTempoVersion(&opts)
+	if err != nil {
+		return nil, err
+	}
+
 	tempo := opts.Tempo
 	manifests := []client.Object{}
 	extraStsAnnotations := map[string]string{}
@@ -86,6 +93,10 @@ func BuildAll(opts Options) ([]client.Object, error) {
 			manifests = append(manifests, BuildJaegerUIIngress(opts))
 		}
 
+		if tempo.Spec.JaegerUI.GatewayAPI != nil && tempo.Spec.JaegerUI.GatewayAPI.Enabled {
+			manifests = append(manifests, BuildJaegerUIHTTPRoute(opts))
+		}
+
 		if tempo.Spec.JaegerUI.Route != nil && tempo.Spec.JaegerUI.Route.Enabled {
 			route, err := BuildJaegerUIRoute(opts)
 			if err != nil {
@@ -132,5 +143,30 @@ func BuildAll(opts Options) ([]client.Object, error) {
 		}
 	}
 
+	if networkpolicy.Enabled(tempo.Spec.NetworkPolicy) {
+		manifests = append(manifests, BuildNetworkPolicies(opts)...)
+	}
+
 	return manifests, nil
 }
+
+// pinTempoVersion replaces the default Tempo and Tempo Query images with the images of the pinned Tempo version.
+func pinTempoVersion(opts *Options) error {
+	tempoVersion := opts.Tempo.Spec.TempoVersion
+	if tempoVersion == "" {
+		return nil
+	}
+
+	var err error
+	opts.CtrlConfig.DefaultImages.Tempo, err = manifestutils.ImageWithTag(opts.CtrlConfig.DefaultImages.Tempo, tempoVersion)
+	if err != nil {
+		return fmt.Errorf("cannot select the image of Tempo version %s: %w", tempoVersion, err)
+	}
+	if opts.Tempo.Spec.JaegerUI != nil && opts.Tempo.Spec.JaegerUI.Enabled {
+		opts.CtrlConfig.DefaultImages.TempoQuery, err = manifestutils.ImageWithTag(opts.CtrlConfig.DefaultImages.TempoQuery, tempoVersion)
+		if err != nil {
+			return fmt.Errorf("cannot select the image of Tempo version %s: %w", tempoVersion, err)
+		}
+	}
+	return nil
+}
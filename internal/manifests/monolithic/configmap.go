@@ -4,17 +4,21 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 	tempoStackConfig "github.com/grafana/tempo-operator/internal/manifests/config"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
 	"github.com/grafana/tempo-operator/internal/tlsprofile"
+	"github.com/grafana/tempo-operator/internal/version"
 )
 
 type tempoReceiverTLSConfig struct {
@@ -34,14 +38,23 @@ type tempoLocalConfig struct {
 	Path string `yaml:"path"`
 }
 type tempoS3Config struct {
-	Endpoint        string `yaml:"endpoint"`
-	Insecure        bool   `yaml:"insecure"`
-	Bucket          string `yaml:"bucket"`
-	TLSCAPath       string `yaml:"tls_ca_path,omitempty"`
-	TLSCertPath     string `yaml:"tls_cert_path,omitempty"`
-	TLSKeyPath      string `yaml:"tls_key_path,omitempty"`
-	TLSMinVersion   string `yaml:"tls_min_version,omitempty"`
-	TLSCipherSuites string `yaml:"tls_cipher_suites,omitempty"`
+	Endpoint        string            `yaml:"endpoint"`
+	Insecure        bool              `yaml:"insecure"`
+	Bucket          string            `yaml:"bucket"`
+	Region          string            `yaml:"region,omitempty"`
+	ForcePathStyle  bool              `yaml:"forcepathstyle,omitempty"`
+	Prefix          string            `yaml:"prefix,omitempty"`
+	SSE             *tempoS3SSEConfig `yaml:"sse,omitempty"`
+	NativeAWSAuth   bool              `yaml:"native_aws_auth_enabled,omitempty"`
+	TLSCAPath       string            `yaml:"tls_ca_path,omitempty"`
+	TLSCertPath     string            `yaml:"tls_cert_path,omitempty"`
+	TLSKeyPath      string            `yaml:"tls_key_path,omitempty"`
+	TLSMinVersion   string            `yaml:"tls_min_version,omitempty"`
+	TLSCipherSuites string            `yaml:"tls_cipher_suites,omitempty"`
+}
+type tempoS3SSEConfig struct {
+	Type     string `yaml:"type"`
+	KMSKeyID string `yaml:"kms_key_id,omitempty"`
 }
 type tempoAzureConfig struct {
 	ContainerName     string `yaml:"container_name"`
@@ -51,6 +64,127 @@ type tempoGCSConfig struct {
 	BucketName string `yaml:"bucket_name"`
 }
 
+type tempoDedicatedColumnConfig struct {
+	Scope string `yaml:"scope"`
+	Name  string `yaml:"name"`
+	Type  string `yaml:"type"`
+}
+
+type tempoBlockConfig struct {
+	Version                   string                       `yaml:"version,omitempty"`
+	BloomFilterFalsePositive  float64                      `yaml:"bloom_filter_false_positive,omitempty"`
+	BloomFilterShardSizeBytes int                          `yaml:"bloom_filter_shard_size_bytes,omitempty"`
+	DedicatedColumns          []tempoDedicatedColumnConfig `yaml:"parquet_dedicated_columns,omitempty"`
+}
+
+type tempoCompactorConfig struct {
+	Compaction struct {
+		BlockRetention   time.Duration `yaml:"block_retention,omitempty"`
+		CompactionWindow time.Duration `yaml:"compaction_window,omitempty"`
+		MaxBlockBytes    int           `yaml:"max_block_bytes,omitempty"`
+	} `yaml:"compaction"`
+}
+
+type tempoIngesterConfig struct {
+	MaxBlockDuration time.Duration `yaml:"max_block_duration,omitempty"`
+}
+
+type tempoRemoteWriteBasicAuthConfig struct {
+	UsernameFile string `yaml:"username_file"`
+	PasswordFile string `yaml:"password_file"`
+}
+
+type tempoRemoteWriteAuthorizationConfig struct {
+	Type            string `yaml:"type"`
+	CredentialsFile string `yaml:"credentials_file"`
+}
+
+type tempoRemoteWriteTLSConfig struct {
+	CAFile     string `yaml:"ca_file,omitempty"`
+	CertFile   string `yaml:"cert_file,omitempty"`
+	KeyFile    string `yaml:"key_file,omitempty"`
+	MinVersion string `yaml:"min_version,omitempty"`
+}
+
+type tempoRemoteWriteConfig struct {
+	URL           string                               `yaml:"url"`
+	SendExemplars bool                                 `yaml:"send_exemplars,omitempty"`
+	Headers       map[string]string                    `yaml:"headers,omitempty"`
+	BasicAuth     *tempoRemoteWriteBasicAuthConfig     `yaml:"basic_auth,omitempty"`
+	Authorization *tempoRemoteWriteAuthorizationConfig `yaml:"authorization,omitempty"`
+	TLSConfig     *tempoRemoteWriteTLSConfig           `yaml:"tls_config,omitempty"`
+}
+
+type tempoLocalBlocksConfig struct {
+	FilterServerSpans bool `yaml:"filter_server_spans"`
+}
+
+type tempoMetricsGeneratorConfig struct {
+	Processor struct {
+		ServiceGraphs *struct{}               `yaml:"service_graphs,omitempty"`
+		SpanMetrics   *struct{}               `yaml:"span_metrics,omitempty"`
+		LocalBlocks   *tempoLocalBlocksConfig `yaml:"local_blocks,omitempty"`
+	} `yaml:"processor"`
+	Storage struct {
+		Path        string                   `yaml:"path"`
+		RemoteWrite []tempoRemoteWriteConfig `yaml:"remote_write,omitempty"`
+	} `yaml:"storage"`
+	TracesStorage struct {
+		Path string `yaml:"path"`
+	} `yaml:"traces_storage"`
+}
+
+type tempoCostAttributionConfig struct {
+	Dimensions map[string]string `yaml:"dimensions"`
+}
+
+// tempoOverridesConfig contains the default overrides in the legacy (flat) format.
+type tempoOverridesConfig struct {
+	IngestionRateLimitBytes    *int                        `yaml:"ingestion_rate_limit_bytes,omitempty"`
+	IngestionBurstSizeBytes    *int                        `yaml:"ingestion_burst_size_bytes,omitempty"`
+	MaxTracesPerUser           *int                        `yaml:"max_traces_per_user,omitempty"`
+	MaxBytesPerTrace           *int                        `yaml:"max_bytes_per_trace,omitempty"`
+	MaxAttributeBytes          *int                        `yaml:"ingestion_max_attribute_bytes,omitempty"`
+	MaxBytesPerTagValuesQuery  *int                        `yaml:"max_bytes_per_tag_values_query,omitempty"`
+	MaxSearchDuration          time.Duration               `yaml:"max_search_duration,omitempty"`
+	MaxMetricsDuration         time.Duration               `yaml:"max_metrics_duration,omitempty"`
+	UnsafeQueryHints           *bool                       `yaml:"unsafe_query_hints,omitempty"`
+	Forwarders                 []string                    `yaml:"forwarders,omitempty"`
+	CostAttribution            *tempoCostAttributionConfig `yaml:"cost_attribution,omitempty"`
+	MetricsGeneratorProcessors []string                    `yaml:"metrics_generator_processors,omitempty"`
+	PerTenantOverrideConfig    string                      `yaml:"per_tenant_override_config,omitempty"`
+}
+
+// tempoTenantOverridesConfig contains the overrides of a tenant in the per-tenant overrides file.
+// Unset fields fall back to the default overrides.
+type tempoTenantOverridesConfig struct {
+	Ingestion struct {
+		RateLimitBytes    *int `yaml:"rate_limit_bytes,omitempty"`
+		BurstSizeBytes    *int `yaml:"burst_size_bytes,omitempty"`
+		MaxTracesPerUser  *int `yaml:"max_traces_per_user,omitempty"`
+		MaxBytesPerTrace  *int `yaml:"max_bytes_per_trace,omitempty"`
+		MaxAttributeBytes *int `yaml:"max_attribute_bytes,omitempty"`
+	} `yaml:"ingestion,omitempty"`
+	Read struct {
+		MaxBytesPerTagValuesQuery *int          `yaml:"max_bytes_per_tag_values_query,omitempty"`
+		MaxSearchDuration         time.Duration `yaml:"max_search_duration,omitempty"`
+		MaxMetricsDuration        time.Duration `yaml:"max_metrics_duration,omitempty"`
+		UnsafeQueryHints          *bool         `yaml:"unsafe_query_hints,omitempty"`
+	} `yaml:"read,omitempty"`
+	Forwarders       []string `yaml:"forwarders,omitempty"`
+	MetricsGenerator *struct {
+		Processors []string `yaml:"processors"`
+	} `yaml:"metrics_generator,omitempty"`
+	CostAttribution *tempoCostAttributionConfig `yaml:"cost_attribution,omitempty"`
+	Compaction      *struct {
+		BlockRetention time.Duration `yaml:"block_retention"`
+	} `yaml:"compaction,omitempty"`
+}
+
+type tempoTenantOverrides struct {
+	Overrides map[string]tempoTenantOverridesConfig `yaml:"overrides"`
+}
+
 type tempoConfig struct {
 	MultitenancyEnabled bool `yaml:"multitenancy_enabled,omitempty"`
 
@@ -69,9 +203,12 @@ type tempoConfig struct {
 
 	Storage struct {
 		Trace struct {
-			Backend string `yaml:"backend"`
-			WAL     struct {
-				Path string `yaml:"path"`
+			Backend       string            `yaml:"backend"`
+			BlocklistPoll time.Duration     `yaml:"blocklist_poll,omitempty"`
+			Block         *tempoBlockConfig `yaml:"block,omitempty"`
+			WAL           struct {
+				Path       string `yaml:"path"`
+				V2Encoding string `yaml:"v2_encoding,omitempty"`
 			} `yaml:"wal"`
 			Local *tempoLocalConfig `yaml:"local,omitempty"`
 			S3    *tempoS3Config    `yaml:"s3,omitempty"`
@@ -91,17 +228,25 @@ type tempoConfig struct {
 		} `yaml:"receivers,omitempty"`
 	} `yaml:"distributor,omitempty"`
 
+	Ingester *tempoIngesterConfig `yaml:"ingester,omitempty"`
+
+	Compactor *tempoCompactorConfig `yaml:"compactor,omitempty"`
+
+	MetricsGenerator *tempoMetricsGeneratorConfig `yaml:"metrics_generator,omitempty"`
+
+	Overrides *tempoOverridesConfig `yaml:"overrides,omitempty"`
+
 	UsageReport struct {
 		ReportingEnabled bool `yaml:"reporting_enabled"`
 	} `yaml:"usage_report"`
 }
 
 type tempoQueryConfig struct {
-	Address                      string        `yaml:"address"`
-	Backend                      string        `yaml:"backend"`
-	TenantHeaderKey              string        `yaml:"tenant_header_key"`
-	ServicesQueryDuration        time.Duration `yaml:"services_query_duration"`
-	FindTracesConcurrentRequests int           `yaml:"find_traces_concurrent_requests"`
+	Address                      string         `yaml:"address"`
+	Backend                      string         `yaml:"backend"`
+	TenantHeaderKey              string         `yaml:"tenant_header_key"`
+	ServicesQueryDuration        *time.Duration `yaml:"services_query_duration,omitempty"`
+	FindTracesConcurrentRequests *int           `yaml:"find_traces_concurrent_requests,omitempty"`
 }
 
 // BuildConfigMap creates the Tempo ConfigMap for a monolithic deployment.
@@ -133,8 +278,18 @@ func BuildConfigMap(opts Options) (*corev1.ConfigMap, map[string]string, error)
 	h := sha256.Sum256(tempoConfig)
 	extraAnnotations["tempo.grafana.com/tempoConfig.hash"] = fmt.Sprintf("%x", h)
 
+	// The per-tenant overrides are reloaded by Tempo at runtime,
+	// therefore they are not part of the config hash and don't restart the pod.
+	if tenantOverridesRequired(tempo) {
+		tenantOverrides, err := buildTenantOverrides(tempo)
+		if err != nil {
+			return nil, nil, err
+		}
+		configMap.Data[tenantOverridesFilename] = string(tenantOverrides)
+	}
+
 	if tempo.Spec.JaegerUI != nil && tempo.Spec.JaegerUI.Enabled {
-		tempoQueryConfig, err := buildTempoQueryConfig(tempo.Spec.JaegerUI)
+		tempoQueryConfig, err := buildTempoQueryConfig(tempo.Spec.JaegerUI, tempo.Spec.TempoVersion)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -221,7 +376,24 @@ func buildTempoConfig(opts Options) ([]byte, error) {
 				}
 			} else if opts.StorageParams.CredentialMode == v1alpha1.CredentialModeToken || opts.StorageParams.CredentialMode == v1alpha1.CredentialModeTokenCCO {
 				config.Storage.Trace.S3.Bucket = opts.StorageParams.S3.Bucket
-				config.Storage.Trace.S3.Endpoint = fmt.Sprintf("s3.%s.amazonaws.com", opts.StorageParams.S3.Region)
+				if opts.StorageParams.S3.Endpoint != "" {
+					config.Storage.Trace.S3.Endpoint = opts.StorageParams.S3.Endpoint
+					config.Storage.Trace.S3.Region = opts.StorageParams.S3.Region
+				} else {
+					config.Storage.Trace.S3.Endpoint = fmt.Sprintf("s3.%s.amazonaws.com", opts.StorageParams.S3.Region)
+				}
+				// the AWS SDK uses the custom STS endpoint of the AWS_ENDPOINT_URL_STS environment variable
+				config.Storage.Trace.S3.NativeAWSAuth = opts.StorageParams.S3.STSEndpoint != ""
+			}
+			if opts.StorageParams.S3 != nil {
+				config.Storage.Trace.S3.ForcePathStyle = opts.StorageParams.S3.ForcePathStyle
+				config.Storage.Trace.S3.Prefix = opts.StorageParams.S3.Prefix
+				if sse := opts.StorageParams.S3.SSE; sse != nil {
+					config.Storage.Trace.S3.SSE = &tempoS3SSEConfig{
+						Type:     sse.Type,
+						KMSKeyID: sse.KMSKeyID,
+					}
+				}
 			}
 
 		case v1alpha1.MonolithicTracesStorageBackendAzure:
@@ -237,6 +409,10 @@ func buildTempoConfig(opts Options) ([]byte, error) {
 		default:
 			return nil, fmt.Errorf("invalid storage backend: '%s'", tempo.Spec.Storage.Traces.Backend)
 		}
+
+		if err := configureStorageTuning(&config, tempo.Spec.Storage.Tuning, tempo.Spec.Storage.DedicatedColumns); err != nil {
+			return nil, err
+		}
 	}
 
 	if tempo.Spec.Ingestion != nil {
@@ -282,6 +458,25 @@ func buildTempoConfig(opts Options) ([]byte, error) {
 		}
 	}
 
+	if metricsGeneratorEnabled(tempo) {
+		metricsGenerator, err := buildMetricsGeneratorConfig(tempo.Spec.MetricsGenerator, opts.TLSProfile)
+		if err != nil {
+			return nil, err
+		}
+		config.MetricsGenerator = metricsGenerator
+
+		config.Overrides = &tempoOverridesConfig{}
+		for _, processor := range tempo.Spec.MetricsGenerator.Processors {
+			config.Overrides.MetricsGeneratorProcessors = append(config.Overrides.MetricsGeneratorProcessors, string(processor))
+		}
+	}
+
+	features, err := version.TempoFeaturesFor(tempo.Spec.TempoVersion)
+	if err != nil {
+		return nil, err
+	}
+	configureRetentionAndLimits(&config, tempo, features)
+
 	generatedYaml, err := yaml.Marshal(config)
 	if err != nil {
 		return nil, err
@@ -294,12 +489,237 @@ func buildTempoConfig(opts Options) ([]byte, error) {
 	}
 }
 
-func buildTempoQueryConfig(jaegerUISpec *v1alpha1.MonolithicJaegerUISpec) ([]byte, error) {
+func configureStorageTuning(config *tempoConfig, spec *v1alpha1.StorageTuningSpec, columns []v1alpha1.DedicatedColumn) error {
+	block := &tempoBlockConfig{}
+	for _, column := range columns {
+		columnType := string(column.Type)
+		if columnType == "" {
+			columnType = string(v1alpha1.DedicatedColumnTypeString)
+		}
+		block.DedicatedColumns = append(block.DedicatedColumns, tempoDedicatedColumnConfig{
+			Scope: string(column.Scope),
+			Name:  column.Name,
+			Type:  columnType,
+		})
+	}
+
+	if spec != nil {
+		if spec.BlocklistPoll != nil {
+			config.Storage.Trace.BlocklistPoll = spec.BlocklistPoll.Duration
+		}
+		if spec.Block != nil {
+			if spec.Block.MaxDuration != nil {
+				config.Ingester = &tempoIngesterConfig{MaxBlockDuration: spec.Block.MaxDuration.Duration}
+			}
+
+			block.Version = string(spec.Block.Version)
+			block.BloomFilterShardSizeBytes = ptr.Deref(spec.Block.BloomFilterShardSizeBytes, 0)
+			if spec.Block.BloomFilterFalsePositive != "" {
+				falsePositive, err := strconv.ParseFloat(spec.Block.BloomFilterFalsePositive, 64)
+				if err != nil {
+					return fmt.Errorf("invalid bloom filter false positive rate: %w", err)
+				}
+				block.BloomFilterFalsePositive = falsePositive
+			}
+		}
+		if spec.Compaction != nil {
+			config.Compactor = &tempoCompactorConfig{}
+			if spec.Compaction.Window != nil {
+				config.Compactor.Compaction.CompactionWindow = spec.Compaction.Window.Duration
+			}
+			config.Compactor.Compaction.MaxBlockBytes = ptr.Deref(spec.Compaction.MaxBlockBytes, 0)
+		}
+		if spec.WAL != nil {
+			config.Storage.Trace.WAL.V2Encoding = string(spec.WAL.Encoding)
+		}
+	}
+
+	if block.Version != "" || block.BloomFilterFalsePositive != 0 || block.BloomFilterShardSizeBytes != 0 || len(block.DedicatedColumns) > 0 {
+		config.Storage.Trace.Block = block
+	}
+	return nil
+}
+
+// configureRetentionAndLimits configures the global retention and limits,
+// and the path of the per-tenant overrides file.
+func configureRetentionAndLimits(config *tempoConfig, tempo v1alpha1.TempoMonolithic, features version.TempoFeatures) {
+	if tempo.Spec.Retention != nil && tempo.Spec.Retention.Global.Traces.Duration > 0 {
+		if config.Compactor == nil {
+			config.Compactor = &tempoCompactorConfig{}
+		}
+		config.Compactor.Compaction.BlockRetention = tempo.Spec.Retention.Global.Traces.Duration
+	}
+
+	if tempo.Spec.Limits == nil && !tenantOverridesRequired(tempo) {
+		return
+	}
+	if config.Overrides == nil {
+		config.Overrides = &tempoOverridesConfig{}
+	}
+
+	if tempo.Spec.Limits != nil {
+		global := tempo.Spec.Limits.Global
+		config.Overrides.IngestionRateLimitBytes = global.Ingestion.IngestionRateLimitBytes
+		config.Overrides.IngestionBurstSizeBytes = global.Ingestion.IngestionBurstSizeBytes
+		config.Overrides.MaxTracesPerUser = global.Ingestion.MaxTracesPerUser
+		config.Overrides.MaxBytesPerTrace = global.Ingestion.MaxBytesPerTrace
+		config.Overrides.MaxAttributeBytes = global.Ingestion.MaxAttributeBytes
+		config.Overrides.Forwarders = global.Ingestion.Forwarders
+		if len(global.Ingestion.CostAttributionDimensions) > 0 && features.CostAttribution {
+			config.Overrides.CostAttribution = &tempoCostAttributionConfig{Dimensions: global.Ingestion.CostAttributionDimensions}
+		}
+		config.Overrides.MaxBytesPerTagValuesQuery = global.Query.MaxBytesPerTagValues
+		config.Overrides.MaxSearchDuration = global.Query.MaxSearchDuration.Duration
+		config.Overrides.MaxMetricsDuration = global.Query.MaxMetricsDuration.Duration
+		config.Overrides.UnsafeQueryHints = global.Query.UnsafeQueryHints
+	}
+
+	if tenantOverridesRequired(tempo) {
+		config.Overrides.PerTenantOverrideConfig = path.Join(configDir, tenantOverridesFilename)
+	}
+}
+
+// tenantOverridesRequired returns true if the per-tenant overrides file should be configured.
+// With multi-tenancy enabled, the file is always configured, so that adding the first per-tenant
+// override doesn't change the main configuration file and doesn't restart the pod.
+func tenantOverridesRequired(tempo v1alpha1.TempoMonolithic) bool {
+	return (tempo.Spec.Multitenancy != nil && tempo.Spec.Multitenancy.Enabled) ||
+		(tempo.Spec.Retention != nil && len(tempo.Spec.Retention.PerTenant) > 0) ||
+		(tempo.Spec.Limits != nil && len(tempo.Spec.Limits.PerTenant) > 0)
+}
+
+func buildTenantOverrides(tempo v1alpha1.TempoMonolithic) ([]byte, error) {
+	features, err := version.TempoFeaturesFor(tempo.Spec.TempoVersion)
+	if err != nil {
+		return nil, err
+	}
+	overrides := tempoTenantOverrides{Overrides: map[string]tempoTenantOverridesConfig{}}
+
+	if tempo.Spec.Limits != nil {
+		for tenant, limits := range tempo.Spec.Limits.PerTenant {
+			cfg := tempoTenantOverridesConfig{}
+			cfg.Ingestion.RateLimitBytes = limits.Ingestion.IngestionRateLimitBytes
+			cfg.Ingestion.BurstSizeBytes = limits.Ingestion.IngestionBurstSizeBytes
+			cfg.Ingestion.MaxTracesPerUser = limits.Ingestion.MaxTracesPerUser
+			cfg.Ingestion.MaxBytesPerTrace = limits.Ingestion.MaxBytesPerTrace
+			cfg.Ingestion.MaxAttributeBytes = limits.Ingestion.MaxAttributeBytes
+			cfg.Read.MaxBytesPerTagValuesQuery = limits.Query.MaxBytesPerTagValues
+			cfg.Read.MaxSearchDuration = limits.Query.MaxSearchDuration.Duration
+			cfg.Read.MaxMetricsDuration = limits.Query.MaxMetricsDuration.Duration
+			cfg.Read.UnsafeQueryHints = limits.Query.UnsafeQueryHints
+			cfg.Forwarders = limits.Ingestion.Forwarders
+			if len(limits.Ingestion.MetricsGeneratorProcessors) > 0 {
+				cfg.MetricsGenerator = &struct {
+					Processors []string `yaml:"processors"`
+				}{}
+				for _, processor := range limits.Ingestion.MetricsGeneratorProcessors {
+					cfg.MetricsGenerator.Processors = append(cfg.MetricsGenerator.Processors, string(processor))
+				}
+			}
+			if len(limits.Ingestion.CostAttributionDimensions) > 0 && features.CostAttribution {
+				cfg.CostAttribution = &tempoCostAttributionConfig{Dimensions: limits.Ingestion.CostAttributionDimensions}
+			}
+			overrides.Overrides[tenant] = cfg
+		}
+	}
+
+	if tempo.Spec.Retention != nil {
+		for tenant, retention := range tempo.Spec.Retention.PerTenant {
+			cfg := overrides.Overrides[tenant]
+			cfg.Compaction = &struct {
+				BlockRetention time.Duration `yaml:"block_retention"`
+			}{BlockRetention: retention.Traces.Duration}
+			overrides.Overrides[tenant] = cfg
+		}
+	}
+
+	return yaml.Marshal(overrides)
+}
+
+func buildMetricsGeneratorConfig(spec *v1alpha1.MonolithicMetricsGeneratorSpec, tlsProfile tlsprofile.TLSProfileOptions) (*tempoMetricsGeneratorConfig, error) {
+	cfg := &tempoMetricsGeneratorConfig{}
+	cfg.Storage.Path = path.Join(metricsGeneratorDir, "wal")
+	cfg.TracesStorage.Path = path.Join(metricsGeneratorDir, "traces")
+
+	for _, processor := range spec.Processors {
+		switch processor {
+		case v1alpha1.MetricsGeneratorProcessorServiceGraphs:
+			cfg.Processor.ServiceGraphs = &struct{}{}
+		case v1alpha1.MetricsGeneratorProcessorSpanMetrics:
+			cfg.Processor.SpanMetrics = &struct{}{}
+		case v1alpha1.MetricsGeneratorProcessorLocalBlocks:
+			cfg.Processor.LocalBlocks = &tempoLocalBlocksConfig{
+				FilterServerSpans: false,
+			}
+		}
+	}
+
+	for i, rw := range spec.RemoteWrite {
+		rwCfg := tempoRemoteWriteConfig{
+			URL:           rw.URL,
+			SendExemplars: rw.SendExemplars,
+			Headers:       rw.Headers,
+		}
+
+		if rw.BasicAuth != nil {
+			authDir := remoteWriteDir(manifestutils.RemoteWriteAuthDir, i)
+			rwCfg.BasicAuth = &tempoRemoteWriteBasicAuthConfig{
+				UsernameFile: path.Join(authDir, manifestutils.RemoteWriteUsernameKey),
+				PasswordFile: path.Join(authDir, manifestutils.RemoteWritePasswordKey),
+			}
+		}
+
+		if rw.BearerToken != nil {
+			rwCfg.Authorization = &tempoRemoteWriteAuthorizationConfig{
+				Type:            "Bearer",
+				CredentialsFile: path.Join(remoteWriteDir(manifestutils.RemoteWriteAuthDir, i), manifestutils.RemoteWriteTokenKey),
+			}
+		}
+
+		if rw.TLS != nil && rw.TLS.Enabled {
+			rwCfg.TLSConfig = &tempoRemoteWriteTLSConfig{}
+			if rw.TLS.CA != "" {
+				rwCfg.TLSConfig.CAFile = path.Join(remoteWriteDir(manifestutils.RemoteWriteTLSCADir, i), manifestutils.TLSCAFilename)
+			}
+			if rw.TLS.Cert != "" {
+				certDir := remoteWriteDir(manifestutils.RemoteWriteTLSCertDir, i)
+				rwCfg.TLSConfig.CertFile = path.Join(certDir, manifestutils.TLSCertFilename)
+				rwCfg.TLSConfig.KeyFile = path.Join(certDir, manifestutils.TLSKeyFilename)
+			}
+
+			minVersion := rw.TLS.MinVersion
+			if minVersion == "" && tlsProfile.MinTLSVersion != "" {
+				var err error
+				minVersion, err = tlsProfile.MinVersionShort()
+				if err != nil {
+					return nil, err
+				}
+			}
+			if minVersion != "" {
+				// Prometheus expects the TLS version in the format TLS12 instead of 1.2
+				rwCfg.TLSConfig.MinVersion = "TLS" + strings.ReplaceAll(minVersion, ".", "")
+			}
+		}
+
+		cfg.Storage.RemoteWrite = append(cfg.Storage.RemoteWrite, rwCfg)
+	}
+
+	return cfg, nil
+}
+
+func buildTempoQueryConfig(jaegerUISpec *v1alpha1.MonolithicJaegerUISpec, tempoVersion string) ([]byte, error) {
+	features, err := version.TempoFeaturesFor(tempoVersion)
+	if err != nil {
+		return nil, err
+	}
+
 	config := tempoQueryConfig{}
 	config.Address = fmt.Sprintf("0.0.0.0:%d", manifestutils.PortTempoGRPCQuery)
 	config.Backend = fmt.Sprintf("localhost:%d", manifestutils.PortHTTPServer)
 	config.TenantHeaderKey = manifestutils.TenantHeader
-	config.ServicesQueryDuration = jaegerUISpec.ServicesQueryDuration.Duration
-	config.FindTracesConcurrentRequests = jaegerUISpec.FindTracesConcurrentRequests
+	if features.TempoQueryTuning {
+		config.ServicesQueryDuration = &jaegerUISpec.ServicesQueryDuration.Duration
+		config.FindTracesConcurrentRequests = &jaegerUISpec.FindTracesConcurrentRequests
+	}
 	return yaml.Marshal(&config)
 }
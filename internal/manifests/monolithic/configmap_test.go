@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
@@ -417,6 +418,67 @@ distributor:
           endpoint: 0.0.0.0:4318
 usage_report:
   reporting_enabled: false
+`,
+		},
+		{
+			name: "S3 storage with short-lived credentials, custom endpoint and SSE",
+			spec: v1alpha1.TempoMonolithicSpec{
+				Storage: &v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendS3,
+						S3:      &v1alpha1.MonolithicTracesStorageS3Spec{},
+					},
+				},
+			},
+			opts: Options{
+				StorageParams: manifestutils.StorageParams{
+					CredentialMode: v1alpha1.CredentialModeToken,
+					S3: &manifestutils.S3{
+						Endpoint: "s3-fips.us-gov-west-1.amazonaws.com",
+						Region:   "us-gov-west-1",
+						Bucket:   "tempo",
+						Prefix:   "dev",
+						SSE: &manifestutils.S3SSE{
+							Type:     "SSE-KMS",
+							KMSKeyID: "key",
+						},
+						STSEndpoint: "https://sts-fips.us-gov-west-1.amazonaws.com",
+					},
+				},
+			},
+			expected: `
+server:
+  http_listen_port: 3200
+  http_server_read_timeout: 30s
+  http_server_write_timeout: 30s
+internal_server:
+  enable: true
+  http_listen_address: 0.0.0.0
+storage:
+  trace:
+    backend: s3
+    wal:
+      path: /var/tempo/wal
+    s3:
+      endpoint: s3-fips.us-gov-west-1.amazonaws.com
+      region: us-gov-west-1
+      bucket: tempo
+      insecure: false
+      prefix: dev
+      sse:
+        type: SSE-KMS
+        kms_key_id: key
+      native_aws_auth_enabled: true
+distributor:
+  receivers:
+    otlp:
+      protocols:
+        grpc:
+          endpoint: 0.0.0.0:4317
+        http:
+          endpoint: 0.0.0.0:4318
+usage_report:
+  reporting_enabled: false
 `,
 		},
 		{
@@ -452,6 +514,115 @@ distributor:
           endpoint: 0.0.0.0:4318
 usage_report:
   reporting_enabled: false
+`,
+		},
+		{
+			name: "storage tuning",
+			spec: v1alpha1.TempoMonolithicSpec{
+				Storage: &v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: "pv",
+					},
+					Tuning: &v1alpha1.StorageTuningSpec{
+						BlocklistPoll: &metav1.Duration{Duration: 2 * time.Minute},
+						Block: &v1alpha1.BlockTuningSpec{
+							Version:                  v1alpha1.BlockVersionParquet4,
+							BloomFilterFalsePositive: "0.05",
+							MaxDuration:              &metav1.Duration{Duration: 5 * time.Minute},
+						},
+						Compaction: &v1alpha1.CompactionTuningSpec{
+							Window: &metav1.Duration{Duration: 30 * time.Minute},
+						},
+						WAL: &v1alpha1.WALTuningSpec{Encoding: "zstd"},
+					},
+					DedicatedColumns: []v1alpha1.DedicatedColumn{
+						{Scope: v1alpha1.DedicatedColumnScopeResource, Name: "k8s.namespace.name"},
+					},
+				},
+			},
+			expected: `
+server:
+  http_listen_port: 3200
+  http_server_read_timeout: 30s
+  http_server_write_timeout: 30s
+internal_server:
+  enable: true
+  http_listen_address: 0.0.0.0
+storage:
+  trace:
+    backend: local
+    blocklist_poll: 2m0s
+    block:
+      version: vParquet4
+      bloom_filter_false_positive: 0.05
+      parquet_dedicated_columns:
+      - scope: resource
+        name: k8s.namespace.name
+        type: string
+    wal:
+      path: /var/tempo/wal
+      v2_encoding: zstd
+    local:
+      path: /var/tempo/blocks
+ingester:
+  max_block_duration: 5m0s
+compactor:
+  compaction:
+    compaction_window: 30m0s
+distributor:
+  receivers:
+    otlp:
+      protocols:
+        grpc:
+          endpoint: 0.0.0.0:4317
+        http:
+          endpoint: 0.0.0.0:4318
+usage_report:
+  reporting_enabled: false
+`,
+		},
+		{
+			name: "dedicated columns",
+			spec: v1alpha1.TempoMonolithicSpec{
+				Storage: &v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: "pv",
+					},
+					DedicatedColumns: []v1alpha1.DedicatedColumn{
+						{Scope: v1alpha1.DedicatedColumnScopeSpan, Name: "http.route"},
+					},
+				},
+			},
+			expected: `
+server:
+  http_listen_port: 3200
+  http_server_read_timeout: 30s
+  http_server_write_timeout: 30s
+internal_server:
+  enable: true
+  http_listen_address: 0.0.0.0
+storage:
+  trace:
+    backend: local
+    block:
+      parquet_dedicated_columns:
+      - scope: span
+        name: http.route
+        type: string
+    wal:
+      path: /var/tempo/wal
+    local:
+      path: /var/tempo/blocks
+distributor:
+  receivers:
+    otlp:
+      protocols:
+        grpc:
+          endpoint: 0.0.0.0:4317
+        http:
+          endpoint: 0.0.0.0:4318
+usage_report:
+  reporting_enabled: false
 `,
 		},
 		{
@@ -500,6 +671,195 @@ distributor:
           endpoint: 0.0.0.0:4318
 usage_report:
   reporting_enabled: false
+`,
+		},
+		{
+			name: "metrics-generator with remote-write TLS and authentication",
+			spec: v1alpha1.TempoMonolithicSpec{
+				MetricsGenerator: &v1alpha1.MonolithicMetricsGeneratorSpec{
+					Enabled: true,
+					Processors: []v1alpha1.MetricsGeneratorProcessor{
+						v1alpha1.MetricsGeneratorProcessorSpanMetrics,
+						v1alpha1.MetricsGeneratorProcessorLocalBlocks,
+					},
+					RemoteWrite: []v1alpha1.MonolithicMetricsGeneratorRemoteWriteSpec{
+						{
+							MetricsGeneratorRemoteWriteSpec: v1alpha1.MetricsGeneratorRemoteWriteSpec{
+								URL:           "https://prometheus:9090/api/v1/write",
+								SendExemplars: true,
+							},
+							TLS: &v1alpha1.TLSSpec{
+								Enabled:    true,
+								CA:         "ca",
+								MinVersion: "1.3",
+							},
+							BasicAuth: &v1alpha1.MonolithicRemoteWriteBasicAuthSpec{
+								Secret: "basic-auth",
+							},
+						},
+						{
+							MetricsGeneratorRemoteWriteSpec: v1alpha1.MetricsGeneratorRemoteWriteSpec{
+								URL:     "http://mimir:8080/api/v1/push",
+								Headers: map[string]string{"X-Scope-OrgID": "dev"},
+							},
+							BearerToken: &v1alpha1.MonolithicRemoteWriteBearerTokenSpec{
+								Secret: "token",
+							},
+						},
+					},
+				},
+			},
+			expected: `
+server:
+  http_listen_port: 3200
+  http_server_read_timeout: 30s
+  http_server_write_timeout: 30s
+internal_server:
+  enable: true
+  http_listen_address: 0.0.0.0
+storage:
+  trace:
+    backend: local
+    wal:
+      path: /var/tempo/wal
+    local:
+      path: /var/tempo/blocks
+distributor:
+  receivers:
+    otlp:
+      protocols:
+        grpc:
+          endpoint: 0.0.0.0:4317
+        http:
+          endpoint: 0.0.0.0:4318
+metrics_generator:
+  processor:
+    span_metrics: {}
+    local_blocks:
+      filter_server_spans: false
+  storage:
+    path: /var/tempo/generator/wal
+    remote_write:
+    - url: https://prometheus:9090/api/v1/write
+      send_exemplars: true
+      basic_auth:
+        username_file: /var/run/secrets/remote-write/0/username
+        password_file: /var/run/secrets/remote-write/0/password
+      tls_config:
+        ca_file: /var/run/tls/remote-write/ca/0/service-ca.crt
+        min_version: TLS13
+    - url: http://mimir:8080/api/v1/push
+      headers:
+        X-Scope-OrgID: dev
+      authorization:
+        type: Bearer
+        credentials_file: /var/run/secrets/remote-write/1/token
+  traces_storage:
+    path: /var/tempo/generator/traces
+overrides:
+  metrics_generator_processors:
+  - span-metrics
+  - local-blocks
+usage_report:
+  reporting_enabled: false
+`,
+		},
+		{
+			name: "global retention and limits",
+			spec: v1alpha1.TempoMonolithicSpec{
+				Retention: &v1alpha1.RetentionSpec{
+					Global: v1alpha1.RetentionConfig{
+						Traces: metav1.Duration{Duration: 72 * time.Hour},
+					},
+				},
+				Limits: &v1alpha1.LimitSpec{
+					Global: v1alpha1.RateLimitSpec{
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							IngestionRateLimitBytes: ptr.To(1000),
+							MaxTracesPerUser:        ptr.To(100),
+							MaxAttributeBytes:       ptr.To(2048),
+						},
+						Query: v1alpha1.QueryLimit{
+							MaxSearchDuration: metav1.Duration{Duration: 24 * time.Hour},
+						},
+					},
+				},
+			},
+			expected: `
+server:
+  http_listen_port: 3200
+  http_server_read_timeout: 30s
+  http_server_write_timeout: 30s
+internal_server:
+  enable: true
+  http_listen_address: 0.0.0.0
+storage:
+  trace:
+    backend: local
+    wal:
+      path: /var/tempo/wal
+    local:
+      path: /var/tempo/blocks
+distributor:
+  receivers:
+    otlp:
+      protocols:
+        grpc:
+          endpoint: 0.0.0.0:4317
+        http:
+          endpoint: 0.0.0.0:4318
+compactor:
+  compaction:
+    block_retention: 72h0m0s
+overrides:
+  ingestion_rate_limit_bytes: 1000
+  max_traces_per_user: 100
+  ingestion_max_attribute_bytes: 2048
+  max_search_duration: 24h0m0s
+usage_report:
+  reporting_enabled: false
+`,
+		},
+		{
+			name: "multi-tenancy with per-tenant retention",
+			spec: v1alpha1.TempoMonolithicSpec{
+				Multitenancy: &v1alpha1.MonolithicMultitenancySpec{
+					Enabled: true,
+				},
+				Retention: &v1alpha1.RetentionSpec{
+					PerTenant: map[string]v1alpha1.RetentionConfig{
+						"dev": {Traces: metav1.Duration{Duration: 24 * time.Hour}},
+					},
+				},
+			},
+			expected: `
+server:
+  http_listen_port: 3200
+  http_server_read_timeout: 30s
+  http_server_write_timeout: 30s
+internal_server:
+  enable: true
+  http_listen_address: 0.0.0.0
+storage:
+  trace:
+    backend: local
+    wal:
+      path: /var/tempo/wal
+    local:
+      path: /var/tempo/blocks
+distributor:
+  receivers:
+    otlp:
+      protocols:
+        grpc:
+          endpoint: 0.0.0.0:4317
+        http:
+          endpoint: 0.0.0.0:4318
+multitenancy_enabled: true
+overrides:
+  per_tenant_override_config: /conf/overrides.yaml
+usage_report:
+  reporting_enabled: false
 `,
 		},
 	}
@@ -521,3 +881,108 @@ usage_report:
 		})
 	}
 }
+
+func TestBuildTenantOverrides(t *testing.T) {
+	tempo := v1alpha1.TempoMonolithic{
+		Spec: v1alpha1.TempoMonolithicSpec{
+			Multitenancy: &v1alpha1.MonolithicMultitenancySpec{
+				Enabled: true,
+			},
+			Retention: &v1alpha1.RetentionSpec{
+				PerTenant: map[string]v1alpha1.RetentionConfig{
+					"dev":  {Traces: metav1.Duration{Duration: 24 * time.Hour}},
+					"prod": {Traces: metav1.Duration{Duration: 720 * time.Hour}},
+				},
+			},
+			Limits: &v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"dev": {
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							IngestionBurstSizeBytes:    ptr.To(2000),
+							MaxBytesPerTrace:           ptr.To(500),
+							MetricsGeneratorProcessors: []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorLocalBlocks},
+						},
+						Query: v1alpha1.QueryLimit{
+							MaxSearchDuration: metav1.Duration{Duration: time.Hour},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	require.YAMLEq(t, `
+overrides:
+  dev:
+    ingestion:
+      burst_size_bytes: 2000
+      max_bytes_per_trace: 500
+    read:
+      max_search_duration: 1h0m0s
+    metrics_generator:
+      processors:
+      - local-blocks
+    compaction:
+      block_retention: 24h0m0s
+  prod:
+    compaction:
+      block_retention: 720h0m0s
+`, string(cfg))
+}
+
+func TestBuildTenantOverridesTempoVersion(t *testing.T) {
+	tempo := v1alpha1.TempoMonolithic{
+		Spec: v1alpha1.TempoMonolithicSpec{
+			Limits: &v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"dev": {
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							CostAttributionDimensions: map[string]string{"service.name": "service"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	require.Contains(t, string(cfg), "cost_attribution")
+
+	tempo.Spec.TempoVersion = "2.7.2"
+	cfg, err = buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	require.NotContains(t, string(cfg), "cost_attribution")
+
+	tempo.Spec.TempoVersion = "2.5.0"
+	_, err = buildTenantOverrides(tempo)
+	require.Error(t, err)
+}
+
+func TestBuildConfigMapTenantOverrides(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				Multitenancy: &v1alpha1.MonolithicMultitenancySpec{
+					Enabled: true,
+				},
+			},
+		},
+	}
+	opts.Tempo.Default(opts.CtrlConfig)
+
+	cm, _, err := BuildConfigMap(opts)
+	require.NoError(t, err)
+	require.YAMLEq(t, "overrides: {}", cm.Data["overrides.yaml"])
+
+	opts.Tempo.Spec.Multitenancy = nil
+	cm, _, err = BuildConfigMap(opts)
+	require.NoError(t, err)
+	require.NotContains(t, cm.Data, "overrides.yaml")
+}
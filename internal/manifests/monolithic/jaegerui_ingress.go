@@ -8,8 +8,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/gatewayapi"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
 )
@@ -124,6 +126,28 @@ func BuildJaegerUIRoute(opts Options) (*routev1.Route, error) {
 	}, nil
 }
 
+// BuildJaegerUIHTTPRoute creates a HTTPRoute object of the Kubernetes Gateway API for Jaeger UI.
+func BuildJaegerUIHTTPRoute(opts Options) *gatewayv1.HTTPRoute {
+	tempo := opts.Tempo
+	targetService, _ := jaegerUIServiceAndPort(tempo)
+
+	var targetPort int32 = manifestutils.PortJaegerUI
+	if tempo.Spec.Multitenancy.IsGatewayEnabled() {
+		targetPort = manifestutils.GatewayPortHTTPServer
+	}
+
+	return gatewayapi.HTTPRoute(gatewayapi.RouteOptions{
+		Name:        naming.Name(manifestutils.JaegerUIComponentName, tempo.Name),
+		Namespace:   tempo.Namespace,
+		Labels:      ComponentLabels(manifestutils.JaegerUIComponentName, tempo.Name),
+		Annotations: tempo.Spec.JaegerUI.GatewayAPI.Annotations,
+		Host:        tempo.Spec.JaegerUI.GatewayAPI.Host,
+		ParentRef:   tempo.Spec.JaegerUI.GatewayAPI.ParentRef,
+		ServiceName: targetService,
+		ServicePort: targetPort,
+	})
+}
+
 func jaegerUIServiceAndPort(tempo v1alpha1.TempoMonolithic) (string, string) {
 	if tempo.Spec.Multitenancy.IsGatewayEnabled() {
 		return naming.Name(manifestutils.GatewayComponentName, tempo.Name), manifestutils.GatewayHttpPortName
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
@@ -254,3 +255,62 @@ func TestBuildJaegerUIRoute(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildJaegerUIHTTPRoute(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				JaegerUI: &v1alpha1.MonolithicJaegerUISpec{
+					Enabled: true,
+					GatewayAPI: &v1alpha1.MonolithicJaegerUIGatewayAPISpec{
+						Enabled: true,
+						Host:    "abc",
+						ParentRef: v1alpha1.GatewayParentReference{
+							Name: "public",
+						},
+					},
+				},
+			},
+		},
+	}
+	opts.Tempo.Default(configv1alpha1.ProjectConfig{})
+
+	obj := BuildJaegerUIHTTPRoute(opts)
+	require.Equal(t, &gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "gateway.networking.k8s.io/v1",
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-sample-jaegerui",
+			Namespace: "default",
+			Labels:    ComponentLabels(manifestutils.JaegerUIComponentName, "sample"),
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "public"}},
+			},
+			Hostnames: []gatewayv1.Hostname{"abc"},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{{
+					Path: &gatewayv1.HTTPPathMatch{
+						Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+						Value: ptr.To("/"),
+					},
+				}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{
+							Name: "tempo-sample-jaegerui",
+							Port: ptr.To(gatewayv1.PortNumber(16686)),
+						},
+					},
+				}},
+			}},
+		},
+	}, obj)
+}
@@ -0,0 +1,66 @@
+package monolithic
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/networkpolicy"
+)
+
+// BuildNetworkPolicies creates the NetworkPolicies of a TempoMonolithic instance.
+// Traffic to the receivers, query APIs and metrics endpoints is only allowed
+// from the peers configured in the CR, and optionally from the pods of the same namespace.
+// The egress is restricted to DNS, the Kubernetes API, HTTPS (e.g. the OIDC issuers of the gateway),
+// the object storage and the remote-write endpoints.
+func BuildNetworkPolicies(opts Options) []client.Object {
+	tempo := opts.Tempo
+	spec := tempo.Spec.NetworkPolicy
+	labels := CommonLabels(tempo.Name)
+	podSelector := ComponentLabels(manifestutils.TempoMonolithComponentName, tempo.Name)
+
+	var ingestionPorts, queryPorts []networkingv1.NetworkPolicyPort
+	if tempo.Spec.Multitenancy.IsGatewayEnabled() {
+		ingestionPorts = networkpolicy.Ports(corev1.ProtocolTCP, manifestutils.GatewayPortGRPCServer, manifestutils.GatewayPortHTTPServer)
+		queryPorts = networkpolicy.Ports(corev1.ProtocolTCP, manifestutils.GatewayPortHTTPServer)
+	} else {
+		ingestionPorts = networkpolicy.Ports(corev1.ProtocolTCP, manifestutils.PortOtlpGrpcServer, manifestutils.PortOtlpHttp)
+		queryPorts = networkpolicy.Ports(corev1.ProtocolTCP,
+			manifestutils.PortHTTPServer,
+			manifestutils.PortJaegerUI,
+			manifestutils.PortJaegerGRPCQuery,
+			manifestutils.OAuthProxyPort,
+		)
+	}
+	metricsPorts := networkpolicy.Ports(corev1.ProtocolTCP,
+		manifestutils.PortHTTPServer,
+		manifestutils.PortJaegerMetrics,
+		manifestutils.GatewayPortInternalHTTPServer,
+	)
+
+	return []client.Object{
+		networkpolicy.NetworkPolicy(naming.Name(networkpolicy.IngestionPolicyName, tempo.Name), tempo.Namespace, labels, podSelector,
+			ingestionPorts, networkpolicy.AllowedPeers(spec.AllowSameNamespace, spec.Ingestion)),
+		networkpolicy.NetworkPolicy(naming.Name(networkpolicy.QueryPolicyName, tempo.Name), tempo.Namespace, labels, podSelector,
+			queryPorts, networkpolicy.AllowedPeers(spec.AllowSameNamespace, spec.Query)),
+		networkpolicy.NetworkPolicy(naming.Name(networkpolicy.MetricsPolicyName, tempo.Name), tempo.Namespace, labels, podSelector,
+			metricsPorts, networkpolicy.AllowedPeers(spec.AllowSameNamespace, spec.Metrics)),
+		networkpolicy.EgressNetworkPolicy(naming.Name(networkpolicy.EgressPolicyName, tempo.Name), tempo.Namespace, labels,
+			metav1.LabelSelector{MatchLabels: podSelector},
+			networkpolicy.EgressRules(nil, externalPorts(opts), spec.Egress)),
+	}
+}
+
+// externalPorts returns the ports of the object storage and the remote-write endpoints.
+func externalPorts(opts Options) []int {
+	ports := networkpolicy.StoragePorts(opts.StorageParams)
+	if metricsGenerator := opts.Tempo.Spec.MetricsGenerator; metricsGenerator != nil && metricsGenerator.Enabled {
+		for _, remoteWrite := range metricsGenerator.RemoteWrite {
+			ports = append(ports, networkpolicy.URLPort(remoteWrite.URL))
+		}
+	}
+	return ports
+}
@@ -0,0 +1,144 @@
+package monolithic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildNetworkPolicies(t *testing.T) {
+	grafanaNamespace := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"kubernetes.io/metadata.name": "grafana"},
+		},
+	}
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				NetworkPolicy: &v1alpha1.NetworkPolicySpec{
+					Enabled: true,
+					Query:   []networkingv1.NetworkPolicyPeer{grafanaNamespace},
+				},
+				MetricsGenerator: &v1alpha1.MonolithicMetricsGeneratorSpec{
+					Enabled: true,
+					RemoteWrite: []v1alpha1.MonolithicMetricsGeneratorRemoteWriteSpec{
+						{MetricsGeneratorRemoteWriteSpec: v1alpha1.MetricsGeneratorRemoteWriteSpec{URL: "http://prometheus.monitoring.svc:9090/api/v1/write"}},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{Endpoint: "minio:9000", Insecure: true},
+		},
+	}
+	objects := BuildNetworkPolicies(opts)
+	require.Len(t, objects, 4)
+
+	tcp := ptr.To(corev1.ProtocolTCP)
+	require.Equal(t, &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-sample-allow-query",
+			Namespace: "default",
+			Labels:    CommonLabels("sample"),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: ComponentLabels("tempo", "sample"),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: tcp, Port: ptr.To(intstr.FromInt(3200))},
+					{Protocol: tcp, Port: ptr.To(intstr.FromInt(16686))},
+					{Protocol: tcp, Port: ptr.To(intstr.FromInt(16685))},
+					{Protocol: tcp, Port: ptr.To(intstr.FromInt(8443))},
+				},
+				From: []networkingv1.NetworkPolicyPeer{grafanaNamespace},
+			}},
+		},
+	}, objects[1])
+
+	// no peers are configured, and the pods of the same namespace are not allowed
+	ingestion := objects[0].(*networkingv1.NetworkPolicy)
+	require.Equal(t, "tempo-sample-allow-ingestion", ingestion.Name)
+	require.Empty(t, ingestion.Spec.Ingress)
+
+	udp := ptr.To(corev1.ProtocolUDP)
+	egress := objects[3].(*networkingv1.NetworkPolicy)
+	require.Equal(t, "tempo-sample-allow-egress", egress.Name)
+	require.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, egress.Spec.PolicyTypes)
+	require.Equal(t, metav1.LabelSelector{MatchLabels: ComponentLabels("tempo", "sample")}, egress.Spec.PodSelector)
+	require.Equal(t, []networkingv1.NetworkPolicyEgressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: udp, Port: ptr.To(intstr.FromInt(53))},
+				{Protocol: udp, Port: ptr.To(intstr.FromInt(5353))},
+				{Protocol: tcp, Port: ptr.To(intstr.FromInt(53))},
+				{Protocol: tcp, Port: ptr.To(intstr.FromInt(5353))},
+			},
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: tcp, Port: ptr.To(intstr.FromInt(443))},
+				{Protocol: tcp, Port: ptr.To(intstr.FromInt(6443))},
+				{Protocol: tcp, Port: ptr.To(intstr.FromInt(9000))},
+				{Protocol: tcp, Port: ptr.To(intstr.FromInt(9090))},
+			},
+		},
+	}, egress.Spec.Egress)
+}
+
+func TestBuildNetworkPoliciesGateway(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				Multitenancy: &v1alpha1.MonolithicMultitenancySpec{
+					Enabled: true,
+					TenantsSpec: v1alpha1.TenantsSpec{
+						Mode: v1alpha1.ModeOpenShift,
+						Authentication: []v1alpha1.AuthenticationSpec{
+							{
+								TenantName: "dev",
+								TenantID:   "1610b0c3-c509-4592-a256-a1871353dbfa",
+							},
+						},
+					},
+				},
+				NetworkPolicy: &v1alpha1.NetworkPolicySpec{Enabled: true, AllowSameNamespace: true},
+			},
+		},
+	}
+	objects := BuildNetworkPolicies(opts)
+	require.Len(t, objects, 4)
+
+	tcp := ptr.To(corev1.ProtocolTCP)
+	require.Equal(t, []networkingv1.NetworkPolicyPort{
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(8090))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(8080))},
+	}, objects[0].(*networkingv1.NetworkPolicy).Spec.Ingress[0].Ports)
+	require.Equal(t, []networkingv1.NetworkPolicyPort{
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(8080))},
+	}, objects[1].(*networkingv1.NetworkPolicy).Spec.Ingress[0].Ports)
+	require.Equal(t, []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+		objects[1].(*networkingv1.NetworkPolicy).Spec.Ingress[0].From)
+}
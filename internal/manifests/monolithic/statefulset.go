@@ -144,6 +144,13 @@ func BuildTempoStatefulset(opts Options, extraAnnotations map[string]string) (*a
 		}
 	}
 
+	if metricsGeneratorEnabled(tempo) {
+		err = configureMetricsGenerator(opts, sts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if tempo.Spec.JaegerUI != nil && tempo.Spec.JaegerUI.Enabled {
 		configureJaegerUI(opts, sts)
 
@@ -269,6 +276,12 @@ func configureStorage(opts Options, sts *appsv1.StatefulSet) error {
 		if err != nil {
 			return err
 		}
+		if opts.StorageParams.CredentialMode == v1alpha1.CredentialModeToken {
+			err = manifestutils.ConfigureS3STS(&sts.Spec.Template.Spec, "tempo", opts.StorageParams.S3)
+			if err != nil {
+				return err
+			}
+		}
 
 	case v1alpha1.MonolithicTracesStorageBackendAzure:
 		if tempo.Spec.Storage.Traces.Azure == nil {
@@ -297,6 +310,74 @@ func configureStorage(opts Options, sts *appsv1.StatefulSet) error {
 	return nil
 }
 
+func configureMetricsGenerator(opts Options, sts *appsv1.StatefulSet) error {
+	tempo := opts.Tempo
+	spec := tempo.Spec.MetricsGenerator
+	const volumeName = "tempo-metrics-generator"
+
+	sts.Spec.Template.Spec.Containers[0].VolumeMounts = append(sts.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: metricsGeneratorDir,
+	})
+
+	// the WAL of the metrics-generator is stored on the same kind of volume as the traces
+	if tempo.Spec.Storage.Traces.Backend == v1alpha1.MonolithicTracesStorageBackendMemory {
+		sts.Spec.Template.Spec.Volumes = append(sts.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium:    corev1.StorageMediumMemory,
+					SizeLimit: spec.Size,
+				},
+			},
+		})
+	} else {
+		sts.Spec.VolumeClaimTemplates = append(sts.Spec.VolumeClaimTemplates, corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: volumeName,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: ptr.Deref(spec.Size, tenGBQuantity),
+					},
+				},
+				StorageClassName: tempo.Spec.Storage.Traces.StorageClassName,
+				VolumeMode:       ptr.To(corev1.PersistentVolumeFilesystem),
+			},
+		})
+	}
+
+	for i, rw := range spec.RemoteWrite {
+		if rw.TLS != nil && rw.TLS.Enabled {
+			err := manifestutils.MountTLSSpecVolumes(
+				&sts.Spec.Template.Spec, "tempo", *rw.TLS,
+				remoteWriteDir(manifestutils.RemoteWriteTLSCADir, i), remoteWriteDir(manifestutils.RemoteWriteTLSCertDir, i),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if rw.BasicAuth != nil {
+			err := manifestutils.MountCertSecret(&sts.Spec.Template.Spec, "tempo", rw.BasicAuth.Secret, remoteWriteDir(manifestutils.RemoteWriteAuthDir, i))
+			if err != nil {
+				return err
+			}
+		}
+
+		if rw.BearerToken != nil {
+			err := manifestutils.MountCertSecret(&sts.Spec.Template.Spec, "tempo", rw.BearerToken.Secret, remoteWriteDir(manifestutils.RemoteWriteAuthDir, i))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func configureJaegerUI(opts Options, sts *appsv1.StatefulSet) {
 	const tmpVolumeName = "tempo-query-tmp"
 	tempo := opts.Tempo
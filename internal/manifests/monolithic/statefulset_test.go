@@ -1351,3 +1351,122 @@ func TestStatefulsetPodSecurityContext(t *testing.T) {
 		RunAsGroup: ptr.To(int64(10001)),
 	}, sts.Spec.Template.Spec.SecurityContext)
 }
+
+func TestStatefulsetMetricsGenerator(t *testing.T) {
+	opts := Options{
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Tempo: "docker.io/grafana/tempo:x.y.z",
+			},
+		},
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				Storage: &v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: "pv",
+						Size:    &tenGBQuantity,
+					},
+				},
+				MetricsGenerator: &v1alpha1.MonolithicMetricsGeneratorSpec{
+					Enabled: true,
+					Size:    ptr.To(resource.MustParse("5Gi")),
+					RemoteWrite: []v1alpha1.MonolithicMetricsGeneratorRemoteWriteSpec{
+						{
+							MetricsGeneratorRemoteWriteSpec: v1alpha1.MetricsGeneratorRemoteWriteSpec{
+								URL: "https://prometheus:9090/api/v1/write",
+							},
+							TLS: &v1alpha1.TLSSpec{
+								Enabled: true,
+								CA:      "ca",
+								Cert:    "cert",
+							},
+							BasicAuth: &v1alpha1.MonolithicRemoteWriteBasicAuthSpec{
+								Secret: "basic-auth",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	sts, err := BuildTempoStatefulset(opts, map[string]string{})
+	require.NoError(t, err)
+
+	require.Len(t, sts.Spec.VolumeClaimTemplates, 2)
+	require.Equal(t, "tempo-metrics-generator", sts.Spec.VolumeClaimTemplates[1].Name)
+	require.Equal(t, resource.MustParse("5Gi"), sts.Spec.VolumeClaimTemplates[1].Spec.Resources.Requests[corev1.ResourceStorage])
+
+	require.Equal(t, []corev1.VolumeMount{
+		{
+			Name:      manifestutils.ConfigVolumeName,
+			MountPath: "/conf",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "tempo-storage",
+			MountPath: "/var/tempo",
+		},
+		{
+			Name:      "tempo-metrics-generator",
+			MountPath: "/var/tempo/generator",
+		},
+		{
+			Name:      "ca",
+			MountPath: "/var/run/tls/remote-write/ca/0",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "cert",
+			MountPath: "/var/run/tls/remote-write/cert/0",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "basic-auth",
+			MountPath: "/var/run/secrets/remote-write/0",
+			ReadOnly:  true,
+		},
+	}, sts.Spec.Template.Spec.Containers[0].VolumeMounts)
+
+	require.Equal(t, []corev1.Volume{
+		{
+			Name: manifestutils.ConfigVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "tempo-sample-config",
+					},
+				},
+			},
+		},
+		{
+			Name: "ca",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "ca",
+					},
+				},
+			},
+		},
+		{
+			Name: "cert",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: "cert",
+				},
+			},
+		},
+		{
+			Name: "basic-auth",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: "basic-auth",
+				},
+			},
+		},
+	}, sts.Spec.Template.Spec.Volumes)
+}
@@ -1,6 +1,30 @@
 package monolithic
 
-import "github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+import (
+	"path"
+	"strconv"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+)
+
+const (
+	// metricsGeneratorDir is the directory of the metrics-generator WAL and local blocks.
+	metricsGeneratorDir = "/var/tempo/generator"
+
+	// configDir is the mount path of the Tempo ConfigMap.
+	configDir = "/conf"
+	// tenantOverridesFilename is the key of the per-tenant overrides in the Tempo ConfigMap.
+	tenantOverridesFilename = "overrides.yaml"
+)
+
+// remoteWriteDir returns the mount path of the credentials of the i-th remote-write endpoint.
+func remoteWriteDir(baseDir string, i int) string {
+	return path.Join(baseDir, strconv.Itoa(i))
+}
+
+func metricsGeneratorEnabled(tempo v1alpha1.TempoMonolithic) bool {
+	return tempo.Spec.MetricsGenerator != nil && tempo.Spec.MetricsGenerator.Enabled
+}
 
 func tlsSecretAndBundleEmptyGRPC(tempo v1alpha1.TempoMonolithic) bool {
 	if tempo.Spec.Ingestion != nil && tempo.Spec.Ingestion.OTLP != nil &&
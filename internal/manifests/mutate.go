@@ -12,12 +12,15 @@ import (
 	cloudcredentialv1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // ImmutableErr occurs if an immutable field should be changed.
@@ -38,6 +41,11 @@ func (m *ImmutableErr) Error() string {
 // - Service
 // - Deployment
 // - StatefulSet
+// - HorizontalPodAutoscaler
+// - PodDisruptionBudget
+// - NetworkPolicy
+// - HTTPRoute
+// - GRPCRoute
 // - ServiceMonitor
 // - Secret.
 func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
@@ -104,6 +112,21 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantSts := desired.(*appsv1.StatefulSet)
 			return mutateStatefulSet(sts, wantSts)
 
+		case *autoscalingv2.HorizontalPodAutoscaler:
+			hpa := existing.(*autoscalingv2.HorizontalPodAutoscaler)
+			wantHpa := desired.(*autoscalingv2.HorizontalPodAutoscaler)
+			mutateHorizontalPodAutoscaler(hpa, wantHpa)
+
+		case *policyv1.PodDisruptionBudget:
+			pdb := existing.(*policyv1.PodDisruptionBudget)
+			wantPdb := desired.(*policyv1.PodDisruptionBudget)
+			mutatePodDisruptionBudget(pdb, wantPdb)
+
+		case *networkingv1.NetworkPolicy:
+			np := existing.(*networkingv1.NetworkPolicy)
+			wantNp := desired.(*networkingv1.NetworkPolicy)
+			mutateNetworkPolicy(np, wantNp)
+
 		case *monitoringv1.ServiceMonitor:
 			svcMonitor := existing.(*monitoringv1.ServiceMonitor)
 			wantSvcMonitor := desired.(*monitoringv1.ServiceMonitor)
@@ -119,6 +142,16 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantRt := desired.(*routev1.Route)
 			mutateRoute(rt, wantRt)
 
+		case *gatewayv1.HTTPRoute:
+			rt := existing.(*gatewayv1.HTTPRoute)
+			wantRt := desired.(*gatewayv1.HTTPRoute)
+			mutateHTTPRoute(rt, wantRt)
+
+		case *gatewayv1.GRPCRoute:
+			rt := existing.(*gatewayv1.GRPCRoute)
+			wantRt := desired.(*gatewayv1.GRPCRoute)
+			mutateGRPCRoute(rt, wantRt)
+
 		case *monitoringv1.PrometheusRule:
 			pr := existing.(*monitoringv1.PrometheusRule)
 			wantPr := desired.(*monitoringv1.PrometheusRule)
@@ -218,6 +251,24 @@ func mutateServiceMonitor(existing, desired *monitoringv1.ServiceMonitor) {
 	existing.Spec = desired.Spec
 }
 
+func mutateHorizontalPodAutoscaler(existing, desired *autoscalingv2.HorizontalPodAutoscaler) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
+func mutatePodDisruptionBudget(existing, desired *policyv1.PodDisruptionBudget) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
+func mutateNetworkPolicy(existing, desired *networkingv1.NetworkPolicy) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutateIngress(existing, desired *networkingv1.Ingress) {
 	existing.Labels = desired.Labels
 	existing.Annotations = desired.Annotations
@@ -232,6 +283,18 @@ func mutateRoute(existing, desired *routev1.Route) {
 	existing.Spec = desired.Spec
 }
 
+func mutateHTTPRoute(existing, desired *gatewayv1.HTTPRoute) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
+func mutateGRPCRoute(existing, desired *gatewayv1.GRPCRoute) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutatePrometheusRule(existing, desired *monitoringv1.PrometheusRule) {
 	existing.Annotations = desired.Annotations
 	existing.Labels = desired.Labels
@@ -264,7 +327,10 @@ func mutateDeployment(existing, desired *appsv1.Deployment) error {
 	if existing.CreationTimestamp.IsZero() {
 		existing.Spec.Selector = desired.Spec.Selector
 	}
-	existing.Spec.Replicas = desired.Spec.Replicas
+	// The replicas are not set if a HorizontalPodAutoscaler manages the replicas of this Deployment.
+	if desired.Spec.Replicas != nil {
+		existing.Spec.Replicas = desired.Spec.Replicas
+	}
 	if err := mergeWithOverride(&existing.Spec.Template, desired.Spec.Template); err != nil {
 		return err
 	}
@@ -0,0 +1,329 @@
+package networkpolicy
+
+import (
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/memcached"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	// InternalPolicyName is the name suffix of the NetworkPolicy allowing the traffic between the Tempo pods.
+	InternalPolicyName = "allow-internal"
+	// IngestionPolicyName is the name suffix of the NetworkPolicy allowing the traffic to the receivers.
+	IngestionPolicyName = "allow-ingestion"
+	// QueryPolicyName is the name suffix of the NetworkPolicy allowing the traffic to the query APIs and the Jaeger UI.
+	QueryPolicyName = "allow-query"
+	// MetricsPolicyName is the name suffix of the NetworkPolicy allowing the traffic to the metrics endpoints.
+	MetricsPolicyName = "allow-metrics"
+	// EgressPolicyName is the name suffix of the NetworkPolicy restricting the egress traffic of the Tempo pods.
+	EgressPolicyName = "allow-egress"
+
+	httpsPort         = 443
+	httpPort          = 80
+	kubernetesAPIPort = 6443
+	kafkaPort         = 9092
+	redisPort         = 6379
+)
+
+// tempoComponents are the components of a TempoStack which run Tempo.
+// The egress of the gateway is not restricted, because it reaches the OIDC issuers of the tenants.
+var tempoComponents = []string{
+	manifestutils.CompactorComponentName,
+	manifestutils.DistributorComponentName,
+	manifestutils.IngesterComponentName,
+	manifestutils.QuerierComponentName,
+	manifestutils.QueryFrontendComponentName,
+	manifestutils.MetricsGeneratorComponentName,
+	manifestutils.BlockBuilderComponentName,
+}
+
+// Enabled returns true if NetworkPolicies should be created.
+func Enabled(spec *v1alpha1.NetworkPolicySpec) bool {
+	return spec != nil && spec.Enabled
+}
+
+// BuildNetworkPolicies creates the NetworkPolicies of a TempoStack.
+// The traffic between the pods of the TempoStack is only allowed on the ports used by Tempo,
+// other traffic is only allowed to the receivers, query APIs and metrics endpoints from the peers
+// configured in the CR, and optionally from the pods of the same namespace.
+// The operator is allowed to access the metrics endpoints to observe the runtime configuration.
+// The egress of the Tempo pods is restricted to DNS, the Kubernetes API and the external services of the TempoStack.
+func BuildNetworkPolicies(params manifestutils.Params) []client.Object {
+	tempo := params.Tempo
+	spec := tempo.Spec.NetworkPolicy
+	if !Enabled(spec) {
+		return nil
+	}
+
+	commonLabels := manifestutils.CommonLabels(tempo.Name)
+	internalPorts := Ports(corev1.ProtocolTCP,
+		manifestutils.PortMemberlist,
+		manifestutils.PortGRPCServer,
+		manifestutils.PortHTTPServer,
+		// the gateway forwards the traces to the distributor and the Jaeger UI requests to the query-frontend
+		manifestutils.PortOtlpGrpcServer,
+		manifestutils.PortOtlpHttp,
+		manifestutils.PortJaegerUI,
+	)
+	if memcached.ManagedEnabled(tempo) {
+		internalPorts = append(internalPorts, Ports(corev1.ProtocolTCP, memcached.Port)...)
+	}
+	internal := NetworkPolicy(naming.Name(InternalPolicyName, tempo.Name), tempo.Namespace, commonLabels, commonLabels, internalPorts,
+		[]networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: commonLabels}}})
+
+	var ingestion, query *networkingv1.NetworkPolicy
+	if tempo.Spec.Template.Gateway.Enabled {
+		gatewayLabels := manifestutils.ComponentLabels(manifestutils.GatewayComponentName, tempo.Name)
+		ingestion = NetworkPolicy(naming.Name(IngestionPolicyName, tempo.Name), tempo.Namespace, commonLabels, gatewayLabels,
+			Ports(corev1.ProtocolTCP, manifestutils.GatewayPortGRPCServer, manifestutils.GatewayPortHTTPServer),
+			AllowedPeers(spec.AllowSameNamespace, spec.Ingestion))
+		query = NetworkPolicy(naming.Name(QueryPolicyName, tempo.Name), tempo.Namespace, commonLabels, gatewayLabels,
+			Ports(corev1.ProtocolTCP, manifestutils.GatewayPortHTTPServer),
+			AllowedPeers(spec.AllowSameNamespace, spec.Query))
+	} else {
+		ingestion = NetworkPolicy(naming.Name(IngestionPolicyName, tempo.Name), tempo.Namespace, commonLabels,
+			manifestutils.ComponentLabels(manifestutils.DistributorComponentName, tempo.Name),
+			append(Ports(corev1.ProtocolTCP,
+				manifestutils.PortOtlpGrpcServer,
+				manifestutils.PortOtlpHttp,
+				manifestutils.PortJaegerGrpc,
+				manifestutils.PortJaegerThriftHTTP,
+				manifestutils.PortZipkin,
+			), Ports(corev1.ProtocolUDP,
+				manifestutils.PortJaegerThriftCompact,
+				manifestutils.PortJaegerThriftBinary,
+			)...),
+			AllowedPeers(spec.AllowSameNamespace, spec.Ingestion))
+		query = NetworkPolicy(naming.Name(QueryPolicyName, tempo.Name), tempo.Namespace, commonLabels,
+			manifestutils.ComponentLabels(manifestutils.QueryFrontendComponentName, tempo.Name),
+			Ports(corev1.ProtocolTCP,
+				manifestutils.PortHTTPServer,
+				manifestutils.PortJaegerUI,
+				manifestutils.PortJaegerGRPCQuery,
+				manifestutils.OAuthProxyPort,
+			),
+			AllowedPeers(spec.AllowSameNamespace, spec.Query))
+	}
+
+	metrics := NetworkPolicy(naming.Name(MetricsPolicyName, tempo.Name), tempo.Namespace, commonLabels, commonLabels,
+		Ports(corev1.ProtocolTCP,
+			manifestutils.PortHTTPServer,
+			manifestutils.PortJaegerMetrics,
+			manifestutils.GatewayPortInternalHTTPServer,
+		),
+		append(AllowedPeers(spec.AllowSameNamespace, spec.Metrics), OperatorPeer()))
+
+	tempoPods := metav1.LabelSelector{
+		MatchLabels: commonLabels,
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "app.kubernetes.io/component",
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   tempoComponents,
+		}},
+	}
+	egress := EgressNetworkPolicy(naming.Name(EgressPolicyName, tempo.Name), tempo.Namespace, commonLabels, tempoPods,
+		EgressRules(
+			&networkingv1.NetworkPolicyEgressRule{
+				Ports: internalPorts,
+				To:    []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: commonLabels}}},
+			},
+			tempoStackExternalPorts(params),
+			spec.Egress,
+		))
+
+	return []client.Object{internal, ingestion, query, metrics, egress}
+}
+
+// tempoStackExternalPorts returns the ports of the object storage, Kafka, the caches and the remote-write endpoints of a TempoStack.
+func tempoStackExternalPorts(params manifestutils.Params) []int {
+	tempo := params.Tempo
+	ports := StoragePorts(params.StorageParams)
+
+	if tempo.Spec.Ingest != nil && tempo.Spec.Ingest.Kafka != nil {
+		for _, broker := range tempo.Spec.Ingest.Kafka.Brokers {
+			ports = append(ports, HostPort(broker, kafkaPort))
+		}
+	}
+
+	if cache := tempo.Spec.Cache; cache != nil {
+		switch {
+		case cache.Backend == v1alpha1.CacheBackendMemcached && cache.Memcached != nil && !cache.Memcached.Managed.Enabled:
+			// the memcached servers are discovered with a DNS SRV lookup,
+			// other ports must be allowed with additional egress rules
+			ports = append(ports, memcached.Port)
+		case cache.Backend == v1alpha1.CacheBackendRedis && cache.Redis != nil:
+			ports = append(ports, HostPort(cache.Redis.Endpoint, redisPort))
+		}
+	}
+
+	for _, remoteWrite := range tempo.Spec.Template.MetricsGenerator.RemoteWrite {
+		ports = append(ports, URLPort(remoteWrite.URL))
+	}
+	return ports
+}
+
+// StoragePorts returns the port of the S3 endpoint.
+// Azure Storage and Google Cloud Storage are reached via HTTPS, which is always allowed.
+func StoragePorts(params manifestutils.StorageParams) []int {
+	if params.S3 == nil || params.S3.Endpoint == "" {
+		return nil
+	}
+
+	defaultPort := httpsPort
+	if params.S3.Insecure {
+		defaultPort = httpPort
+	}
+	return []int{HostPort(params.S3.Endpoint, defaultPort)}
+}
+
+// HostPort returns the port of a host:port address, or the default port if the address does not contain a port.
+func HostPort(address string, defaultPort int) int {
+	_, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return defaultPort
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return defaultPort
+	}
+	return port
+}
+
+// URLPort returns the port of a URL, or the default port of the scheme if the URL does not contain a port.
+func URLPort(rawURL string) int {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return httpsPort
+	}
+
+	defaultPort := httpsPort
+	if u.Scheme == "http" {
+		defaultPort = httpPort
+	}
+	return HostPort(u.Host, defaultPort)
+}
+
+// EgressRules returns the egress rules of the Tempo pods.
+// DNS, the Kubernetes API and HTTPS are always allowed, the latter is used by the OAuth proxy,
+// the cloud object storages and the token exchange of short-lived credentials.
+// The ports of the external services are allowed to all destinations, because the services
+// might run outside of the cluster.
+func EgressRules(internal *networkingv1.NetworkPolicyEgressRule, externalPorts []int, additional []networkingv1.NetworkPolicyEgressRule) []networkingv1.NetworkPolicyEgressRule {
+	var rules []networkingv1.NetworkPolicyEgressRule
+	if internal != nil {
+		rules = append(rules, *internal)
+	}
+
+	rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+		// OpenShift serves DNS on port 5353
+		Ports: append(Ports(corev1.ProtocolUDP, 53, 5353), Ports(corev1.ProtocolTCP, 53, 5353)...),
+	})
+
+	ports := append([]int{httpsPort, kubernetesAPIPort}, externalPorts...)
+	slices.Sort(ports)
+	rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+		Ports: Ports(corev1.ProtocolTCP, slices.Compact(ports)...),
+	})
+
+	return append(rules, additional...)
+}
+
+// OperatorPeer selects the operator pods in all namespaces.
+// The operator queries the /status/runtime_config endpoint of the Tempo pods.
+func OperatorPeer() networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app.kubernetes.io/name": "tempo-operator",
+				"control-plane":          "controller-manager",
+			},
+		},
+	}
+}
+
+// AllowedPeers returns the additional peers, and the pods of the same namespace if allowSameNamespace is set.
+func AllowedPeers(allowSameNamespace bool, additional []networkingv1.NetworkPolicyPeer) []networkingv1.NetworkPolicyPeer {
+	var peers []networkingv1.NetworkPolicyPeer
+	if allowSameNamespace {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}})
+	}
+	return append(peers, additional...)
+}
+
+// Ports returns a NetworkPolicyPort for every port number.
+func Ports(protocol corev1.Protocol, ports ...int) []networkingv1.NetworkPolicyPort {
+	policyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{
+			Protocol: ptr.To(protocol),
+			Port:     ptr.To(intstr.FromInt(port)),
+		})
+	}
+	return policyPorts
+}
+
+// NetworkPolicy creates an ingress NetworkPolicy, which allows traffic from the peers to the ports of the selected pods.
+// If no peers are given, the policy does not allow any traffic, other policies selecting the same pods still apply.
+func NetworkPolicy(name, namespace string, labels, podSelector map[string]string, ports []networkingv1.NetworkPolicyPort, peers []networkingv1.NetworkPolicyPeer) *networkingv1.NetworkPolicy {
+	// a rule without peers would allow the traffic from all sources
+	var rules []networkingv1.NetworkPolicyIngressRule
+	if len(peers) > 0 {
+		rules = []networkingv1.NetworkPolicyIngressRule{{
+			Ports: ports,
+			From:  peers,
+		}}
+	}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: podSelector,
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     rules,
+		},
+	}
+}
+
+// EgressNetworkPolicy creates an egress NetworkPolicy, which only allows the egress traffic of the selected pods matching the rules.
+func EgressNetworkPolicy(name, namespace string, labels map[string]string, podSelector metav1.LabelSelector, rules []networkingv1.NetworkPolicyEgressRule) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: podSelector,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      rules,
+		},
+	}
+}
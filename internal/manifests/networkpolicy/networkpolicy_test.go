@@ -0,0 +1,264 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildNetworkPolicies_Disabled(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+	}
+	assert.Empty(t, BuildNetworkPolicies(manifestutils.Params{Tempo: tempo}))
+
+	tempo.Spec.NetworkPolicy = &v1alpha1.NetworkPolicySpec{Enabled: false}
+	assert.Empty(t, BuildNetworkPolicies(manifestutils.Params{Tempo: tempo}))
+}
+
+func TestBuildNetworkPolicies(t *testing.T) {
+	collectorNamespace := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"kubernetes.io/metadata.name": "otel"},
+		},
+	}
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			NetworkPolicy: &v1alpha1.NetworkPolicySpec{
+				Enabled:   true,
+				Ingestion: []networkingv1.NetworkPolicyPeer{collectorNamespace},
+			},
+		},
+	}
+
+	objects := BuildNetworkPolicies(manifestutils.Params{Tempo: tempo})
+	require.Len(t, objects, 5)
+
+	names := []string{}
+	for _, obj := range objects {
+		names = append(names, obj.GetName())
+	}
+	assert.Equal(t, []string{
+		"tempo-test-allow-internal",
+		"tempo-test-allow-ingestion",
+		"tempo-test-allow-query",
+		"tempo-test-allow-metrics",
+		"tempo-test-allow-egress",
+	}, names)
+
+	tcp := ptr.To(corev1.ProtocolTCP)
+	udp := ptr.To(corev1.ProtocolUDP)
+	commonLabels := manifestutils.CommonLabels("test")
+	internalPorts := []networkingv1.NetworkPolicyPort{
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(7946))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(9095))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(3200))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(4317))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(4318))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(16686))},
+	}
+	internal := objects[0].(*networkingv1.NetworkPolicy)
+	assert.Equal(t, commonLabels, internal.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []networkingv1.NetworkPolicyIngressRule{{
+		Ports: internalPorts,
+		From:  []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: commonLabels}}},
+	}}, internal.Spec.Ingress)
+
+	assert.Equal(t, &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-allow-ingestion",
+			Namespace: "project1",
+			Labels:    commonLabels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: manifestutils.ComponentLabels(manifestutils.DistributorComponentName, "test"),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: tcp, Port: ptr.To(intstr.FromInt(4317))},
+					{Protocol: tcp, Port: ptr.To(intstr.FromInt(4318))},
+					{Protocol: tcp, Port: ptr.To(intstr.FromInt(14250))},
+					{Protocol: tcp, Port: ptr.To(intstr.FromInt(14268))},
+					{Protocol: tcp, Port: ptr.To(intstr.FromInt(9411))},
+					{Protocol: udp, Port: ptr.To(intstr.FromInt(6831))},
+					{Protocol: udp, Port: ptr.To(intstr.FromInt(6832))},
+				},
+				From: []networkingv1.NetworkPolicyPeer{collectorNamespace},
+			}},
+		},
+	}, objects[1])
+
+	query := objects[2].(*networkingv1.NetworkPolicy)
+	assert.Equal(t, map[string]string(manifestutils.ComponentLabels(manifestutils.QueryFrontendComponentName, "test")), query.Spec.PodSelector.MatchLabels)
+	// no peers are configured, and the pods of the same namespace are not allowed
+	assert.Empty(t, query.Spec.Ingress)
+
+	metrics := objects[3].(*networkingv1.NetworkPolicy)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: &metav1.LabelSelector{},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name": "tempo-operator",
+					"control-plane":          "controller-manager",
+				},
+			},
+		},
+	}, metrics.Spec.Ingress[0].From)
+
+	egress := objects[4].(*networkingv1.NetworkPolicy)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, egress.Spec.PolicyTypes)
+	assert.Equal(t, metav1.LabelSelector{
+		MatchLabels: commonLabels,
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "app.kubernetes.io/component",
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{"compactor", "distributor", "ingester", "querier", "query-frontend", "metrics-generator", "block-builder"},
+		}},
+	}, egress.Spec.PodSelector)
+	assert.Equal(t, []networkingv1.NetworkPolicyEgressRule{
+		{
+			Ports: internalPorts,
+			To:    []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: commonLabels}}},
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: udp, Port: ptr.To(intstr.FromInt(53))},
+				{Protocol: udp, Port: ptr.To(intstr.FromInt(5353))},
+				{Protocol: tcp, Port: ptr.To(intstr.FromInt(53))},
+				{Protocol: tcp, Port: ptr.To(intstr.FromInt(5353))},
+			},
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: tcp, Port: ptr.To(intstr.FromInt(443))},
+				{Protocol: tcp, Port: ptr.To(intstr.FromInt(6443))},
+			},
+		},
+	}, egress.Spec.Egress)
+}
+
+func TestBuildNetworkPolicies_Egress(t *testing.T) {
+	tcp := ptr.To(corev1.ProtocolTCP)
+	ldapRule := networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{{Protocol: tcp, Port: ptr.To(intstr.FromInt(636))}},
+	}
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			NetworkPolicy: &v1alpha1.NetworkPolicySpec{
+				Enabled: true,
+				Egress:  []networkingv1.NetworkPolicyEgressRule{ldapRule},
+			},
+			Ingest: &v1alpha1.IngestSpec{
+				Kafka: &v1alpha1.KafkaIngestSpec{Brokers: []string{"kafka-0:9093", "kafka-1:9093", "kafka-2"}},
+			},
+			Cache: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Memcached: &v1alpha1.MemcachedSpec{
+					Managed: v1alpha1.ManagedMemcachedSpec{Enabled: true},
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+					Enabled: true,
+					RemoteWrite: []v1alpha1.MetricsGeneratorRemoteWriteSpec{
+						{URL: "http://prometheus.monitoring.svc:9090/api/v1/write"},
+						{URL: "https://mimir.example.com/api/v1/push"},
+					},
+				},
+			},
+		},
+	}
+
+	objects := BuildNetworkPolicies(manifestutils.Params{
+		Tempo: tempo,
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{Endpoint: "minio.minio.svc:9000"},
+		},
+	})
+	require.Len(t, objects, 5)
+
+	internal := objects[0].(*networkingv1.NetworkPolicy)
+	assert.Contains(t, internal.Spec.Ingress[0].Ports, networkingv1.NetworkPolicyPort{Protocol: tcp, Port: ptr.To(intstr.FromInt(11211))})
+
+	egress := objects[4].(*networkingv1.NetworkPolicy)
+	require.Len(t, egress.Spec.Egress, 4)
+	assert.Contains(t, egress.Spec.Egress[0].Ports, networkingv1.NetworkPolicyPort{Protocol: tcp, Port: ptr.To(intstr.FromInt(11211))})
+	assert.Equal(t, []networkingv1.NetworkPolicyPort{
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(443))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(6443))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(9000))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(9090))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(9092))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(9093))},
+	}, egress.Spec.Egress[2].Ports)
+	assert.Equal(t, ldapRule, egress.Spec.Egress[3])
+}
+
+func TestBuildNetworkPolicies_Gateway(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			NetworkPolicy: &v1alpha1.NetworkPolicySpec{Enabled: true, AllowSameNamespace: true},
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{Enabled: true},
+			},
+		},
+	}
+
+	objects := BuildNetworkPolicies(manifestutils.Params{Tempo: tempo})
+	require.Len(t, objects, 5)
+
+	gatewayLabels := map[string]string(manifestutils.ComponentLabels(manifestutils.GatewayComponentName, "test"))
+	tcp := ptr.To(corev1.ProtocolTCP)
+
+	ingestion := objects[1].(*networkingv1.NetworkPolicy)
+	assert.Equal(t, gatewayLabels, ingestion.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []networkingv1.NetworkPolicyPort{
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(8090))},
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(8080))},
+	}, ingestion.Spec.Ingress[0].Ports)
+
+	query := objects[2].(*networkingv1.NetworkPolicy)
+	assert.Equal(t, gatewayLabels, query.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []networkingv1.NetworkPolicyPort{
+		{Protocol: tcp, Port: ptr.To(intstr.FromInt(8080))},
+	}, query.Spec.Ingress[0].Ports)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}, query.Spec.Ingress[0].From)
+}
+
+func TestURLPort(t *testing.T) {
+	assert.Equal(t, 9090, URLPort("http://prometheus:9090/api/v1/write"))
+	assert.Equal(t, 80, URLPort("http://prometheus/api/v1/write"))
+	assert.Equal(t, 443, URLPort("https://prometheus/api/v1/write"))
+	assert.Equal(t, 8443, URLPort("https://[::1]:8443/api/v1/write"))
+}
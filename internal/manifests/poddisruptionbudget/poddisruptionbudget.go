@@ -0,0 +1,86 @@
+package poddisruptionbudget
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/memcached"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// BuildPodDisruptionBudgets creates a PodDisruptionBudget for every enabled TempoStack component.
+func BuildPodDisruptionBudgets(params manifestutils.Params) []client.Object {
+	tempo := params.Tempo
+	tpl := tempo.Spec.Template
+
+	objects := []client.Object{
+		podDisruptionBudget(tempo, manifestutils.DistributorComponentName, tpl.Distributor.PodDisruptionBudget, intstr.FromInt32(1)),
+		podDisruptionBudget(tempo, manifestutils.IngesterComponentName, tpl.Ingester.PodDisruptionBudget, ingesterMaxUnavailable(tempo.Spec.ReplicationFactor)),
+		podDisruptionBudget(tempo, manifestutils.QuerierComponentName, tpl.Querier.PodDisruptionBudget, intstr.FromInt32(1)),
+		podDisruptionBudget(tempo, manifestutils.QueryFrontendComponentName, tpl.QueryFrontend.PodDisruptionBudget, intstr.FromInt32(1)),
+		podDisruptionBudget(tempo, manifestutils.CompactorComponentName, tpl.Compactor.PodDisruptionBudget, intstr.FromInt32(1)),
+	}
+
+	if tpl.MetricsGenerator.Enabled {
+		objects = append(objects, podDisruptionBudget(tempo, manifestutils.MetricsGeneratorComponentName, tpl.MetricsGenerator.PodDisruptionBudget, intstr.FromInt32(1)))
+	}
+	if tpl.Gateway.Enabled {
+		objects = append(objects, podDisruptionBudget(tempo, manifestutils.GatewayComponentName, tpl.Gateway.PodDisruptionBudget, intstr.FromInt32(1)))
+	}
+	if manifestutils.KafkaIngestEnabled(tempo) {
+		objects = append(objects, podDisruptionBudget(tempo, manifestutils.BlockBuilderComponentName, tpl.BlockBuilder.PodDisruptionBudget, intstr.FromInt32(1)))
+	}
+	if memcached.ManagedEnabled(tempo) {
+		objects = append(objects, podDisruptionBudget(tempo, manifestutils.MemcachedComponentName, tempo.Spec.Cache.Memcached.Managed.PodDisruptionBudget, intstr.FromInt32(1)))
+	}
+
+	return objects
+}
+
+// ingesterMaxUnavailable returns the number of ingesters which can be unavailable
+// without losing the write quorum of the replication factor.
+func ingesterMaxUnavailable(replicationFactor int) intstr.IntOrString {
+	quorum := replicationFactor/2 + 1
+	maxUnavailable := replicationFactor - quorum
+	// A PodDisruptionBudget which does not allow any disruption blocks node drains,
+	// therefore always allow at least one unavailable ingester.
+	if maxUnavailable < 1 {
+		maxUnavailable = 1
+	}
+	return intstr.FromInt(maxUnavailable)
+}
+
+func podDisruptionBudget(tempo v1alpha1.TempoStack, componentName string, spec *v1alpha1.PodDisruptionBudgetSpec, defaultMaxUnavailable intstr.IntOrString) *policyv1.PodDisruptionBudget {
+	labels := manifestutils.ComponentLabels(componentName, tempo.Name)
+
+	pdbSpec := policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+	}
+	switch {
+	case spec != nil && spec.MinAvailable != nil:
+		pdbSpec.MinAvailable = spec.MinAvailable
+	case spec != nil && spec.MaxUnavailable != nil:
+		pdbSpec.MaxUnavailable = spec.MaxUnavailable
+	default:
+		pdbSpec.MaxUnavailable = &defaultMaxUnavailable
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(componentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: pdbSpec,
+	}
+}
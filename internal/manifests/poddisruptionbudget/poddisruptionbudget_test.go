@@ -0,0 +1,133 @@
+package poddisruptionbudget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildPodDisruptionBudgets(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ReplicationFactor: 3,
+		},
+	}
+
+	objects := BuildPodDisruptionBudgets(manifestutils.Params{Tempo: tempo})
+	require.Len(t, objects, 5)
+
+	names := []string{}
+	for _, obj := range objects {
+		names = append(names, obj.GetName())
+	}
+	assert.Equal(t, []string{
+		"tempo-test-distributor",
+		"tempo-test-ingester",
+		"tempo-test-querier",
+		"tempo-test-query-frontend",
+		"tempo-test-compactor",
+	}, names)
+
+	labels := manifestutils.ComponentLabels(manifestutils.IngesterComponentName, "test")
+	assert.Equal(t, &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "policy/v1",
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-ingester",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			MaxUnavailable: ptr.To(intstr.FromInt(1)),
+		},
+	}, objects[1])
+}
+
+func TestBuildPodDisruptionBudgetsOptionalComponents(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ReplicationFactor: 1,
+			Template: v1alpha1.TempoTemplateSpec{
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{Enabled: true},
+				Gateway:          v1alpha1.TempoGatewaySpec{Enabled: true},
+			},
+			Cache: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Memcached: &v1alpha1.MemcachedSpec{
+					Managed: v1alpha1.ManagedMemcachedSpec{Enabled: true},
+				},
+			},
+			Ingest: &v1alpha1.IngestSpec{
+				Kafka: &v1alpha1.KafkaIngestSpec{Brokers: []string{"kafka:9092"}, Topic: "tempo-ingest"},
+			},
+		},
+	}
+
+	objects := BuildPodDisruptionBudgets(manifestutils.Params{Tempo: tempo})
+	require.Len(t, objects, 9)
+	assert.Equal(t, "tempo-test-metrics-generator", objects[5].GetName())
+	assert.Equal(t, "tempo-test-gateway", objects[6].GetName())
+	assert.Equal(t, "tempo-test-block-builder", objects[7].GetName())
+	assert.Equal(t, "tempo-test-memcached", objects[8].GetName())
+}
+
+func TestBuildPodDisruptionBudgetsOverride(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ReplicationFactor: 3,
+			Template: v1alpha1.TempoTemplateSpec{
+				Querier: v1alpha1.TempoQuerierSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						PodDisruptionBudget: &v1alpha1.PodDisruptionBudgetSpec{
+							MinAvailable: ptr.To(intstr.FromString("50%")),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	objects := BuildPodDisruptionBudgets(manifestutils.Params{Tempo: tempo})
+	pdb, ok := objects[2].(*policyv1.PodDisruptionBudget)
+	require.True(t, ok)
+	assert.Equal(t, ptr.To(intstr.FromString("50%")), pdb.Spec.MinAvailable)
+	assert.Nil(t, pdb.Spec.MaxUnavailable)
+}
+
+func TestIngesterMaxUnavailable(t *testing.T) {
+	tests := []struct {
+		replicationFactor int
+		expected          int
+	}{
+		{replicationFactor: 1, expected: 1},
+		{replicationFactor: 2, expected: 1},
+		{replicationFactor: 3, expected: 1},
+		{replicationFactor: 5, expected: 2},
+	}
+	for _, test := range tests {
+		assert.Equal(t, intstr.FromInt(test.expected), ingesterMaxUnavailable(test.replicationFactor))
+	}
+}
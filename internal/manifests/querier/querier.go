@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/autoscaling"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
@@ -45,7 +46,11 @@ func BuildQuerier(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
-	return []client.Object{d, service(tempo)}, nil
+	objects := []client.Object{d, service(tempo)}
+	if hpa := tempo.Spec.Template.Querier.Autoscaling; autoscaling.Enabled(hpa) {
+		objects = append(objects, autoscaling.BuildHorizontalPodAutoscaler(tempo, manifestutils.QuerierComponentName, *hpa))
+	}
+	return objects, nil
 }
 
 func resources(tempo v1alpha1.TempoStack) corev1.ResourceRequirements {
@@ -59,7 +64,7 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 	tempo := params.Tempo
 	labels := manifestutils.ComponentLabels(manifestutils.QuerierComponentName, tempo.Name)
 	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
-	cfg := tempo.Spec.Template.Querier
+	cfg := tempo.Spec.Template.Querier.TempoComponentSpec
 	image := tempo.Spec.Images.Tempo
 	if image == "" {
 		image = params.CtrlConfig.DefaultImages.Tempo
@@ -75,7 +80,7 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 			Labels:    labels,
 		},
 		Spec: v1.DeploymentSpec{
-			Replicas: tempo.Spec.Template.Querier.Replicas,
+			Replicas: autoscaling.Replicas(tempo.Spec.Template.Querier.Replicas, tempo.Spec.Template.Querier.Autoscaling),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -85,10 +90,11 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
-					Affinity:           manifestutils.DefaultAffinity(labels),
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					Affinity:                  manifestutils.ComponentAffinity(cfg, labels),
+					TopologySpreadConstraints: cfg.TopologySpreadConstraints,
 					Containers: []corev1.Container{
 						{
 							Name:  "tempo",
@@ -119,6 +125,11 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 									MountPath: "/conf",
 									ReadOnly:  true,
 								},
+								{
+									Name:      manifestutils.RuntimeOverridesVolumeName,
+									MountPath: manifestutils.RuntimeOverridesMountPath,
+									ReadOnly:  true,
+								},
 								{
 									Name:      manifestutils.TmpStorageVolumeName,
 									MountPath: manifestutils.TmpTempoStoragePath,
@@ -139,6 +150,16 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 								},
 							},
 						},
+						{
+							Name: manifestutils.RuntimeOverridesVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name(manifestutils.RuntimeOverridesComponentName, tempo.Name),
+									},
+								},
+							},
+						},
 						{
 							Name: manifestutils.TmpStorageVolumeName,
 							VolumeSource: corev1.VolumeSource{
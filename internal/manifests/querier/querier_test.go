@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,12 +31,14 @@ func TestBuildQuerier(t *testing.T) {
 			},
 			ServiceAccount: "tempo-test-serviceaccount",
 			Template: v1alpha1.TempoTemplateSpec{
-				Querier: v1alpha1.TempoComponentSpec{
-					Replicas:     ptr.To(int32(3)),
-					NodeSelector: map[string]string{"a": "b"},
-					Tolerations: []corev1.Toleration{
-						{
-							Key: "c",
+				Querier: v1alpha1.TempoQuerierSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas:     ptr.To(int32(3)),
+						NodeSelector: map[string]string{"a": "b"},
+						Tolerations: []corev1.Toleration{
+							{
+								Key: "c",
+							},
 						},
 					},
 				},
@@ -137,6 +140,11 @@ func TestBuildQuerier(t *testing.T) {
 									MountPath: "/conf",
 									ReadOnly:  true,
 								},
+								{
+									Name:      manifestutils.RuntimeOverridesVolumeName,
+									MountPath: manifestutils.RuntimeOverridesMountPath,
+									ReadOnly:  true,
+								},
 								{
 									Name:      manifestutils.TmpStorageVolumeName,
 									MountPath: manifestutils.TmpTempoStoragePath,
@@ -189,6 +197,16 @@ func TestBuildQuerier(t *testing.T) {
 								},
 							},
 						},
+						{
+							Name: manifestutils.RuntimeOverridesVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "tempo-test-overrides",
+									},
+								},
+							},
+						},
 						{
 							Name: manifestutils.TmpStorageVolumeName,
 							VolumeSource: corev1.VolumeSource{
@@ -221,9 +239,11 @@ func TestOverrideResources(t *testing.T) {
 			},
 			ServiceAccount: "tempo-test-serviceaccount",
 			Template: v1alpha1.TempoTemplateSpec{
-				Querier: v1alpha1.TempoComponentSpec{
-					Replicas:  ptr.To(int32(3)),
-					Resources: &overrideResources,
+				Querier: v1alpha1.TempoQuerierSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas:  ptr.To(int32(3)),
+						Resources: &overrideResources,
+					},
 				},
 			},
 			Resources: v1alpha1.Resources{
@@ -241,3 +261,40 @@ func TestOverrideResources(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, dep.Spec.Template.Spec.Containers[0].Resources, overrideResources)
 }
+
+func TestBuildQuerierAutoscaling(t *testing.T) {
+	objects, err := BuildQuerier(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Querier: v1alpha1.TempoQuerierSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas: ptr.To(int32(1)),
+					},
+					Autoscaling: &v1alpha1.AutoscalingSpec{
+						Enabled:              true,
+						MinReplicas:          ptr.To(int32(2)),
+						MaxReplicas:          5,
+						TargetCPUUtilization: ptr.To(int32(70)),
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, objects, 3)
+
+	dep, ok := objects[0].(*v1.Deployment)
+	require.True(t, ok)
+	assert.Nil(t, dep.Spec.Replicas)
+
+	hpa, ok := objects[2].(*autoscalingv2.HorizontalPodAutoscaler)
+	require.True(t, ok)
+	assert.Equal(t, "tempo-test-querier", hpa.Name)
+	assert.Equal(t, "tempo-test-querier", hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, ptr.To(int32(2)), hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+}
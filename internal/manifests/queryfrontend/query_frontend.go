@@ -1,6 +1,7 @@
 package queryfrontend
 
 import (
+	"errors"
 	"fmt"
 	"path"
 	"strings"
@@ -16,8 +17,11 @@ import (
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/autoscaling"
+	"github.com/grafana/tempo-operator/internal/manifests/gatewayapi"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
@@ -111,11 +115,21 @@ func BuildQueryFrontend(params manifestutils.Params) ([]client.Object, error) {
 				oauthproxy.PatchRouteForOauthProxy(routeObj)
 			}
 			manifests = append(manifests, routeObj)
+		case v1alpha1.IngressTypeGatewayAPI:
+			routeObj, err := httpRoute(tempo)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, routeObj)
 		}
 	}
 
 	manifests = append(manifests, d)
 
+	if hpa := tempo.Spec.Template.QueryFrontend.Autoscaling; autoscaling.Enabled(hpa) {
+		manifests = append(manifests, autoscaling.BuildHorizontalPodAutoscaler(tempo, manifestutils.QueryFrontendComponentName, *hpa))
+	}
+
 	if tempo.Spec.Template.QueryFrontend.JaegerQuery.Enabled && tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.Enabled &&
 		tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint == thanosQuerierOpenShiftMonitoring {
 		rbac := openShiftMonitoringRBAC(tempo, d)
@@ -185,7 +199,7 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: tempo.Spec.Template.QueryFrontend.Replicas,
+			Replicas: autoscaling.Replicas(tempo.Spec.Template.QueryFrontend.Replicas, tempo.Spec.Template.QueryFrontend.Autoscaling),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -195,10 +209,11 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
-					Affinity:           manifestutils.DefaultAffinity(labels),
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					Affinity:                  manifestutils.ComponentAffinity(cfg.TempoComponentSpec, labels),
+					TopologySpreadConstraints: cfg.TopologySpreadConstraints,
 					Containers: []corev1.Container{
 						{
 							Name:  containerNameTempo,
@@ -230,6 +245,11 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 									MountPath: "/conf",
 									ReadOnly:  true,
 								},
+								{
+									Name:      manifestutils.RuntimeOverridesVolumeName,
+									MountPath: manifestutils.RuntimeOverridesMountPath,
+									ReadOnly:  true,
+								},
 								{
 									Name:      manifestutils.TmpStorageVolumeName,
 									MountPath: manifestutils.TmpTempoStoragePath,
@@ -250,6 +270,16 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 								},
 							},
 						},
+						{
+							Name: manifestutils.RuntimeOverridesVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name(manifestutils.RuntimeOverridesComponentName, tempo.Name),
+									},
+								},
+							},
+						},
 						{
 							Name: manifestutils.TmpStorageVolumeName,
 							VolumeSource: corev1.VolumeSource{
@@ -624,6 +654,26 @@ func ingress(tempo v1alpha1.TempoStack) *networkingv1.Ingress {
 	return ingress
 }
 
+// httpRoute creates a HTTPRoute of the Kubernetes Gateway API for the Jaeger UI.
+func httpRoute(tempo v1alpha1.TempoStack) (*gatewayv1.HTTPRoute, error) {
+	ingressSpec := tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress
+	if ingressSpec.GatewayAPI == nil {
+		return nil, errors.New("the gatewayAPI configuration is required for the ingress type gateway-api")
+	}
+
+	queryFrontendName := naming.Name(manifestutils.QueryFrontendComponentName, tempo.Name)
+	return gatewayapi.HTTPRoute(gatewayapi.RouteOptions{
+		Name:        queryFrontendName,
+		Namespace:   tempo.Namespace,
+		Labels:      manifestutils.ComponentLabels(manifestutils.QueryFrontendComponentName, tempo.Name),
+		Annotations: ingressSpec.Annotations,
+		Host:        ingressSpec.Host,
+		ParentRef:   ingressSpec.GatewayAPI.ParentRef,
+		ServiceName: queryFrontendName,
+		ServicePort: manifestutils.PortJaegerUI,
+	}), nil
+}
+
 func route(tempo v1alpha1.TempoStack) (*routev1.Route, error) {
 	queryFrontendName := naming.Name(manifestutils.QueryFrontendComponentName, tempo.Name)
 	labels := manifestutils.ComponentLabels(manifestutils.QueryFrontendComponentName, tempo.Name)
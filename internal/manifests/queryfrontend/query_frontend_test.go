@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -16,6 +17,7 @@ import (
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
@@ -180,6 +182,11 @@ func getExpectedDeployment(withJaeger bool) *v1.Deployment {
 									MountPath: "/conf",
 									ReadOnly:  true,
 								},
+								{
+									Name:      manifestutils.RuntimeOverridesVolumeName,
+									MountPath: manifestutils.RuntimeOverridesMountPath,
+									ReadOnly:  true,
+								},
 								{
 									Name:      manifestutils.TmpStorageVolumeName,
 									MountPath: manifestutils.TmpTempoStoragePath,
@@ -209,6 +216,16 @@ func getExpectedDeployment(withJaeger bool) *v1.Deployment {
 								},
 							},
 						},
+						{
+							Name: manifestutils.RuntimeOverridesVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name(manifestutils.RuntimeOverridesComponentName, "test"),
+									},
+								},
+							},
+						},
 						{
 							Name: manifestutils.TmpStorageVolumeName,
 							VolumeSource: corev1.VolumeSource{
@@ -507,6 +524,95 @@ func TestQueryFrontendJaegerIngress(t *testing.T) {
 	}, objects[2].(*networkingv1.Ingress))
 }
 
+func TestQueryFrontendJaegerHTTPRoute(t *testing.T) {
+	objects, err := BuildQueryFrontend(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+					JaegerQuery: v1alpha1.JaegerQuerySpec{
+						Enabled: true,
+						Ingress: v1alpha1.IngressSpec{
+							Type: v1alpha1.IngressTypeGatewayAPI,
+							Host: "jaeger.example.com",
+							GatewayAPI: &v1alpha1.GatewayAPISpec{
+								ParentRef: v1alpha1.GatewayParentReference{
+									Name:        "public",
+									SectionName: "https",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}})
+
+	require.NoError(t, err)
+	require.Equal(t, 4, len(objects))
+	assert.Equal(t, &gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "gateway.networking.k8s.io/v1",
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.QueryFrontendComponentName, "test"),
+			Namespace: "project1",
+			Labels:    manifestutils.ComponentLabels("query-frontend", "test"),
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{
+					Name:        "public",
+					SectionName: ptr.To(gatewayv1.SectionName("https")),
+				}},
+			},
+			Hostnames: []gatewayv1.Hostname{"jaeger.example.com"},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{{
+					Path: &gatewayv1.HTTPPathMatch{
+						Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+						Value: ptr.To("/"),
+					},
+				}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{
+							Name: gatewayv1.ObjectName(naming.Name(manifestutils.QueryFrontendComponentName, "test")),
+							Port: ptr.To(gatewayv1.PortNumber(16686)),
+						},
+					},
+				}},
+			}},
+		},
+	}, objects[2].(*gatewayv1.HTTPRoute))
+}
+
+func TestQueryFrontendJaegerHTTPRouteWithoutParent(t *testing.T) {
+	_, err := BuildQueryFrontend(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+					JaegerQuery: v1alpha1.JaegerQuerySpec{
+						Enabled: true,
+						Ingress: v1alpha1.IngressSpec{
+							Type: v1alpha1.IngressTypeGatewayAPI,
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.Error(t, err)
+}
+
 func TestQueryFrontendJaegerRoute(t *testing.T) {
 	objects, err := BuildQueryFrontend(manifestutils.Params{Tempo: v1alpha1.TempoStack{
 		ObjectMeta: metav1.ObjectMeta{
@@ -902,3 +1008,42 @@ func TestQueryFrontendJaegerRouteSecured(t *testing.T) {
 		},
 	}, objects[3].(*routev1.Route))
 }
+
+func TestBuildQueryFrontendAutoscaling(t *testing.T) {
+	objects, err := BuildQueryFrontend(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas: ptr.To(int32(1)),
+					},
+					Autoscaling: &v1alpha1.AutoscalingSpec{
+						Enabled:              true,
+						MaxReplicas:          4,
+						TargetCPUUtilization: ptr.To(int32(60)),
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	var dep *v1.Deployment
+	var hpa *autoscalingv2.HorizontalPodAutoscaler
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *v1.Deployment:
+			dep = o
+		case *autoscalingv2.HorizontalPodAutoscaler:
+			hpa = o
+		}
+	}
+	require.NotNil(t, dep)
+	require.NotNil(t, hpa)
+	assert.Nil(t, dep.Spec.Replicas)
+	assert.Equal(t, "tempo-test-query-frontend", hpa.Spec.ScaleTargetRef.Name)
+}
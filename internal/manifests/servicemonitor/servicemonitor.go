@@ -26,6 +26,10 @@ func BuildServiceMonitors(params manifestutils.Params) []client.Object {
 		buildFrontEndServiceMonitor(params, manifestutils.HttpPortName),
 	}
 
+	if params.Tempo.Spec.Template.MetricsGenerator.Enabled {
+		monitors = append(monitors, buildServiceMonitor(params, manifestutils.MetricsGeneratorComponentName, manifestutils.HttpPortName))
+	}
+
 	if params.Tempo.Spec.Template.Gateway.Enabled {
 		monitors = append(monitors, buildServiceMonitor(params, manifestutils.GatewayComponentName, manifestutils.GatewayInternalHttpPortName))
 	}
@@ -0,0 +1,296 @@
+package storagemigration
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// Phase is a phase of the storage migration.
+type Phase string
+
+const (
+	// PhaseCopy copies all objects from the previous object storage,
+	// while the Tempo components still use the previous object storage.
+	PhaseCopy Phase = "copy"
+	// PhaseSync copies the objects written to the previous object storage during the copy phase,
+	// after the Tempo components were switched to the new object storage.
+	PhaseSync Phase = "sync"
+
+	// ChecksumAnnotation contains the checksum of the pod spec of a storage migration Job.
+	// A Job is recreated if the checksum changes, because the pod template of a Job is immutable.
+	ChecksumAnnotation = "tempo.grafana.com/storage-migration.checksum"
+
+	containerName = "rclone"
+	tmpDir        = "/tmp"
+	secretsDir    = "/etc/storage-migration"
+
+	remoteSource      = "source"
+	remoteDestination = "destination"
+)
+
+// Labels returns the labels of the storage migration Jobs.
+func Labels(tempoName string) map[string]string {
+	return manifestutils.ComponentLabels(manifestutils.StorageMigrationComponentName, tempoName)
+}
+
+// JobName returns the name of the storage migration Job of a phase.
+func JobName(tempoName string, phase Phase) string {
+	return naming.Name(fmt.Sprintf("%s-%s", manifestutils.StorageMigrationComponentName, phase), tempoName)
+}
+
+// SourceTempoStack returns a copy of the TempoStack which uses the previous object storage of the storage migration.
+func SourceTempoStack(tempo v1alpha1.TempoStack) v1alpha1.TempoStack {
+	source := *tempo.DeepCopy()
+	source.Spec.Storage.Secret = tempo.Spec.Storage.Migration.Source
+	source.Spec.Storage.TLS = tempo.Spec.Storage.Migration.SourceTLS
+	return source
+}
+
+// Validate returns an error if the storage migration is not supported for the storage configuration.
+// The params must contain the storage parameters of the new object storage.
+func Validate(params manifestutils.Params, sourceParams manifestutils.StorageParams) error {
+	storage := params.Tempo.Spec.Storage
+	if sourceParams.CredentialMode != v1alpha1.CredentialModeStatic {
+		return fmt.Errorf("the %s credential mode of the previous object storage is not supported", sourceParams.CredentialMode)
+	}
+	if params.StorageParams.CredentialMode != v1alpha1.CredentialModeStatic {
+		return fmt.Errorf("the %s credential mode of the new object storage is not supported", params.StorageParams.CredentialMode)
+	}
+	if storage.Migration.SourceTLS.Cert != "" || storage.TLS.Cert != "" {
+		return fmt.Errorf("client certificates are not supported")
+	}
+	return nil
+}
+
+// BuildJob creates the Job of a storage migration phase,
+// which copies the objects from the previous object storage to the new object storage with rclone.
+// The params must contain the storage parameters of the new object storage.
+func BuildJob(params manifestutils.Params, sourceParams manifestutils.StorageParams, phase Phase) (*batchv1.Job, error) {
+	tempo := params.Tempo
+	image := params.CtrlConfig.DefaultImages.Rclone
+	if image == "" {
+		return nil, fmt.Errorf("rclone image is not set, please set the %s environment variable of the operator", configv1alpha1.EnvRelatedImageRclone)
+	}
+
+	pod := corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:  containerName,
+			Image: image,
+			Env: []corev1.EnvVar{
+				{Name: "RCLONE_CONFIG", Value: path.Join(tmpDir, "rclone.conf")},
+				{Name: "RCLONE_CACHE_DIR", Value: path.Join(tmpDir, "cache")},
+			},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "tmp",
+				MountPath: tmpDir,
+			}},
+			SecurityContext: manifestutils.TempoContainerSecurityContext(),
+		}},
+		Volumes: []corev1.Volume{{
+			Name: "tmp",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}},
+		RestartPolicy: corev1.RestartPolicyNever,
+	}
+
+	sourcePath, err := configureRemote(&pod, remoteSource, tempo.Spec.Storage.Migration.Source, tempo.Spec.Storage.Migration.SourceTLS, sourceParams)
+	if err != nil {
+		return nil, err
+	}
+	destinationPath, err := configureRemote(&pod, remoteDestination, tempo.Spec.Storage.Secret, tempo.Spec.Storage.TLS, params.StorageParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// rclone copy never deletes objects in the destination,
+	// and skips objects which exist in the destination with the same size and modification time.
+	pod.Containers[0].Args = append([]string{
+		"copy",
+		fmt.Sprintf("%s:%s", remoteSource, sourcePath),
+		fmt.Sprintf("%s:%s", remoteDestination, destinationPath),
+		"--stats=1m",
+		"--stats-log-level=NOTICE",
+	}, pod.Containers[0].Args...)
+
+	podSpecJSON, err := json.Marshal(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := Labels(tempo.Name)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(tempo.Name, phase),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				ChecksumAnnotation: fmt.Sprintf("%x", sha256.Sum256(podSpecJSON)),
+			},
+		},
+		// The Jobs don't set a TTL, because the phase of the storage migration is derived from the Jobs.
+		// They are deleted once the migration is removed from the TempoStack.
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: pod,
+			},
+		},
+	}, nil
+}
+
+// configureRemote configures a rclone remote with environment variables, and returns the path of the traces in the remote.
+func configureRemote(pod *corev1.PodSpec, remote string, secret v1alpha1.ObjectStorageSecretSpec, tlsSpec v1alpha1.TLSSpec, params manifestutils.StorageParams) (string, error) {
+	container := &pod.Containers[0]
+	env := func(name string, value string) {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  fmt.Sprintf("RCLONE_CONFIG_%s_%s", strings.ToUpper(remote), name),
+			Value: value,
+		})
+	}
+	secretEnv := func(name string, key string) {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: fmt.Sprintf("RCLONE_CONFIG_%s_%s", strings.ToUpper(remote), name),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+					Key:                  key,
+				},
+			},
+		})
+	}
+
+	switch secret.Type {
+	case v1alpha1.ObjectStorageSecretS3, v1alpha1.ObjectStorageSecretSwift:
+		s3 := params.S3
+		env("TYPE", "s3")
+		if strings.HasSuffix(s3.Endpoint, "amazonaws.com") {
+			env("PROVIDER", "AWS")
+		} else {
+			env("PROVIDER", "Other")
+		}
+		scheme := "https://"
+		if s3.Insecure {
+			scheme = "http://"
+		}
+		env("ENDPOINT", scheme+s3.Endpoint)
+		secretEnv("ACCESS_KEY_ID", "access_key_id")
+		secretEnv("SECRET_ACCESS_KEY", "access_key_secret")
+		if s3.Region != "" {
+			env("REGION", s3.Region)
+		}
+		if s3.ForcePathStyle {
+			env("FORCE_PATH_STYLE", strconv.FormatBool(s3.ForcePathStyle))
+		}
+		if s3.SSE != nil {
+			switch s3.SSE.Type {
+			case "SSE-S3":
+				env("SERVER_SIDE_ENCRYPTION", "AES256")
+			case "SSE-KMS":
+				env("SERVER_SIDE_ENCRYPTION", "aws:kms")
+				env("SSE_KMS_KEY_ID", s3.SSE.KMSKeyID)
+			}
+		}
+
+		if tlsSpec.Enabled && tlsSpec.CA != "" {
+			caDir := path.Join(secretsDir, remote, "ca")
+			err := manifestutils.MountCAConfigMap(pod, containerName, tlsSpec.CA, caDir)
+			if err != nil {
+				return "", err
+			}
+			container = &pod.Containers[0]
+			container.Args = append(container.Args, fmt.Sprintf("--ca-cert=%s", path.Join(caDir, s3.TLS.CAFilename)))
+		}
+		return path.Join(s3.Bucket, s3.Prefix), nil
+
+	case v1alpha1.ObjectStorageSecretAzure:
+		env("TYPE", "azureblob")
+		secretEnv("ACCOUNT", "account_name")
+		secretEnv("KEY", "account_key")
+		return params.AzureStorage.Container, nil
+
+	case v1alpha1.ObjectStorageSecretGCS:
+		keyDir := path.Join(secretsDir, remote, "gcs")
+		volumeName := fmt.Sprintf("%s-gcs-key", remote)
+		env("TYPE", "google cloud storage")
+		env("SERVICE_ACCOUNT_FILE", path.Join(keyDir, "key.json"))
+		env("BUCKET_POLICY_ONLY", "true")
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: keyDir,
+			ReadOnly:  true,
+		})
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+				},
+			},
+		})
+		return params.GCS.Bucket, nil
+
+	default:
+		return "", fmt.Errorf("storage secret type %s is not supported by the storage migration", secret.Type)
+	}
+}
+
+// Condition returns the StorageMigrated condition derived from the states of the storage migration Jobs.
+// The Jobs are nil if they don't exist.
+func Condition(copyJob *batchv1.Job, syncJob *batchv1.Job) metav1.Condition {
+	condition := metav1.Condition{
+		Type:   string(v1alpha1.ConditionStorageMigrated),
+		Status: metav1.ConditionFalse,
+	}
+
+	switch {
+	case copyJob == nil || !finished(copyJob, batchv1.JobComplete) && !finished(copyJob, batchv1.JobFailed):
+		condition.Reason = string(v1alpha1.ReasonStorageMigrationCopying)
+		condition.Message = "copying the objects from the previous object storage, the components use the previous object storage"
+	case finished(copyJob, batchv1.JobFailed):
+		condition.Reason = string(v1alpha1.ReasonStorageMigrationFailed)
+		condition.Message = fmt.Sprintf("copying the objects failed, please check the logs of Job %s", copyJob.Name)
+	case syncJob == nil || !finished(syncJob, batchv1.JobComplete) && !finished(syncJob, batchv1.JobFailed):
+		condition.Reason = string(v1alpha1.ReasonStorageMigrationSyncing)
+		condition.Message = "copying the objects written during the copy phase, the components use the new object storage"
+	case finished(syncJob, batchv1.JobFailed):
+		condition.Reason = string(v1alpha1.ReasonStorageMigrationFailed)
+		condition.Message = fmt.Sprintf("copying the objects failed, please check the logs of Job %s", syncJob.Name)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = string(v1alpha1.ReasonStorageMigrationComplete)
+		condition.Message = "all objects were copied to the new object storage, spec.storage.migration can be removed"
+	}
+	return condition
+}
+
+// CopyComplete returns true if the copy phase finished successfully,
+// i.e. if the components can be switched to the new object storage.
+func CopyComplete(copyJob *batchv1.Job) bool {
+	return copyJob != nil && finished(copyJob, batchv1.JobComplete)
+}
+
+func finished(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,291 @@
+package storagemigration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func testParams() manifestutils.Params {
+	return manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Name: "s3",
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+					Migration: &v1alpha1.StorageMigrationSpec{
+						Source: v1alpha1.ObjectStorageSecretSpec{
+							Name: "minio",
+							Type: v1alpha1.ObjectStorageSecretS3,
+						},
+						SourceTLS: v1alpha1.TLSSpec{
+							Enabled: true,
+							CA:      "minio-ca",
+						},
+					},
+				},
+			},
+		},
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Rclone: "docker.io/rclone/rclone:1.69.3",
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			CredentialMode: v1alpha1.CredentialModeStatic,
+			S3: &manifestutils.S3{
+				Endpoint: "s3.eu-central-1.amazonaws.com",
+				Bucket:   "tempo",
+				Region:   "eu-central-1",
+				Prefix:   "traces",
+				SSE:      &manifestutils.S3SSE{Type: "SSE-KMS", KMSKeyID: "key"},
+			},
+		},
+	}
+}
+
+func testSourceParams() manifestutils.StorageParams {
+	return manifestutils.StorageParams{
+		CredentialMode: v1alpha1.CredentialModeStatic,
+		S3: &manifestutils.S3{
+			Endpoint:       "minio.minio.svc:9000",
+			Bucket:         "tempo",
+			ForcePathStyle: true,
+			TLS:            manifestutils.StorageTLS{CAFilename: "ca.crt"},
+		},
+	}
+}
+
+func secretEnv(name string, secret string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+func TestBuildJob(t *testing.T) {
+	job, err := BuildJob(testParams(), testSourceParams(), PhaseCopy)
+	require.NoError(t, err)
+
+	assert.Equal(t, "tempo-test-storage-migration-copy", job.Name)
+	assert.Equal(t, "project1", job.Namespace)
+	assert.Equal(t, map[string]string(manifestutils.ComponentLabels("storage-migration", "test")), job.Labels)
+	assert.NotEmpty(t, job.Annotations[ChecksumAnnotation])
+	assert.Nil(t, job.Spec.TTLSecondsAfterFinished)
+
+	pod := job.Spec.Template.Spec
+	assert.Equal(t, corev1.RestartPolicyNever, pod.RestartPolicy)
+	require.Len(t, pod.Containers, 1)
+	container := pod.Containers[0]
+	assert.Equal(t, "docker.io/rclone/rclone:1.69.3", container.Image)
+	assert.Equal(t, []string{
+		"copy",
+		"source:tempo",
+		"destination:tempo/traces",
+		"--stats=1m",
+		"--stats-log-level=NOTICE",
+		"--ca-cert=/etc/storage-migration/source/ca/ca.crt",
+	}, container.Args)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "RCLONE_CONFIG", Value: "/tmp/rclone.conf"},
+		{Name: "RCLONE_CACHE_DIR", Value: "/tmp/cache"},
+		{Name: "RCLONE_CONFIG_SOURCE_TYPE", Value: "s3"},
+		{Name: "RCLONE_CONFIG_SOURCE_PROVIDER", Value: "Other"},
+		{Name: "RCLONE_CONFIG_SOURCE_ENDPOINT", Value: "https://minio.minio.svc:9000"},
+		secretEnv("RCLONE_CONFIG_SOURCE_ACCESS_KEY_ID", "minio", "access_key_id"),
+		secretEnv("RCLONE_CONFIG_SOURCE_SECRET_ACCESS_KEY", "minio", "access_key_secret"),
+		{Name: "RCLONE_CONFIG_SOURCE_FORCE_PATH_STYLE", Value: "true"},
+		{Name: "RCLONE_CONFIG_DESTINATION_TYPE", Value: "s3"},
+		{Name: "RCLONE_CONFIG_DESTINATION_PROVIDER", Value: "AWS"},
+		{Name: "RCLONE_CONFIG_DESTINATION_ENDPOINT", Value: "https://s3.eu-central-1.amazonaws.com"},
+		secretEnv("RCLONE_CONFIG_DESTINATION_ACCESS_KEY_ID", "s3", "access_key_id"),
+		secretEnv("RCLONE_CONFIG_DESTINATION_SECRET_ACCESS_KEY", "s3", "access_key_secret"),
+		{Name: "RCLONE_CONFIG_DESTINATION_REGION", Value: "eu-central-1"},
+		{Name: "RCLONE_CONFIG_DESTINATION_SERVER_SIDE_ENCRYPTION", Value: "aws:kms"},
+		{Name: "RCLONE_CONFIG_DESTINATION_SSE_KMS_KEY_ID", Value: "key"},
+	}, container.Env)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "tmp", MountPath: "/tmp"},
+		{Name: "minio-ca", MountPath: "/etc/storage-migration/source/ca", ReadOnly: true},
+	}, container.VolumeMounts)
+	require.Len(t, pod.Volumes, 2)
+	assert.Equal(t, "minio-ca", pod.Volumes[1].ConfigMap.Name)
+
+	// the sync Job runs the same copy command
+	syncJob, err := BuildJob(testParams(), testSourceParams(), PhaseSync)
+	require.NoError(t, err)
+	assert.Equal(t, "tempo-test-storage-migration-sync", syncJob.Name)
+	assert.Equal(t, job.Spec.Template.Spec, syncJob.Spec.Template.Spec)
+	assert.Equal(t, job.Annotations, syncJob.Annotations)
+
+	// the checksum changes if the storage configuration changes
+	sourceParams := testSourceParams()
+	sourceParams.S3.Bucket = "other"
+	otherJob, err := BuildJob(testParams(), sourceParams, PhaseCopy)
+	require.NoError(t, err)
+	assert.NotEqual(t, job.Annotations[ChecksumAnnotation], otherJob.Annotations[ChecksumAnnotation])
+}
+
+func TestBuildJobAzureAndGCS(t *testing.T) {
+	params := testParams()
+	params.Tempo.Spec.Storage.Secret = v1alpha1.ObjectStorageSecretSpec{Name: "gcs", Type: v1alpha1.ObjectStorageSecretGCS}
+	params.Tempo.Spec.Storage.Migration = &v1alpha1.StorageMigrationSpec{
+		Source: v1alpha1.ObjectStorageSecretSpec{Name: "azure", Type: v1alpha1.ObjectStorageSecretAzure},
+	}
+	params.StorageParams = manifestutils.StorageParams{
+		CredentialMode: v1alpha1.CredentialModeStatic,
+		GCS:            &manifestutils.GCS{Bucket: "tempo-gcs"},
+	}
+	sourceParams := manifestutils.StorageParams{
+		CredentialMode: v1alpha1.CredentialModeStatic,
+		AzureStorage:   &manifestutils.AzureStorage{Container: "tempo-azure"},
+	}
+
+	job, err := BuildJob(params, sourceParams, PhaseCopy)
+	require.NoError(t, err)
+
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []string{
+		"copy",
+		"source:tempo-azure",
+		"destination:tempo-gcs",
+		"--stats=1m",
+		"--stats-log-level=NOTICE",
+	}, container.Args)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "RCLONE_CONFIG", Value: "/tmp/rclone.conf"},
+		{Name: "RCLONE_CACHE_DIR", Value: "/tmp/cache"},
+		{Name: "RCLONE_CONFIG_SOURCE_TYPE", Value: "azureblob"},
+		secretEnv("RCLONE_CONFIG_SOURCE_ACCOUNT", "azure", "account_name"),
+		secretEnv("RCLONE_CONFIG_SOURCE_KEY", "azure", "account_key"),
+		{Name: "RCLONE_CONFIG_DESTINATION_TYPE", Value: "google cloud storage"},
+		{Name: "RCLONE_CONFIG_DESTINATION_SERVICE_ACCOUNT_FILE", Value: "/etc/storage-migration/destination/gcs/key.json"},
+		{Name: "RCLONE_CONFIG_DESTINATION_BUCKET_POLICY_ONLY", Value: "true"},
+	}, container.Env)
+	assert.Equal(t, corev1.VolumeMount{
+		Name:      "destination-gcs-key",
+		MountPath: "/etc/storage-migration/destination/gcs",
+		ReadOnly:  true,
+	}, container.VolumeMounts[1])
+	assert.Equal(t, "gcs", job.Spec.Template.Spec.Volumes[1].Secret.SecretName)
+}
+
+func TestBuildJobWithoutImage(t *testing.T) {
+	params := testParams()
+	params.CtrlConfig.DefaultImages.Rclone = ""
+	_, err := BuildJob(params, testSourceParams(), PhaseCopy)
+	assert.EqualError(t, err, "rclone image is not set, please set the RELATED_IMAGE_RCLONE environment variable of the operator")
+}
+
+func TestSourceTempoStack(t *testing.T) {
+	params := testParams()
+	source := SourceTempoStack(params.Tempo)
+	assert.Equal(t, "minio", source.Spec.Storage.Secret.Name)
+	assert.Equal(t, "minio-ca", source.Spec.Storage.TLS.CA)
+	assert.Equal(t, "s3", params.Tempo.Spec.Storage.Secret.Name)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(testParams(), testSourceParams()))
+
+	sourceParams := testSourceParams()
+	sourceParams.CredentialMode = v1alpha1.CredentialModeToken
+	assert.EqualError(t, Validate(testParams(), sourceParams), "the token credential mode of the previous object storage is not supported")
+
+	params := testParams()
+	params.StorageParams.CredentialMode = v1alpha1.CredentialModeTokenCCO
+	assert.EqualError(t, Validate(params, testSourceParams()), "the token-cco credential mode of the new object storage is not supported")
+
+	params = testParams()
+	params.Tempo.Spec.Storage.TLS = v1alpha1.TLSSpec{Enabled: true, Cert: "client-cert"}
+	assert.EqualError(t, Validate(params, testSourceParams()), "client certificates are not supported")
+}
+
+func jobWithCondition(name string, conditionType batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if conditionType != "" {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+	}
+	return job
+}
+
+func TestCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		copyJob *batchv1.Job
+		syncJob *batchv1.Job
+		status  metav1.ConditionStatus
+		reason  v1alpha1.ConditionReason
+	}{
+		{
+			name:   "copy job not created",
+			status: metav1.ConditionFalse,
+			reason: v1alpha1.ReasonStorageMigrationCopying,
+		},
+		{
+			name:    "copying",
+			copyJob: jobWithCondition("copy", ""),
+			status:  metav1.ConditionFalse,
+			reason:  v1alpha1.ReasonStorageMigrationCopying,
+		},
+		{
+			name:    "copy failed",
+			copyJob: jobWithCondition("copy", batchv1.JobFailed),
+			status:  metav1.ConditionFalse,
+			reason:  v1alpha1.ReasonStorageMigrationFailed,
+		},
+		{
+			name:    "syncing",
+			copyJob: jobWithCondition("copy", batchv1.JobComplete),
+			syncJob: jobWithCondition("sync", ""),
+			status:  metav1.ConditionFalse,
+			reason:  v1alpha1.ReasonStorageMigrationSyncing,
+		},
+		{
+			name:    "sync failed",
+			copyJob: jobWithCondition("copy", batchv1.JobComplete),
+			syncJob: jobWithCondition("sync", batchv1.JobFailed),
+			status:  metav1.ConditionFalse,
+			reason:  v1alpha1.ReasonStorageMigrationFailed,
+		},
+		{
+			name:    "complete",
+			copyJob: jobWithCondition("copy", batchv1.JobComplete),
+			syncJob: jobWithCondition("sync", batchv1.JobComplete),
+			status:  metav1.ConditionTrue,
+			reason:  v1alpha1.ReasonStorageMigrationComplete,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			condition := Condition(test.copyJob, test.syncJob)
+			assert.Equal(t, string(v1alpha1.ConditionStorageMigrated), condition.Type)
+			assert.Equal(t, test.status, condition.Status)
+			assert.Equal(t, string(test.reason), condition.Reason)
+		})
+	}
+
+	assert.False(t, CopyComplete(nil))
+	assert.False(t, CopyComplete(jobWithCondition("copy", batchv1.JobFailed)))
+	assert.True(t, CopyComplete(jobWithCondition("copy", batchv1.JobComplete)))
+}
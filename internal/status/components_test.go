@@ -33,6 +33,8 @@ func TestSetComponentsStatus_WhenListReturnError_ReturnError(t *testing.T) {
 		{componentNotFound: "distributor"},
 		{componentNotFound: "query-frontend"},
 		{componentNotFound: "gateway"},
+		{componentNotFound: "metrics-generator"},
+		{componentNotFound: "memcached"},
 	}
 	for _, tc := range tests {
 		t.Run(tc.componentNotFound, func(t *testing.T) {
@@ -105,12 +107,15 @@ func TestSetComponentsStatus_WhenSomePodPending(t *testing.T) {
 
 	expected := v1alpha1.TempoStackStatus{
 		Components: v1alpha1.ComponentStatus{
-			Compactor:     expectedComponents,
-			Ingester:      expectedComponents,
-			Distributor:   expectedComponents,
-			Querier:       expectedComponents,
-			QueryFrontend: expectedComponents,
-			Gateway:       expectedComponents,
+			Compactor:        expectedComponents,
+			Ingester:         expectedComponents,
+			Distributor:      expectedComponents,
+			Querier:          expectedComponents,
+			QueryFrontend:    expectedComponents,
+			Gateway:          expectedComponents,
+			MetricsGenerator: expectedComponents,
+			Memcached:        expectedComponents,
+			BlockBuilder:     expectedComponents,
 		},
 	}
 
@@ -173,12 +178,15 @@ func TestSetComponentsStatus_WhenSomePodFailed(t *testing.T) {
 
 	expected := v1alpha1.TempoStackStatus{
 		Components: v1alpha1.ComponentStatus{
-			Compactor:     expectedComponents,
-			Ingester:      expectedComponents,
-			Distributor:   expectedComponents,
-			Querier:       expectedComponents,
-			QueryFrontend: expectedComponents,
-			Gateway:       expectedComponents,
+			Compactor:        expectedComponents,
+			Ingester:         expectedComponents,
+			Distributor:      expectedComponents,
+			Querier:          expectedComponents,
+			QueryFrontend:    expectedComponents,
+			Gateway:          expectedComponents,
+			MetricsGenerator: expectedComponents,
+			Memcached:        expectedComponents,
+			BlockBuilder:     expectedComponents,
 		},
 	}
 
@@ -241,12 +249,15 @@ func TestSetComponentsStatus_WhenSomePodUnknow(t *testing.T) {
 
 	expected := v1alpha1.TempoStackStatus{
 		Components: v1alpha1.ComponentStatus{
-			Compactor:     expectedComponents,
-			Ingester:      expectedComponents,
-			Distributor:   expectedComponents,
-			Querier:       expectedComponents,
-			QueryFrontend: expectedComponents,
-			Gateway:       expectedComponents,
+			Compactor:        expectedComponents,
+			Ingester:         expectedComponents,
+			Distributor:      expectedComponents,
+			Querier:          expectedComponents,
+			QueryFrontend:    expectedComponents,
+			Gateway:          expectedComponents,
+			MetricsGenerator: expectedComponents,
+			Memcached:        expectedComponents,
+			BlockBuilder:     expectedComponents,
 		},
 	}
 
@@ -308,12 +319,15 @@ func TestSetComponentsStatus_WhenAllReady(t *testing.T) {
 
 	expected := v1alpha1.TempoStackStatus{
 		Components: v1alpha1.ComponentStatus{
-			Compactor:     expectedComponents,
-			Ingester:      expectedComponents,
-			Distributor:   expectedComponents,
-			Querier:       expectedComponents,
-			QueryFrontend: expectedComponents,
-			Gateway:       expectedComponents,
+			Compactor:        expectedComponents,
+			Ingester:         expectedComponents,
+			Distributor:      expectedComponents,
+			Querier:          expectedComponents,
+			QueryFrontend:    expectedComponents,
+			Gateway:          expectedComponents,
+			MetricsGenerator: expectedComponents,
+			Memcached:        expectedComponents,
+			BlockBuilder:     expectedComponents,
 		},
 	}
 
@@ -2,6 +2,7 @@ package status
 
 import (
 	"fmt"
+	"slices"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -61,7 +62,7 @@ func PendingCondition(tempo v1alpha1.TempoStack) []metav1.Condition {
 }
 
 // UpdateCondition updates or appends the condition to the TempoStack status conditions.
-// In addition it resets all other status conditions to false.
+// In addition it resets all other status conditions listed in AllStatusConditions to false.
 func UpdateCondition(tempo v1alpha1.TempoStack, condition metav1.Condition) []metav1.Condition {
 
 	for _, c := range tempo.Status.Conditions {
@@ -82,14 +83,18 @@ func UpdateCondition(tempo v1alpha1.TempoStack, condition metav1.Condition) []me
 
 	index := -1
 	for i := range status.Conditions {
-		// Reset all other conditions first
-		status.Conditions[i].Status = metav1.ConditionFalse
-		status.Conditions[i].LastTransitionTime = now
-
 		// Locate existing pending condition if any
 		if status.Conditions[i].Type == condition.Type {
 			index = i
 		}
+
+		// Reset all other conditions first.
+		// Independent conditions (e.g. StorageReady) are not reset.
+		if !slices.Contains(v1alpha1.AllStatusConditions, v1alpha1.ConditionStatus(status.Conditions[i].Type)) {
+			continue
+		}
+		status.Conditions[i].Status = metav1.ConditionFalse
+		status.Conditions[i].LastTransitionTime = now
 	}
 
 	if index == -1 {
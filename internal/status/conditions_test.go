@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
@@ -379,3 +380,33 @@ func TestConfigurationError(t *testing.T) {
 	}
 	assert.Equal(t, "invalid configuration: my message", err.Error())
 }
+
+func TestUpdateConditionKeepsStorageReady(t *testing.T) {
+	storageReady := metav1.Condition{
+		Type:    string(v1alpha1.ConditionStorageReady),
+		Reason:  string(v1alpha1.ReasonStorageReady),
+		Message: "listed, wrote and deleted a probe object in bucket tempo",
+		Status:  metav1.ConditionTrue,
+	}
+	stack := v1alpha1.TempoStack{
+		Status: v1alpha1.TempoStackStatus{
+			Conditions: []metav1.Condition{
+				storageReady,
+				{
+					Type:    string(v1alpha1.ConditionPending),
+					Reason:  string(v1alpha1.ReasonPendingComponents),
+					Message: messagePending,
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	conditions := ReadyCondition(stack)
+
+	require.Len(t, conditions, 3)
+	assert.Equal(t, storageReady, conditions[0])
+	assert.Equal(t, metav1.ConditionFalse, conditions[1].Status)
+	assert.Equal(t, string(v1alpha1.ConditionReady), conditions[2].Type)
+	assert.Equal(t, metav1.ConditionTrue, conditions[2].Status)
+}
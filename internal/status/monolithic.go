@@ -200,7 +200,15 @@ func HandleTempoMonolithicStatus(ctx context.Context, client client.Client, temp
 		status.OperatorVersion = version.Get().OperatorVersion
 	}
 	if status.TempoVersion == "" {
-		status.TempoVersion = version.Get().TempoVersion
+		status.TempoVersion = version.TempoVersionFor(tempo.Spec.TempoVersion)
+	}
+
+	// The Tempo version is updated once all pods run the same Tempo version
+	tempoVersion, err := tempoMonolithicVersion(ctx, client, tempo)
+	if err != nil {
+		log.Error(err, "could not get the running Tempo version")
+	} else if tempoVersion != "" {
+		status.TempoVersion = tempoVersion
 	}
 
 	status.Components, err = getComponentsStatus(ctx, client, tempo)
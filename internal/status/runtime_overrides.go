@@ -0,0 +1,215 @@
+package status
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// RuntimeConfigPath is the path of the Tempo endpoint which returns the loaded runtime configuration.
+const RuntimeConfigPath = "/status/runtime_config"
+
+// runtimeConfigConcurrency limits the concurrent requests to the runtime configuration endpoints of the Tempo pods.
+const runtimeConfigConcurrency = 10
+
+// runtimeOverridesComponents are the components which load the per-tenant overrides.
+var runtimeOverridesComponents = []string{
+	manifestutils.DistributorComponentName,
+	manifestutils.IngesterComponentName,
+	manifestutils.QuerierComponentName,
+	manifestutils.QueryFrontendComponentName,
+	manifestutils.CompactorComponentName,
+	manifestutils.MetricsGeneratorComponentName,
+	manifestutils.BlockBuilderComponentName,
+}
+
+// RuntimeConfigGetter returns the runtime configuration loaded by a Tempo pod.
+type RuntimeConfigGetter func(ctx context.Context, pod corev1.Pod) ([]byte, error)
+
+// HTTPRuntimeConfigGetter returns a RuntimeConfigGetter which queries the runtime configuration endpoint of the pod.
+func HTTPRuntimeConfigGetter(httpClient *http.Client) RuntimeConfigGetter {
+	return func(ctx context.Context, pod corev1.Pod) ([]byte, error) {
+		url := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(manifestutils.PortHTTPServer)), RuntimeConfigPath)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+		}
+		return io.ReadAll(resp.Body)
+	}
+}
+
+// GetRuntimeOverridesStatus checks if all running Tempo pods loaded the per-tenant overrides.
+// The runtime configuration of the pods is queried concurrently, the caller bounds the total time with the context,
+// pods which do not respond in time are reported as pending.
+// The ObservedTime is kept as long as the checksum of the overrides doesn't change.
+func GetRuntimeOverridesStatus(ctx context.Context, k StatusClient, getRuntimeConfig RuntimeConfigGetter, tempo v1alpha1.TempoStack, overrides []byte, now time.Time) (*v1alpha1.RuntimeOverridesStatus, error) {
+	var expected map[string]interface{}
+	if err := yaml.Unmarshal(overrides, &expected); err != nil {
+		return nil, fmt.Errorf("error parsing per-tenant overrides: %w", err)
+	}
+
+	status := &v1alpha1.RuntimeOverridesStatus{
+		Checksum: overridesChecksum(overrides),
+	}
+
+	var pods []corev1.Pod
+	for _, component := range runtimeOverridesComponents {
+		componentPods, err := k.GetPodsComponent(ctx, component, tempo)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range componentPods.Items {
+			if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+				pods = append(pods, pod)
+			}
+		}
+	}
+
+	loaded := make([]bool, len(pods))
+	semaphore := make(chan struct{}, runtimeConfigConcurrency)
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			runtimeConfig, err := getRuntimeConfig(ctx, pod)
+			loaded[i] = err == nil && overridesLoaded(expected, runtimeConfig)
+		}()
+	}
+	wg.Wait()
+
+	for i, pod := range pods {
+		if !loaded[i] {
+			status.PendingPods = append(status.PendingPods, pod.Name)
+		}
+	}
+
+	if len(status.PendingPods) > 0 {
+		status.State = v1alpha1.RuntimeOverridesPending
+		return status, nil
+	}
+
+	status.State = v1alpha1.RuntimeOverridesLoaded
+	previous := tempo.Status.RuntimeOverrides
+	if previous != nil && previous.Checksum == status.Checksum && previous.ObservedTime != nil {
+		status.ObservedTime = previous.ObservedTime
+	} else {
+		observedTime := metav1.NewTime(now)
+		status.ObservedTime = &observedTime
+	}
+	return status, nil
+}
+
+// UnknownRuntimeOverridesStatus returns the status of per-tenant overrides which cannot be checked.
+func UnknownRuntimeOverridesStatus(overrides []byte) *v1alpha1.RuntimeOverridesStatus {
+	return &v1alpha1.RuntimeOverridesStatus{
+		Checksum: overridesChecksum(overrides),
+		State:    v1alpha1.RuntimeOverridesUnknown,
+	}
+}
+
+func overridesChecksum(overrides []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(overrides))
+}
+
+// wildcardTenant is the entry of the per-tenant overrides which applies to all tenants.
+const wildcardTenant = "*"
+
+type dedicatedColumnsOverrides struct {
+	Overrides map[string]struct {
+		Storage struct {
+			ParquetDedicatedColumns []v1alpha1.DedicatedColumn `json:"parquet_dedicated_columns"`
+		} `json:"storage"`
+	} `json:"overrides"`
+}
+
+// GetDedicatedColumnsStatus returns the dedicated attribute columns which are loaded by all Tempo pods.
+// The columns are read from the per-tenant overrides, and the previous columns are kept
+// until all pods loaded the current per-tenant overrides.
+func GetDedicatedColumnsStatus(tempo v1alpha1.TempoStack, runtimeOverrides *v1alpha1.RuntimeOverridesStatus, overrides []byte) ([]v1alpha1.DedicatedColumn, error) {
+	if runtimeOverrides == nil || runtimeOverrides.ObservedTime == nil || len(runtimeOverrides.PendingPods) > 0 {
+		return tempo.Status.DedicatedColumns, nil
+	}
+
+	var parsed dedicatedColumnsOverrides
+	if err := yaml.Unmarshal(overrides, &parsed); err != nil {
+		return nil, fmt.Errorf("error parsing per-tenant overrides: %w", err)
+	}
+	return parsed.Overrides[wildcardTenant].Storage.ParquetDedicatedColumns, nil
+}
+
+// overridesLoaded returns true if the runtime configuration contains all per-tenant overrides.
+// The runtime configuration returned by Tempo contains the default values of all fields,
+// therefore only the fields of the expected configuration are compared.
+func overridesLoaded(expected map[string]interface{}, runtimeConfig []byte) bool {
+	var actual map[string]interface{}
+	if err := yaml.Unmarshal(runtimeConfig, &actual); err != nil {
+		return false
+	}
+	return containsValue(expected, actual)
+}
+
+func containsValue(expected, actual interface{}) bool {
+	switch expectedValue := expected.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return len(expectedValue) == 0
+		}
+		for key, value := range expectedValue {
+			if !containsValue(value, actualValue[key]) {
+				return false
+			}
+		}
+		return true
+	case string:
+		actualValue, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		if expectedValue == actualValue {
+			return true
+		}
+		// Tempo formats durations differently, e.g. 24h0m0s is returned as 1d.
+		expectedDuration, err := time.ParseDuration(expectedValue)
+		if err != nil {
+			return false
+		}
+		actualDuration, err := model.ParseDuration(actualValue)
+		return err == nil && time.Duration(actualDuration) == expectedDuration
+	default:
+		return reflect.DeepEqual(expected, actual)
+	}
+}
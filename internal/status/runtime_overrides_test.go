@@ -0,0 +1,204 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+const testOverrides = `
+overrides:
+  "dev":
+    ingestion:
+      rate_limit_bytes: 15000000
+    read:
+    compaction:
+      block_retention: 48h0m0s
+`
+
+func runningPod(name string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: "10.0.0.1",
+		},
+	}
+}
+
+func runtimeOverridesClient() *statusClientStub {
+	return &statusClientStub{
+		GetPodsComponentStub: func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
+			switch componentName {
+			case manifestutils.DistributorComponentName:
+				return &corev1.PodList{Items: []corev1.Pod{runningPod("distributor")}}, nil
+			case manifestutils.CompactorComponentName:
+				pending := runningPod("compactor-pending")
+				pending.Status.Phase = corev1.PodPending
+				return &corev1.PodList{Items: []corev1.Pod{runningPod("compactor"), pending}}, nil
+			}
+			return &corev1.PodList{}, nil
+		},
+	}
+}
+
+func TestGetRuntimeOverridesStatus(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	loaded := func(ctx context.Context, pod corev1.Pod) ([]byte, error) {
+		return []byte(`
+overrides:
+  dev:
+    ingestion:
+      rate_strategy: local
+      rate_limit_bytes: 15000000
+      burst_size_bytes: 20000000
+    read:
+      max_bytes_per_tag_values_query: 5000000
+    compaction:
+      block_retention: 2d
+  prod:
+    ingestion:
+      rate_strategy: local
+`), nil
+	}
+
+	status, err := GetRuntimeOverridesStatus(context.Background(), runtimeOverridesClient(), loaded, v1alpha1.TempoStack{}, []byte(testOverrides), now)
+	require.NoError(t, err)
+	assert.Empty(t, status.PendingPods)
+	assert.NotEmpty(t, status.Checksum)
+	assert.Equal(t, v1alpha1.RuntimeOverridesLoaded, status.State)
+	assert.Equal(t, metav1.NewTime(now), *status.ObservedTime)
+
+	// the observed time doesn't change if the overrides didn't change
+	tempo := v1alpha1.TempoStack{Status: v1alpha1.TempoStackStatus{RuntimeOverrides: status}}
+	newStatus, err := GetRuntimeOverridesStatus(context.Background(), runtimeOverridesClient(), loaded, tempo, []byte(testOverrides), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, status, newStatus)
+
+	// the observed time changes if the overrides changed
+	changedOverrides := []byte(testOverrides + "  \"prod\":\n    ingestion:\n")
+	newStatus, err = GetRuntimeOverridesStatus(context.Background(), runtimeOverridesClient(), loaded, tempo, changedOverrides, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.NotEqual(t, status.Checksum, newStatus.Checksum)
+	assert.Equal(t, metav1.NewTime(now.Add(time.Hour)), *newStatus.ObservedTime)
+}
+
+func TestGetRuntimeOverridesStatusPending(t *testing.T) {
+	getRuntimeConfig := func(ctx context.Context, pod corev1.Pod) ([]byte, error) {
+		switch pod.Name {
+		case "distributor":
+			return []byte(`
+overrides:
+  dev:
+    ingestion:
+      rate_limit_bytes: 10000000
+    compaction:
+      block_retention: 2d
+`), nil
+		default:
+			return nil, errors.New("connection refused")
+		}
+	}
+
+	status, err := GetRuntimeOverridesStatus(context.Background(), runtimeOverridesClient(), getRuntimeConfig, v1alpha1.TempoStack{}, []byte(testOverrides), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"distributor", "compactor"}, status.PendingPods)
+	assert.Equal(t, v1alpha1.RuntimeOverridesPending, status.State)
+	assert.Nil(t, status.ObservedTime)
+}
+
+func TestGetRuntimeOverridesStatusConcurrency(t *testing.T) {
+	var pods []corev1.Pod
+	for i := 0; i < 3*runtimeConfigConcurrency; i++ {
+		pods = append(pods, runningPod(fmt.Sprintf("ingester-%d", i)))
+	}
+	k := &statusClientStub{
+		GetPodsComponentStub: func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
+			if componentName == manifestutils.IngesterComponentName {
+				return &corev1.PodList{Items: pods}, nil
+			}
+			return &corev1.PodList{}, nil
+		},
+	}
+
+	// all pods hang until the context expires
+	var inflight, maxInflight atomic.Int32
+	getRuntimeConfig := func(ctx context.Context, pod corev1.Pod) ([]byte, error) {
+		current := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			previous := maxInflight.Load()
+			if current <= previous || maxInflight.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	status, err := GetRuntimeOverridesStatus(ctx, k, getRuntimeConfig, v1alpha1.TempoStack{}, []byte(testOverrides), start)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.LessOrEqual(t, maxInflight.Load(), int32(runtimeConfigConcurrency))
+	assert.Len(t, status.PendingPods, len(pods))
+	assert.Equal(t, "ingester-0", status.PendingPods[0])
+	assert.Equal(t, v1alpha1.RuntimeOverridesPending, status.State)
+}
+
+func TestUnknownRuntimeOverridesStatus(t *testing.T) {
+	status := UnknownRuntimeOverridesStatus([]byte(testOverrides))
+	assert.Equal(t, v1alpha1.RuntimeOverridesUnknown, status.State)
+	assert.NotEmpty(t, status.Checksum)
+	assert.Empty(t, status.PendingPods)
+	assert.Nil(t, status.ObservedTime)
+}
+
+func TestGetDedicatedColumnsStatus(t *testing.T) {
+	overrides := []byte(`
+overrides:
+  "*":
+    storage:
+      parquet_dedicated_columns:
+      - scope: span
+        name: "http.route"
+        type: string
+  "dev":
+    ingestion:
+    storage:
+      parquet_dedicated_columns:
+      - scope: span
+        name: "http.route"
+        type: string
+`)
+	previous := []v1alpha1.DedicatedColumn{{Scope: v1alpha1.DedicatedColumnScopeResource, Name: "k8s.namespace.name", Type: v1alpha1.DedicatedColumnTypeString}}
+	tempo := v1alpha1.TempoStack{Status: v1alpha1.TempoStackStatus{DedicatedColumns: previous}}
+	observedTime := metav1.Now()
+
+	// the previous columns are kept until all pods loaded the overrides
+	columns, err := GetDedicatedColumnsStatus(tempo, &v1alpha1.RuntimeOverridesStatus{PendingPods: []string{"ingester-0"}}, overrides)
+	require.NoError(t, err)
+	assert.Equal(t, previous, columns)
+
+	columns, err = GetDedicatedColumnsStatus(tempo, &v1alpha1.RuntimeOverridesStatus{ObservedTime: &observedTime}, overrides)
+	require.NoError(t, err)
+	assert.Equal(t, []v1alpha1.DedicatedColumn{{Scope: v1alpha1.DedicatedColumnScopeSpan, Name: "http.route", Type: v1alpha1.DedicatedColumnTypeString}}, columns)
+
+	// all columns are removed
+	columns, err = GetDedicatedColumnsStatus(tempo, &v1alpha1.RuntimeOverridesStatus{ObservedTime: &observedTime}, []byte(testOverrides))
+	require.NoError(t, err)
+	assert.Empty(t, columns)
+}
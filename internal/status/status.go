@@ -7,7 +7,8 @@ import (
 	"github.com/grafana/tempo-operator/internal/version"
 )
 
-// Refresh updates the status field with the Tempo versions and updates the tempostack_status_condition metric.
+// Refresh updates the status field with the operator version and the running Tempo version,
+// and updates the tempostack_status_condition metric.
 func Refresh(ctx context.Context, k StatusClient, tempo v1alpha1.TempoStack, status *v1alpha1.TempoStackStatus) error {
 	changed := tempo.DeepCopy()
 	changed.Status = *status
@@ -17,12 +18,21 @@ func Refresh(ctx context.Context, k StatusClient, tempo v1alpha1.TempoStack, sta
 		changed.Status.OperatorVersion = version.Get().OperatorVersion
 	}
 	if status.TempoVersion == "" {
-		changed.Status.TempoVersion = version.Get().TempoVersion
+		changed.Status.TempoVersion = version.TempoVersionFor(tempo.Spec.TempoVersion)
+	}
+
+	// The Tempo version is updated once all pods run the same Tempo version
+	tempoVersion, err := tempoStackVersion(ctx, k, tempo)
+	if err != nil {
+		return err
+	}
+	if tempoVersion != "" {
+		changed.Status.TempoVersion = tempoVersion
 	}
 
 	updateMetrics(metricTempoStackStatusCondition, status.Conditions, tempo.Namespace, tempo.Name)
 
-	err := k.PatchStatus(ctx, changed, &tempo)
+	err = k.PatchStatus(ctx, changed, &tempo)
 	if err != nil {
 		return err
 	}
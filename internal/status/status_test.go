@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -16,6 +17,9 @@ import (
 
 func TestRefreshPatchError(t *testing.T) {
 	c := &statusClientStub{}
+	c.GetPodsComponentStub = func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
+		return &corev1.PodList{}, nil
+	}
 	c.PatchStatusStub = func(ctx context.Context, changed, original *v1alpha1.TempoStack) error {
 		return apierrors.NewConflict(schema.GroupResource{}, original.Name,
 			errors.New("patching error, likely some other thing modified this and the patch was rejected"))
@@ -39,6 +43,9 @@ func TestRefreshPatchError(t *testing.T) {
 
 func TestRefreshNoError(t *testing.T) {
 	c := &statusClientStub{}
+	c.GetPodsComponentStub = func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
+		return &corev1.PodList{}, nil
+	}
 	callPatchCount := 0
 
 	stack := v1alpha1.TempoStack{
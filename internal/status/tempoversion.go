@@ -0,0 +1,86 @@
+package status
+
+import (
+	"context"
+
+	"github.com/Masterminds/semver/v3"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/monolithic"
+)
+
+// tempoContainerName is the name of the container running Tempo.
+const tempoContainerName = "tempo"
+
+// tempoStackComponents are the components of a TempoStack which run Tempo.
+var tempoStackComponents = []string{
+	manifestutils.CompactorComponentName,
+	manifestutils.DistributorComponentName,
+	manifestutils.IngesterComponentName,
+	manifestutils.QuerierComponentName,
+	manifestutils.QueryFrontendComponentName,
+	manifestutils.MetricsGeneratorComponentName,
+	manifestutils.BlockBuilderComponentName,
+}
+
+// runningTempoVersion returns the Tempo version of the pods, or an empty string if the version is unknown,
+// i.e. while the pods run different versions during a rollout, or while a pod is not running and ready.
+func runningTempoVersion(pods []corev1.Pod) string {
+	tempoVersion := ""
+	for _, pod := range pods {
+		podVersion := podTempoVersion(pod)
+		if podVersion == "" || pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || !isPodReady(pod) {
+			return ""
+		}
+		if tempoVersion != "" && podVersion != tempoVersion {
+			return ""
+		}
+		tempoVersion = podVersion
+	}
+	return tempoVersion
+}
+
+// podTempoVersion returns the Tempo version of the image running in the tempo container of a pod,
+// or an empty string if the tag of the image is not a version, e.g. if the image is pinned by digest.
+func podTempoVersion(pod corev1.Pod) string {
+	for _, container := range pod.Status.ContainerStatuses {
+		if container.Name != tempoContainerName {
+			continue
+		}
+		tag := manifestutils.ImageTag(container.Image)
+		if _, err := semver.StrictNewVersion(tag); err != nil {
+			return ""
+		}
+		return tag
+	}
+	return ""
+}
+
+// tempoStackVersion returns the Tempo version running in all pods of a TempoStack.
+func tempoStackVersion(ctx context.Context, k StatusClient, tempo v1alpha1.TempoStack) (string, error) {
+	var pods []corev1.Pod
+	for _, component := range tempoStackComponents {
+		componentPods, err := k.GetPodsComponent(ctx, component, tempo)
+		if err != nil {
+			return "", err
+		}
+		pods = append(pods, componentPods.Items...)
+	}
+	return runningTempoVersion(pods), nil
+}
+
+// tempoMonolithicVersion returns the Tempo version running in the pods of a TempoMonolithic.
+func tempoMonolithicVersion(ctx context.Context, c client.Client, tempo v1alpha1.TempoMonolithic) (string, error) {
+	pods := &corev1.PodList{}
+	err := c.List(ctx, pods,
+		client.MatchingLabels(monolithic.ComponentLabels(manifestutils.TempoMonolithComponentName, tempo.Name)),
+		client.InNamespace(tempo.Namespace),
+	)
+	if err != nil {
+		return "", err
+	}
+	return runningTempoVersion(pods.Items), nil
+}
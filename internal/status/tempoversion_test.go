@@ -0,0 +1,100 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func tempoPod(image string, phase corev1.PodPhase, ready bool) corev1.Pod {
+	return corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: phase,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "tempo", Image: image, Ready: ready},
+			},
+		},
+	}
+}
+
+func TestRunningTempoVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		pods     []corev1.Pod
+		expected string
+	}{
+		{
+			name: "no pods",
+		},
+		{
+			name:     "all pods running",
+			pods:     []corev1.Pod{tempoPod("docker.io/grafana/tempo:2.7.2", corev1.PodRunning, true), tempoPod("docker.io/grafana/tempo:2.7.2", corev1.PodRunning, true)},
+			expected: "2.7.2",
+		},
+		{
+			name: "rollout in progress",
+			pods: []corev1.Pod{tempoPod("docker.io/grafana/tempo:2.7.2", corev1.PodRunning, true), tempoPod("docker.io/grafana/tempo:2.8.1", corev1.PodRunning, true)},
+		},
+		{
+			name: "pod not ready",
+			pods: []corev1.Pod{tempoPod("docker.io/grafana/tempo:2.7.2", corev1.PodRunning, true), tempoPod("docker.io/grafana/tempo:2.7.2", corev1.PodRunning, false)},
+		},
+		{
+			name: "pod pending",
+			pods: []corev1.Pod{tempoPod("docker.io/grafana/tempo:2.7.2", corev1.PodPending, false)},
+		},
+		{
+			name: "image pinned by digest",
+			pods: []corev1.Pod{
+				tempoPod("docker.io/grafana/tempo:2.7.2", corev1.PodRunning, true),
+				tempoPod("docker.io/grafana/tempo@sha256:0a4b1d5e2e0c9e9a0b3f1d6b1f1a3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f3a5b", corev1.PodRunning, true),
+			},
+		},
+		{
+			name: "image tag is not a version",
+			pods: []corev1.Pod{tempoPod("registry.example.com/tempo:custom", corev1.PodRunning, true)},
+		},
+		{
+			name:     "image tag and digest",
+			pods:     []corev1.Pod{tempoPod("docker.io/grafana/tempo:2.8.1@sha256:0a4b1d5e2e0c9e9a0b3f1d6b1f1a3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f3a5b", corev1.PodRunning, true)},
+			expected: "2.8.1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, runningTempoVersion(test.pods))
+		})
+	}
+}
+
+func TestRefreshRunningTempoVersion(t *testing.T) {
+	c := &statusClientStub{}
+	c.GetPodsComponentStub = func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
+		if componentName == manifestutils.GatewayComponentName {
+			return &corev1.PodList{Items: []corev1.Pod{tempoPod("docker.io/observatorium/api:1.2.3", corev1.PodRunning, true)}}, nil
+		}
+		return &corev1.PodList{Items: []corev1.Pod{tempoPod("docker.io/grafana/tempo:2.7.2", corev1.PodRunning, true)}}, nil
+	}
+
+	var patched *v1alpha1.TempoStack
+	c.PatchStatusStub = func(ctx context.Context, changed, original *v1alpha1.TempoStack) error {
+		patched = changed
+		return nil
+	}
+
+	stack := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-stack", Namespace: "some-ns"},
+		Spec:       v1alpha1.TempoStackSpec{TempoVersion: "2.7.2"},
+	}
+	err := Refresh(context.Background(), c, stack, &v1alpha1.TempoStackStatus{OperatorVersion: "0.1.0", TempoVersion: "2.8.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "2.7.2", patched.Status.TempoVersion)
+}
@@ -0,0 +1,102 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+)
+
+// maxStatusSpecDiffLength is the maximum length of the spec diff in the status of a CR.
+const maxStatusSpecDiffLength = 2048
+
+// Plan runs the upgrade of an UpgradeableCR in dry-run mode.
+// All changes of the upgrade steps and the update of the CR are sent to the API server in dry-run mode,
+// i.e. the admission webhooks validate the upgraded CR, but no changes are persisted.
+//
+// The returned status lists the upgrade steps which would run, the resulting changes of the spec,
+// and the upgrade step or validation which would fail. It is nil if the CR is already up-to-date.
+func (u Upgrade) Plan(ctx context.Context, original UpgradeableCR) (*v1alpha1.UpgradeStatus, error) {
+	u.Client = client.NewDryRunClient(u.Client)
+	u.dryRun = true
+
+	upgraded, upgradeStatus, err := u.upgradeSpec(ctx, original)
+	if upgradeStatus == nil || err != nil {
+		return upgradeStatus, nil
+	}
+
+	upgradeStatus.SpecDiff, err = specDiff(original, upgraded)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.Client.Patch(ctx, upgraded, client.MergeFrom(original))
+	if err != nil {
+		upgradeStatus.Message = fmt.Sprintf("the upgraded CR is invalid: %s", err)
+	}
+	return upgradeStatus, nil
+}
+
+// PendingStatus returns the upgrade status of an upgrade which is held by the upgrade policy,
+// i.e. the upgrade steps which run once the upgrade is approved or the maintenance window starts.
+// The upgrade runs in dry-run mode, see Plan.
+func (u Upgrade) PendingStatus(ctx context.Context, original UpgradeableCR) (*v1alpha1.UpgradeStatus, error) {
+	upgradeStatus, err := u.Plan(ctx, original)
+	if upgradeStatus != nil {
+		upgradeStatus.SpecDiff = truncateSpecDiff(upgradeStatus.SpecDiff)
+	}
+	return upgradeStatus, err
+}
+
+// truncateSpecDiff truncates the spec diff in the status of a CR at a line boundary,
+// to keep the size of the CR small.
+func truncateSpecDiff(diff string) string {
+	if len(diff) <= maxStatusSpecDiffLength {
+		return diff
+	}
+
+	truncated := diff[:maxStatusSpecDiffLength]
+	if i := strings.LastIndex(truncated, "\n"); i >= 0 {
+		truncated = truncated[:i+1]
+	}
+	return truncated + "... (truncated, run tempo-operator upgrade --dry-run to show the full diff)\n"
+}
+
+// specDiff returns the line-based diff of the YAML representation of the spec of both CRs.
+func specDiff(original UpgradeableCR, upgraded UpgradeableCR) (string, error) {
+	originalSpec, err := specYAML(original)
+	if err != nil {
+		return "", err
+	}
+	upgradedSpec, err := specYAML(upgraded)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(originalSpec),
+		B:        difflib.SplitLines(upgradedSpec),
+		FromFile: "original",
+		ToFile:   "upgraded",
+		Context:  3,
+	})
+}
+
+func specYAML(cr UpgradeableCR) (string, error) {
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cr)
+	if err != nil {
+		return "", err
+	}
+
+	spec, err := yaml.Marshal(fields["spec"])
+	if err != nil {
+		return "", err
+	}
+	return string(spec), nil
+}
@@ -0,0 +1,20 @@
+package upgrade
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateSpecDiff(t *testing.T) {
+	short := "-  a: 1\n+  a: 2\n"
+	require.Equal(t, short, truncateSpecDiff(short))
+
+	line := "+  " + strings.Repeat("x", 97) + "\n"
+	long := strings.Repeat(line, 100)
+	truncated := truncateSpecDiff(long)
+	require.LessOrEqual(t, len(truncated), maxStatusSpecDiffLength+100)
+	require.True(t, strings.HasPrefix(truncated, strings.Repeat(line, maxStatusSpecDiffLength/len(line))))
+	require.True(t, strings.HasSuffix(truncated, line+"... (truncated, run tempo-operator upgrade --dry-run to show the full diff)\n"))
+}
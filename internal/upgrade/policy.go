@@ -0,0 +1,84 @@
+package upgrade
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+)
+
+// defaultMaintenanceWindowDuration is the duration of a maintenance window without an explicit duration.
+const defaultMaintenanceWindowDuration = time.Hour
+
+// Hold checks if the upgrade policy of a CR holds the upgrade to a new operator version.
+// It returns the UpgradePending condition if the upgrade is held, and the time until the next maintenance window,
+// or nil if the CR can be upgraded now.
+func Hold(cr UpgradeableCR, policy *v1alpha1.UpgradePolicySpec, toVersion string, now time.Time) (*metav1.Condition, time.Duration, error) {
+	if policy == nil {
+		return nil, 0, nil
+	}
+
+	switch policy.Mode {
+	case v1alpha1.UpgradePolicyModeManual:
+		if cr.GetAnnotations()[v1alpha1.ApproveUpgradeAnnotation] == toVersion {
+			return nil, 0, nil
+		}
+		return &metav1.Condition{
+			Type:   string(v1alpha1.ConditionUpgradePending),
+			Status: metav1.ConditionTrue,
+			Reason: string(v1alpha1.ReasonUpgradeAwaitingApproval),
+			Message: fmt.Sprintf("The upgrade from operator version %s to %s is pending, approve it with the annotation %s: \"%s\"",
+				cr.GetOperatorVersion(), toVersion, v1alpha1.ApproveUpgradeAnnotation, toVersion),
+		}, 0, nil
+
+	case v1alpha1.UpgradePolicyModeWindow:
+		if policy.MaintenanceWindow == nil {
+			return nil, 0, fmt.Errorf("the window upgrade policy requires a maintenance window")
+		}
+		start, err := nextMaintenanceWindow(*policy.MaintenanceWindow, now)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !start.After(now) {
+			return nil, 0, nil
+		}
+		return &metav1.Condition{
+			Type:   string(v1alpha1.ConditionUpgradePending),
+			Status: metav1.ConditionTrue,
+			Reason: string(v1alpha1.ReasonUpgradeAwaitingMaintenanceWindow),
+			Message: fmt.Sprintf("The upgrade from operator version %s to %s is pending until the next maintenance window at %s",
+				cr.GetOperatorVersion(), toVersion, start.Format(time.RFC3339)),
+		}, start.Sub(now), nil
+	}
+
+	return nil, 0, nil
+}
+
+// nextMaintenanceWindow returns the start of the current maintenance window,
+// or the start of the next maintenance window if now is outside of a maintenance window.
+func nextMaintenanceWindow(spec v1alpha1.MaintenanceWindowSpec, now time.Time) (time.Time, error) {
+	location := time.UTC
+	if spec.TimeZone != "" {
+		var err error
+		location, err = time.LoadLocation(spec.TimeZone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time zone of the maintenance window: %w", err)
+		}
+	}
+
+	schedule, err := cron.ParseStandard(spec.Schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule of the maintenance window: %w", err)
+	}
+
+	duration := defaultMaintenanceWindowDuration
+	if spec.Duration != nil {
+		duration = spec.Duration.Duration
+	}
+
+	// the first maintenance window which ends after now
+	return schedule.Next(now.In(location).Add(-duration)), nil
+}
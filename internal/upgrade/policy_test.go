@@ -0,0 +1,131 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
+)
+
+func TestHold(t *testing.T) {
+	maintenanceWindow := &v1alpha1.UpgradePolicySpec{
+		Mode: v1alpha1.UpgradePolicyModeWindow,
+		MaintenanceWindow: &v1alpha1.MaintenanceWindowSpec{
+			Schedule: "0 2 * * 6",
+			Duration: &metav1.Duration{Duration: 2 * time.Hour},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		policy       *v1alpha1.UpgradePolicySpec
+		now          time.Time
+		expected     *metav1.Condition
+		requeueAfter time.Duration
+		err          string
+	}{
+		{
+			name: "no upgrade policy",
+		},
+		{
+			name:   "auto upgrade policy",
+			policy: &v1alpha1.UpgradePolicySpec{Mode: v1alpha1.UpgradePolicyModeAuto},
+		},
+		{
+			name:   "manual upgrade policy without approval",
+			policy: &v1alpha1.UpgradePolicySpec{Mode: v1alpha1.UpgradePolicyModeManual},
+			expected: &metav1.Condition{
+				Type:    string(v1alpha1.ConditionUpgradePending),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(v1alpha1.ReasonUpgradeAwaitingApproval),
+				Message: `The upgrade from operator version 0.15.0 to 0.16.0 is pending, approve it with the annotation tempo.grafana.com/approve-upgrade: "0.16.0"`,
+			},
+		},
+		{
+			name:        "manual upgrade policy approved for a previous version",
+			annotations: map[string]string{v1alpha1.ApproveUpgradeAnnotation: "0.15.0"},
+			policy:      &v1alpha1.UpgradePolicySpec{Mode: v1alpha1.UpgradePolicyModeManual},
+			expected: &metav1.Condition{
+				Type:    string(v1alpha1.ConditionUpgradePending),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(v1alpha1.ReasonUpgradeAwaitingApproval),
+				Message: `The upgrade from operator version 0.15.0 to 0.16.0 is pending, approve it with the annotation tempo.grafana.com/approve-upgrade: "0.16.0"`,
+			},
+		},
+		{
+			name:        "manual upgrade policy approved",
+			annotations: map[string]string{v1alpha1.ApproveUpgradeAnnotation: "0.16.0"},
+			policy:      &v1alpha1.UpgradePolicySpec{Mode: v1alpha1.UpgradePolicyModeManual},
+		},
+		{
+			name:   "in maintenance window",
+			policy: maintenanceWindow,
+			now:    time.Date(2026, 10, 17, 3, 59, 0, 0, time.UTC),
+		},
+		{
+			name:   "outside of maintenance window",
+			policy: maintenanceWindow,
+			now:    time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC),
+			expected: &metav1.Condition{
+				Type:    string(v1alpha1.ConditionUpgradePending),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(v1alpha1.ReasonUpgradeAwaitingMaintenanceWindow),
+				Message: "The upgrade from operator version 0.15.0 to 0.16.0 is pending until the next maintenance window at 2026-10-24T02:00:00Z",
+			},
+			requeueAfter: 6*24*time.Hour + 22*time.Hour,
+		},
+		{
+			name: "maintenance window with time zone",
+			policy: &v1alpha1.UpgradePolicySpec{
+				Mode: v1alpha1.UpgradePolicyModeWindow,
+				MaintenanceWindow: &v1alpha1.MaintenanceWindowSpec{
+					Schedule: "0 2 * * 6",
+					TimeZone: "Europe/Berlin",
+				},
+			},
+			now: time.Date(2026, 10, 17, 1, 30, 0, 0, time.UTC),
+			expected: &metav1.Condition{
+				Type:    string(v1alpha1.ConditionUpgradePending),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(v1alpha1.ReasonUpgradeAwaitingMaintenanceWindow),
+				Message: "The upgrade from operator version 0.15.0 to 0.16.0 is pending until the next maintenance window at 2026-10-24T02:00:00+02:00",
+			},
+			requeueAfter: 6*24*time.Hour + 22*time.Hour + 30*time.Minute,
+		},
+		{
+			name:   "window upgrade policy without maintenance window",
+			policy: &v1alpha1.UpgradePolicySpec{Mode: v1alpha1.UpgradePolicyModeWindow},
+			err:    "the window upgrade policy requires a maintenance window",
+		},
+		{
+			name: "invalid schedule",
+			policy: &v1alpha1.UpgradePolicySpec{
+				Mode:              v1alpha1.UpgradePolicyModeWindow,
+				MaintenanceWindow: &v1alpha1.MaintenanceWindowSpec{Schedule: "invalid"},
+			},
+			err: "invalid schedule of the maintenance window: expected exactly 5 fields, found 1: [invalid]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := &v1alpha1.TempoStack{
+				ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations},
+				Status:     v1alpha1.TempoStackStatus{OperatorVersion: "0.15.0"},
+			}
+
+			condition, requeueAfter, err := Hold(tempo, test.policy, "0.16.0", test.now)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, condition)
+			require.Equal(t, test.requeueAfter, requeueAfter)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -43,6 +44,9 @@ type Upgrade struct {
 	CtrlConfig configv1alpha1.ProjectConfig
 	Version    version.Version
 	Log        logr.Logger
+
+	// dryRun skips waiting for the changes of the upgrade steps, which are never applied in dry-run mode.
+	dryRun bool
 }
 
 // UpgradeableCR defines functions required for upgrading a managed Custom Resource (TempoStack, TempoMonolithic).
@@ -50,7 +54,7 @@ type UpgradeableCR interface {
 	client.Object
 	GetOperatorVersion() string
 	SetOperatorVersion(v string)
-	SetTempoVersion(v string)
+	SetUpgradeStatus(s *v1alpha1.UpgradeStatus)
 	GetStatus() any
 	SetStatus(s any)
 }
@@ -66,12 +70,20 @@ func (u Upgrade) Upgrade(ctx context.Context, original UpgradeableCR) (Upgradeab
 		"to_version", u.Version.OperatorVersion,
 	)
 
-	upgraded, err := u.upgradeSpec(ctx, original)
+	upgraded, upgradeStatus, err := u.upgradeSpec(ctx, original)
 	if err != nil {
 		msg := "automated upgrade is not possible, the CR instance must be corrected manually"
 		itemLogger.Info(msg)
 		u.Recorder.Event(original, corev1.EventTypeWarning, "FailedUpgrade", msg)
 		metricUpgrades.WithLabelValues(kind, metricUpgradesStateFailed).Inc()
+
+		// show the failed upgrade step in the status
+		failed := original.DeepCopyObject().(UpgradeableCR)
+		failed.SetUpgradeStatus(upgradeStatus)
+		if err := u.Client.Status().Patch(ctx, failed, client.MergeFrom(original)); err != nil {
+			itemLogger.Error(err, "failed to apply upgrade status to instance's status object")
+		}
+
 		return original, &status.ConfigurationError{
 			Message: fmt.Sprintf("error during upgrade: %s", err),
 			Reason:  v1alpha1.ReasonFailedUpgrade,
@@ -80,6 +92,13 @@ func (u Upgrade) Upgrade(ctx context.Context, original UpgradeableCR) (Upgradeab
 
 	// only save if there were changes to the CR
 	if !reflect.DeepEqual(upgraded, original) {
+		diff, err := specDiff(original, upgraded)
+		if err != nil {
+			itemLogger.Error(err, "failed to compute the changes of the spec")
+		}
+		upgradeStatus.SpecDiff = truncateSpecDiff(diff)
+		upgraded.SetUpgradeStatus(upgradeStatus)
+
 		// the resource update overrides the status, so, keep it so that we can reset it later
 		status := upgraded.GetStatus()
 		patch := client.MergeFrom(original)
@@ -105,35 +124,42 @@ func (u Upgrade) Upgrade(ctx context.Context, original UpgradeableCR) (Upgradeab
 }
 
 // upgradeSpec upgrades a single CR to the latest known version.
-// It runs all upgrade procedures between the current and latest operator version.
+// It runs all upgrade procedures between the current and latest operator version,
+// and returns the performed upgrade steps, or nil if the CR is already up-to-date.
 // Note: This method does not patch the CR in the cluster.
-func (u Upgrade) upgradeSpec(ctx context.Context, original UpgradeableCR) (UpgradeableCR, error) {
+func (u Upgrade) upgradeSpec(ctx context.Context, original UpgradeableCR) (UpgradeableCR, *v1alpha1.UpgradeStatus, error) {
 	kind := original.GetObjectKind().GroupVersionKind().Kind
 	log := u.Log.WithValues("namespace", original.GetNamespace(), "name", original.GetName(), "kind", kind)
 
 	// do not mutate the CR in place, otherwise a broken upgrade step can result in an inconsistent state
 	upgraded := original.DeepCopyObject().(UpgradeableCR)
+	upgradeStatus := &v1alpha1.UpgradeStatus{
+		FromVersion: original.GetOperatorVersion(),
+		ToVersion:   u.Version.OperatorVersion,
+	}
 
 	if original.GetOperatorVersion() == u.Version.OperatorVersion {
 		log.Info("instance is already up-to-date", "version", original.GetOperatorVersion())
-		return original, nil
+		return original, nil, nil
 	}
 
 	instanceVersion, err := semver.NewVersion(original.GetOperatorVersion())
 	if err != nil {
 		log.Error(err, "failed to parse instance operator version", "version", original.GetOperatorVersion())
-		return original, err
+		upgradeStatus.Message = fmt.Sprintf("invalid operator version of the instance: %s", err)
+		return original, upgradeStatus, err
 	}
 
 	operatorVersion, err := semver.NewVersion(u.Version.OperatorVersion)
 	if err != nil {
 		u.Log.Error(err, "failed to parse current operator version", "version", u.Version.OperatorVersion)
-		return original, err
+		upgradeStatus.Message = fmt.Sprintf("invalid operator version: %s", err)
+		return original, upgradeStatus, err
 	}
 
 	if instanceVersion.GreaterThan(operatorVersion) {
 		log.Info("skipping upgrading this instance because it's newer than the current running operator version", "version", original.GetOperatorVersion(), "operator_version", operatorVersion.String())
-		return original, nil
+		return original, nil, nil
 	}
 
 	for _, availableUpgrade := range upgrades {
@@ -144,31 +170,46 @@ func (u Upgrade) upgradeSpec(ctx context.Context, original UpgradeableCR) (Upgra
 			// to the UpgradeableCR interface, because otherwise the v1alpha1 package would import the upgrade package,
 			// however the upgrade package already imports the v1alpha1 package, resulting in an import loop.
 			var err error
+			ran := false
 			switch t := upgraded.(type) {
 			case *v1alpha1.TempoStack:
 				if availableUpgrade.upgradeTempoStack != nil {
+					ran = true
 					err = availableUpgrade.upgradeTempoStack(ctx, u, t)
 				}
 			case *v1alpha1.TempoMonolithic:
 				if availableUpgrade.upgradeTempoMonolithic != nil {
+					ran = true
 					err = availableUpgrade.upgradeTempoMonolithic(ctx, u, t)
 				}
 			}
 
 			if err != nil {
 				itemLogger.Error(err, "failed to run upgrade step")
-				return original, err
+				upgradeStatus.FailedStep = availableUpgrade.version.String()
+				upgradeStatus.Message = err.Error()
+				return original, upgradeStatus, err
 			}
 
+			if ran {
+				upgradeStatus.Steps = append(upgradeStatus.Steps, availableUpgrade.version.String())
+			}
 			itemLogger.V(1).Info("performed upgrade step")
 			upgraded.SetOperatorVersion(availableUpgrade.version.String())
 		}
 	}
 
-	// at the end of the upgrade process, the CR is up to date with the current running component versions (Operator, Tempo, TempoQuery)
-	// update all component versions in the Status field of the CR with the current running versions
+	// at the end of the upgrade process, the CR is up to date with the current operator version.
+	// The Tempo version in the Status field is updated once the pods run the new Tempo version.
 	upgraded.SetOperatorVersion(u.Version.OperatorVersion)
-	upgraded.SetTempoVersion(u.Version.TempoVersion)
 
-	return upgraded, nil
+	// the upgrade is not held by the upgrade policy anymore
+	switch t := upgraded.(type) {
+	case *v1alpha1.TempoStack:
+		meta.RemoveStatusCondition(&t.Status.Conditions, string(v1alpha1.ConditionUpgradePending))
+	case *v1alpha1.TempoMonolithic:
+		meta.RemoveStatusCondition(&t.Status.Conditions, string(v1alpha1.ConditionUpgradePending))
+	}
+
+	return upgraded, upgradeStatus, nil
 }
@@ -78,9 +78,16 @@ func TestUpgradeTempoStackToLatest(t *testing.T) {
 	err = k8sClient.Get(context.Background(), nsn, &upgradedTempo)
 	assert.NoError(t, err)
 
-	// assert versions were updated
+	// assert versions were updated, the Tempo version is updated once the pods run the new version
 	assert.Equal(t, currentV.OperatorVersion, upgradedTempo.Status.OperatorVersion)
-	assert.Equal(t, currentV.TempoVersion, upgradedTempo.Status.TempoVersion)
+	assert.Empty(t, upgradedTempo.Status.TempoVersion)
+
+	// assert upgrade steps were recorded
+	require.NotNil(t, upgradedTempo.Status.Upgrade)
+	assert.Equal(t, "0.0.1", upgradedTempo.Status.Upgrade.FromVersion)
+	assert.Equal(t, currentV.OperatorVersion, upgradedTempo.Status.Upgrade.ToVersion)
+	assert.Equal(t, []string{"0.1.0", "0.3.0", "0.5.0", "0.6.0", "0.8.0", "0.11.0", "0.15.4"}, upgradedTempo.Status.Upgrade.Steps)
+	assert.Empty(t, upgradedTempo.Status.Upgrade.Message)
 }
 
 func TestUpgradeTempoMonolithicToLatest(t *testing.T) {
@@ -124,9 +131,14 @@ func TestUpgradeTempoMonolithicToLatest(t *testing.T) {
 	err = k8sClient.Get(context.Background(), nsn, &upgradedTempo)
 	assert.NoError(t, err)
 
-	// assert versions were updated
+	// assert versions were updated, the Tempo version is updated once the pods run the new version
 	assert.Equal(t, currentV.OperatorVersion, upgradedTempo.Status.OperatorVersion)
-	assert.Equal(t, currentV.TempoVersion, upgradedTempo.Status.TempoVersion)
+	assert.Empty(t, upgradedTempo.Status.TempoVersion)
+
+	// assert upgrade steps were recorded
+	require.NotNil(t, upgradedTempo.Status.Upgrade)
+	assert.Equal(t, "0.0.0", upgradedTempo.Status.Upgrade.FromVersion)
+	assert.Equal(t, []string{"0.11.0"}, upgradedTempo.Status.Upgrade.Steps)
 }
 
 func TestSkipUpgrade(t *testing.T) {
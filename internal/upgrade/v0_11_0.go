@@ -104,6 +104,9 @@ func scale_down_ingester(ctx context.Context, u Upgrade, ingesterQuery client.Ob
 	if err != nil {
 		return err
 	}
+	if u.dryRun {
+		return nil
+	}
 
 	return wait.PollUntilContextTimeout(ctx, pollInterval, pollTimeout, true, func(ctx context.Context) (done bool, err error) {
 		ingester := &appsv1.StatefulSet{}
@@ -178,6 +181,9 @@ func chown_pvcs(ctx context.Context, u Upgrade, tempo metav1.Object, nodeSelecto
 	if err != nil {
 		return err
 	}
+	if u.dryRun {
+		return nil
+	}
 	return wait.PollUntilContextTimeout(ctx, pollInterval, pollTimeout, true, func(ctx context.Context) (done bool, err error) {
 		job := &batchv1.Job{}
 		objectKey := client.ObjectKey{
@@ -44,7 +44,7 @@ func TestRemoveDeprecatedFields(t *testing.T) {
 		Log:      logger,
 	}
 
-	u, err := upgrade.upgradeSpec(context.Background(), &tempo)
+	u, _, err := upgrade.upgradeSpec(context.Background(), &tempo)
 	require.NoError(t, err)
 	upgraded := u.(*v1alpha1.TempoStack)
 	require.Nil(t, upgraded.Spec.LimitSpec.Global.Query.MaxSearchBytesPerTrace)
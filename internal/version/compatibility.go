@@ -0,0 +1,95 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// TempoFeatures lists the configuration options which depend on the Tempo version.
+type TempoFeatures struct {
+	// TempoQueryTuning enables the services_query_duration and find_traces_concurrent_requests options of tempo-query.
+	TempoQueryTuning bool
+	// IngestStorage enables the Kafka ingest storage and the block-builder component.
+	IngestStorage bool
+	// CostAttribution enables the cost_attribution overrides.
+	CostAttribution bool
+}
+
+// TempoRelease is a minor release of Tempo supported by the operator.
+type TempoRelease struct {
+	// Version is the minor version of the release, e.g. 2.8.
+	Version  string
+	Features TempoFeatures
+}
+
+// CompatibilityMatrix lists the Tempo releases supported by this operator version, from the oldest to the newest release.
+// The newest release must match the Tempo version the operator is built for.
+var CompatibilityMatrix = []TempoRelease{
+	{
+		Version:  "2.6",
+		Features: TempoFeatures{},
+	},
+	{
+		Version: "2.7",
+		Features: TempoFeatures{
+			TempoQueryTuning: true,
+		},
+	},
+	{
+		Version: "2.8",
+		Features: TempoFeatures{
+			TempoQueryTuning: true,
+			IngestStorage:    true,
+			CostAttribution:  true,
+		},
+	},
+}
+
+// SupportedTempoVersions returns the supported Tempo releases, e.g. 2.6.x, 2.7.x.
+func SupportedTempoVersions() string {
+	versions := make([]string, len(CompatibilityMatrix))
+	for i, release := range CompatibilityMatrix {
+		versions[i] = release.Version + ".x"
+	}
+	return strings.Join(versions, ", ")
+}
+
+// TempoReleaseFor returns the release of a Tempo version in the compatibility matrix, e.g. 2.8 for Tempo 2.8.1.
+// An empty version selects the release the operator is built for.
+func TempoReleaseFor(tempoVersion string) (TempoRelease, error) {
+	if tempoVersion == "" {
+		return CompatibilityMatrix[len(CompatibilityMatrix)-1], nil
+	}
+
+	v, err := semver.StrictNewVersion(tempoVersion)
+	if err != nil {
+		return TempoRelease{}, fmt.Errorf("invalid Tempo version %s: %w", tempoVersion, err)
+	}
+
+	minor := fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+	for _, release := range CompatibilityMatrix {
+		if release.Version == minor {
+			return release, nil
+		}
+	}
+	return TempoRelease{}, fmt.Errorf("unsupported Tempo version %s, the supported versions of this operator version are %s",
+		tempoVersion, SupportedTempoVersions())
+}
+
+// TempoFeaturesFor returns the configuration features of a Tempo version.
+// An empty version selects the Tempo version the operator is built for, which supports all features.
+func TempoFeaturesFor(tempoVersion string) (TempoFeatures, error) {
+	release, err := TempoReleaseFor(tempoVersion)
+	return release.Features, err
+}
+
+// TempoVersionFor returns the Tempo version of an instance, i.e. the pinned Tempo version
+// or the Tempo version the operator is built for.
+func TempoVersionFor(tempoVersion string) string {
+	if tempoVersion != "" {
+		return tempoVersion
+	}
+	return Get().TempoVersion
+}
@@ -0,0 +1,62 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTempoFeaturesFor(t *testing.T) {
+	tests := []struct {
+		tempoVersion string
+		expected     TempoFeatures
+		err          string
+	}{
+		{
+			tempoVersion: "",
+			expected:     TempoFeatures{TempoQueryTuning: true, IngestStorage: true, CostAttribution: true},
+		},
+		{
+			tempoVersion: "2.8.1",
+			expected:     TempoFeatures{TempoQueryTuning: true, IngestStorage: true, CostAttribution: true},
+		},
+		{
+			tempoVersion: "2.7.0",
+			expected:     TempoFeatures{TempoQueryTuning: true},
+		},
+		{
+			tempoVersion: "2.6.3",
+			expected:     TempoFeatures{},
+		},
+		{
+			tempoVersion: "2.5.0",
+			err:          "unsupported Tempo version 2.5.0, the supported versions of this operator version are 2.6.x, 2.7.x, 2.8.x",
+		},
+		{
+			tempoVersion: "3.0.0",
+			err:          "unsupported Tempo version 3.0.0, the supported versions of this operator version are 2.6.x, 2.7.x, 2.8.x",
+		},
+		{
+			tempoVersion: "2.8",
+			err:          "invalid Tempo version 2.8: Invalid Semantic Version",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tempoVersion, func(t *testing.T) {
+			features, err := TempoFeaturesFor(test.tempoVersion)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, features)
+		})
+	}
+}
+
+func TestTempoVersionFor(t *testing.T) {
+	assert.Equal(t, "2.7.2", TempoVersionFor("2.7.2"))
+	assert.Equal(t, Get().TempoVersion, TempoVersionFor(""))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -106,9 +107,22 @@ func (v *monolithicValidator) validateTempoMonolithic(ctx context.Context, tempo
 
 	errors = append(errors, validateName(tempo.Name)...)
 	addValidationResults(v.validateStorage(ctx, tempo))
+	if tempo.Spec.Storage != nil {
+		var retention time.Duration
+		if tempo.Spec.Retention != nil {
+			retention = tempo.Spec.Retention.Global.Traces.Duration
+		}
+		errors = append(errors, validateStorageTuning(field.NewPath("spec").Child("storage").Child("tuning"), tempo.Spec.Storage.Tuning, retention)...)
+		errors = append(errors, validateDedicatedColumns(field.NewPath("spec").Child("storage").Child("dedicatedColumns"), tempo.Spec.Storage.DedicatedColumns)...)
+	}
 	errors = append(errors, v.validateJaegerUI(tempo)...)
 	errors = append(errors, v.validateMultitenancy(ctx, tempo)...)
 	errors = append(errors, v.validateObservability(tempo)...)
+	errors = append(errors, v.validateMetricsGenerator(tempo)...)
+	errors = append(errors, v.validateRetention(tempo)...)
+	errors = append(errors, v.validateLimits(tempo)...)
+	errors = append(errors, validateUpgradePolicy(field.NewPath("spec").Child("upgradePolicy"), tempo.Spec.UpgradePolicy)...)
+	errors = append(errors, v.validateTempoVersion(tempo)...)
 	errors = append(errors, v.validateServiceAccount(ctx, tempo)...)
 	errors = append(errors, v.validateConflictWithTempoStack(ctx, tempo)...)
 
@@ -156,6 +170,24 @@ func (v *monolithicValidator) validateJaegerUI(tempo tempov1alpha1.TempoMonolith
 			"the openshiftRoute feature gate must be enabled to create a route for Jaeger UI",
 		)}
 	}
+
+	if tempo.Spec.JaegerUI.GatewayAPI != nil && tempo.Spec.JaegerUI.GatewayAPI.Enabled {
+		if !tempo.Spec.JaegerUI.Enabled {
+			return field.ErrorList{field.Invalid(
+				jaegerUIBase.Child("gatewayAPI", "enabled"),
+				tempo.Spec.JaegerUI.GatewayAPI.Enabled,
+				"Jaeger UI must be enabled to create a HTTPRoute for Jaeger UI",
+			)}
+		}
+
+		if !v.ctrlConfig.Gates.GatewayAPI {
+			return field.ErrorList{field.Invalid(
+				jaegerUIBase.Child("gatewayAPI", "enabled"),
+				tempo.Spec.JaegerUI.GatewayAPI.Enabled,
+				"the gatewayAPI feature gate must be enabled to create a HTTPRoute for Jaeger UI",
+			)}
+		}
+	}
 	if tempo.Spec.Query != nil && tempo.Spec.Query.RBAC.Enabled && tempo.Spec.JaegerUI.Enabled {
 		return field.ErrorList{
 			field.Invalid(field.NewPath("spec", "rbac", "enabled"), tempo.Spec.Query.RBAC.Enabled,
@@ -261,6 +293,122 @@ func (v *monolithicValidator) validateObservability(tempo tempov1alpha1.TempoMon
 	return nil
 }
 
+func (v *monolithicValidator) validateMetricsGenerator(tempo tempov1alpha1.TempoMonolithic) field.ErrorList {
+	if tempo.Spec.MetricsGenerator == nil || !tempo.Spec.MetricsGenerator.Enabled {
+		return nil
+	}
+
+	base := field.NewPath("spec", "metricsGenerator")
+	remoteWrite := make([]tempov1alpha1.MetricsGeneratorRemoteWriteSpec, 0, len(tempo.Spec.MetricsGenerator.RemoteWrite))
+	for i, rw := range tempo.Spec.MetricsGenerator.RemoteWrite {
+		if rw.BasicAuth != nil && rw.BearerToken != nil {
+			return field.ErrorList{field.Invalid(
+				base.Child("remoteWrite").Index(i),
+				rw.URL,
+				"basicAuth and bearerToken cannot be configured at the same time",
+			)}
+		}
+		remoteWrite = append(remoteWrite, rw.MetricsGeneratorRemoteWriteSpec)
+	}
+
+	return validateMetricsGeneratorRemoteWrite(base, tempo.Spec.MetricsGenerator.Processors, remoteWrite)
+}
+
+func (v *monolithicValidator) validateRetention(tempo tempov1alpha1.TempoMonolithic) field.ErrorList {
+	if tempo.Spec.Retention == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	base := field.NewPath("spec", "retention")
+	if tempo.Spec.Retention.Global.Traces.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(base.Child("global", "traces"),
+			tempo.Spec.Retention.Global.Traces.Duration.String(), "must be greater than or equal to 0"))
+	}
+
+	if len(tempo.Spec.Retention.PerTenant) > 0 && !multitenancyEnabled(tempo) {
+		return append(allErrs, field.Invalid(base.Child("perTenant"), tempo.Spec.Retention.PerTenant,
+			"per-tenant retention requires multi-tenancy to be enabled in spec.multitenancy"))
+	}
+	for _, tenant := range sortedKeys(tempo.Spec.Retention.PerTenant) {
+		retention := tempo.Spec.Retention.PerTenant[tenant].Traces.Duration
+		if retention <= 0 {
+			allErrs = append(allErrs, field.Invalid(base.Child("perTenant").Key(tenant).Child("traces"),
+				retention.String(), "must be greater than 0"))
+		}
+	}
+	return allErrs
+}
+
+func (v *monolithicValidator) validateTempoVersion(tempo tempov1alpha1.TempoMonolithic) field.ErrorList {
+	defaultImages := []string{v.ctrlConfig.DefaultImages.Tempo}
+	if tempo.Spec.JaegerUI != nil && tempo.Spec.JaegerUI.Enabled {
+		defaultImages = append(defaultImages, v.ctrlConfig.DefaultImages.TempoQuery)
+	}
+
+	tempoVersion := tempo.Spec.TempoVersion
+	features, allErrs := validateTempoVersion(field.NewPath("spec").Child("tempoVersion"), tempoVersion, defaultImages...)
+	if len(allErrs) > 0 || tempo.Spec.Limits == nil {
+		return allErrs
+	}
+	return validateCostAttribution(field.NewPath("spec").Child("limits"), *tempo.Spec.Limits, tempoVersion, features)
+}
+
+func (v *monolithicValidator) validateLimits(tempo tempov1alpha1.TempoMonolithic) field.ErrorList {
+	if tempo.Spec.Limits == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	base := field.NewPath("spec", "limits")
+
+	global := tempo.Spec.Limits.Global
+	allErrs = append(allErrs, validateRateLimitSpec(base.Child("global"), global)...)
+	if len(global.Ingestion.MetricsGeneratorProcessors) > 0 {
+		allErrs = append(allErrs, field.Invalid(
+			base.Child("global").Child("ingestion").Child("metricsGeneratorProcessors"),
+			global.Ingestion.MetricsGeneratorProcessors,
+			"the processors of all tenants must be configured in spec.metricsGenerator.processors",
+		))
+	}
+
+	if len(tempo.Spec.Limits.PerTenant) > 0 && !multitenancyEnabled(tempo) {
+		return append(allErrs, field.Invalid(base.Child("perTenant"), tempo.Spec.Limits.PerTenant,
+			"per-tenant limits require multi-tenancy to be enabled in spec.multitenancy"))
+	}
+	for _, tenant := range sortedKeys(tempo.Spec.Limits.PerTenant) {
+		limits := tempo.Spec.Limits.PerTenant[tenant]
+		path := base.Child("perTenant").Key(tenant)
+		allErrs = append(allErrs, validateRateLimitSpec(path, limits)...)
+
+		processorsPath := path.Child("ingestion").Child("metricsGeneratorProcessors")
+		for _, processor := range limits.Ingestion.MetricsGeneratorProcessors {
+			if tempo.Spec.MetricsGenerator == nil || !tempo.Spec.MetricsGenerator.Enabled {
+				allErrs = append(allErrs, field.Invalid(
+					processorsPath,
+					limits.Ingestion.MetricsGeneratorProcessors,
+					"the metrics-generator must be enabled in spec.metricsGenerator",
+				))
+				break
+			}
+			if len(tempo.Spec.MetricsGenerator.RemoteWrite) == 0 &&
+				(processor == tempov1alpha1.MetricsGeneratorProcessorServiceGraphs || processor == tempov1alpha1.MetricsGeneratorProcessorSpanMetrics) {
+				allErrs = append(allErrs, field.Invalid(
+					processorsPath,
+					limits.Ingestion.MetricsGeneratorProcessors,
+					fmt.Sprintf("at least one remote-write endpoint is required in spec.metricsGenerator.remoteWrite when the %s processor is enabled", processor),
+				))
+				break
+			}
+		}
+	}
+	return allErrs
+}
+
+func multitenancyEnabled(tempo tempov1alpha1.TempoMonolithic) bool {
+	return tempo.Spec.Multitenancy != nil && tempo.Spec.Multitenancy.Enabled
+}
+
 func (v *monolithicValidator) validateServiceAccount(ctx context.Context, tempo tempov1alpha1.TempoMonolithic) field.ErrorList {
 	if tempo.Spec.ServiceAccount == "" {
 		return nil
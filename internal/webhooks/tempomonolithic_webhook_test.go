@@ -3,6 +3,7 @@ package webhooks
 import (
 	"context"
 	"testing"
+	"time"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/api/config/v1alpha1"
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
@@ -14,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -126,6 +128,47 @@ func TestMonolithicValidate(t *testing.T) {
 			warnings: admission.Warnings{},
 			errors:   field.ErrorList{},
 		},
+		{
+			name: "JaegerUI HTTPRoute enabled but gatewayAPI feature gate not set",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					JaegerUI: &v1alpha1.MonolithicJaegerUISpec{
+						Enabled: true,
+						GatewayAPI: &v1alpha1.MonolithicJaegerUIGatewayAPISpec{
+							Enabled:   true,
+							ParentRef: v1alpha1.GatewayParentReference{Name: "public"},
+						},
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "jaegerui", "gatewayAPI", "enabled"),
+				true,
+				"the gatewayAPI feature gate must be enabled to create a HTTPRoute for Jaeger UI",
+			)},
+		},
+		{
+			name: "JaegerUI HTTPRoute enabled and gatewayAPI feature gate set",
+			ctrlConfig: configv1alpha1.ProjectConfig{
+				Gates: configv1alpha1.FeatureGates{
+					GatewayAPI: true,
+				},
+			},
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					JaegerUI: &v1alpha1.MonolithicJaegerUISpec{
+						Enabled: true,
+						GatewayAPI: &v1alpha1.MonolithicJaegerUIGatewayAPISpec{
+							Enabled:   true,
+							ParentRef: v1alpha1.GatewayParentReference{Name: "public"},
+						},
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors:   field.ErrorList{},
+		},
 
 		// multitenancy
 		{
@@ -391,6 +434,231 @@ func TestMonolithicValidate(t *testing.T) {
 			)},
 		},
 
+		// metrics-generator
+		{
+			name: "metrics-generator with span-metrics but without remote-write",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					MetricsGenerator: &v1alpha1.MonolithicMetricsGeneratorSpec{
+						Enabled: true,
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "metricsGenerator", "remoteWrite"),
+				[]v1alpha1.MetricsGeneratorRemoteWriteSpec{},
+				"at least one remote-write endpoint is required when the service-graphs processor is enabled",
+			)},
+		},
+		{
+			name: "metrics-generator with basic auth and bearer token",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					MetricsGenerator: &v1alpha1.MonolithicMetricsGeneratorSpec{
+						Enabled: true,
+						RemoteWrite: []v1alpha1.MonolithicMetricsGeneratorRemoteWriteSpec{{
+							MetricsGeneratorRemoteWriteSpec: v1alpha1.MetricsGeneratorRemoteWriteSpec{
+								URL: "http://prometheus:9090/api/v1/write",
+							},
+							BasicAuth:   &v1alpha1.MonolithicRemoteWriteBasicAuthSpec{Secret: "basic"},
+							BearerToken: &v1alpha1.MonolithicRemoteWriteBearerTokenSpec{Secret: "token"},
+						}},
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "metricsGenerator", "remoteWrite").Index(0),
+				"http://prometheus:9090/api/v1/write",
+				"basicAuth and bearerToken cannot be configured at the same time",
+			)},
+		},
+		{
+			name: "metrics-generator with invalid remote-write URL",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					MetricsGenerator: &v1alpha1.MonolithicMetricsGeneratorSpec{
+						Enabled: true,
+						RemoteWrite: []v1alpha1.MonolithicMetricsGeneratorRemoteWriteSpec{{
+							MetricsGeneratorRemoteWriteSpec: v1alpha1.MetricsGeneratorRemoteWriteSpec{
+								URL: "/api/v1/write",
+							},
+						}},
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "metricsGenerator", "remoteWrite").Index(0).Child("url"),
+				"/api/v1/write",
+				"must be an absolute URL, e.g. http://prometheus:9090/api/v1/write",
+			)},
+		},
+
+		// retention and limits
+		{
+			name: "global retention and limits",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					Retention: &v1alpha1.RetentionSpec{
+						Global: v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: 24 * time.Hour}},
+					},
+					Limits: &v1alpha1.LimitSpec{
+						Global: v1alpha1.RateLimitSpec{
+							Ingestion: v1alpha1.IngestionLimitSpec{MaxTracesPerUser: ptr.To(1000)},
+						},
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors:   field.ErrorList{},
+		},
+		{
+			name: "per-tenant retention and limits with multi-tenancy",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					Multitenancy: &v1alpha1.MonolithicMultitenancySpec{
+						Enabled: true,
+					},
+					Retention: &v1alpha1.RetentionSpec{
+						PerTenant: map[string]v1alpha1.RetentionConfig{
+							"dev": {Traces: metav1.Duration{Duration: 24 * time.Hour}},
+						},
+					},
+					Limits: &v1alpha1.LimitSpec{
+						PerTenant: map[string]v1alpha1.RateLimitSpec{
+							"dev": {Ingestion: v1alpha1.IngestionLimitSpec{MaxTracesPerUser: ptr.To(1000)}},
+						},
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors:   field.ErrorList{},
+		},
+		{
+			name: "per-tenant retention and limits without multi-tenancy",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					Retention: &v1alpha1.RetentionSpec{
+						PerTenant: map[string]v1alpha1.RetentionConfig{
+							"dev": {Traces: metav1.Duration{Duration: 24 * time.Hour}},
+						},
+					},
+					Limits: &v1alpha1.LimitSpec{
+						PerTenant: map[string]v1alpha1.RateLimitSpec{
+							"dev": {},
+						},
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec", "retention", "perTenant"),
+					map[string]v1alpha1.RetentionConfig{"dev": {Traces: metav1.Duration{Duration: 24 * time.Hour}}},
+					"per-tenant retention requires multi-tenancy to be enabled in spec.multitenancy",
+				),
+				field.Invalid(
+					field.NewPath("spec", "limits", "perTenant"),
+					map[string]v1alpha1.RateLimitSpec{"dev": {}},
+					"per-tenant limits require multi-tenancy to be enabled in spec.multitenancy",
+				),
+			},
+		},
+		{
+			name: "invalid retention and limits",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					Multitenancy: &v1alpha1.MonolithicMultitenancySpec{
+						Enabled: true,
+					},
+					Retention: &v1alpha1.RetentionSpec{
+						Global: v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: -time.Hour}},
+						PerTenant: map[string]v1alpha1.RetentionConfig{
+							"dev": {},
+						},
+					},
+					Limits: &v1alpha1.LimitSpec{
+						Global: v1alpha1.RateLimitSpec{
+							Ingestion: v1alpha1.IngestionLimitSpec{
+								MetricsGeneratorProcessors: []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorLocalBlocks},
+							},
+						},
+						PerTenant: map[string]v1alpha1.RateLimitSpec{
+							"dev": {Ingestion: v1alpha1.IngestionLimitSpec{
+								MetricsGeneratorProcessors: []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorLocalBlocks},
+							}},
+						},
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "retention", "global", "traces"), "-1h0m0s", "must be greater than or equal to 0"),
+				field.Invalid(field.NewPath("spec", "retention", "perTenant").Key("dev").Child("traces"), "0s", "must be greater than 0"),
+				field.Invalid(
+					field.NewPath("spec", "limits", "global", "ingestion", "metricsGeneratorProcessors"),
+					[]v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorLocalBlocks},
+					"the processors of all tenants must be configured in spec.metricsGenerator.processors",
+				),
+				field.Invalid(
+					field.NewPath("spec", "limits", "perTenant").Key("dev").Child("ingestion", "metricsGeneratorProcessors"),
+					[]v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorLocalBlocks},
+					"the metrics-generator must be enabled in spec.metricsGenerator",
+				),
+			},
+		},
+
+		// upgrade policy
+		{
+			name: "window upgrade policy without maintenance window",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					UpgradePolicy: &v1alpha1.UpgradePolicySpec{
+						Mode: v1alpha1.UpgradePolicyModeWindow,
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors: field.ErrorList{
+				field.Required(field.NewPath("spec", "upgradePolicy", "maintenanceWindow"), "the window upgrade policy requires a maintenance window"),
+			},
+		},
+
+		// Tempo version
+		{
+			name: "unsupported Tempo version",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					TempoVersion: "2.5.0",
+				},
+			},
+			warnings: admission.Warnings{},
+			errors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "tempoVersion"), "2.5.0", "unsupported Tempo version 2.5.0, the supported versions of this operator version are 2.6.x, 2.7.x, 2.8.x"),
+			},
+		},
+		{
+			name: "cost attribution not supported by the Tempo version",
+			tempo: v1alpha1.TempoMonolithic{
+				Spec: v1alpha1.TempoMonolithicSpec{
+					TempoVersion: "2.7.2",
+					Limits: &v1alpha1.LimitSpec{
+						Global: v1alpha1.RateLimitSpec{
+							Ingestion: v1alpha1.IngestionLimitSpec{
+								CostAttributionDimensions: map[string]string{"service.name": "service"},
+							},
+						},
+					},
+				},
+			},
+			warnings: admission.Warnings{},
+			errors: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "limits", "global", "ingestion", "costAttributionDimensions"), "cost attribution is not supported by Tempo version 2.7.2"),
+			},
+		},
+
 		// extra config
 		{
 			name: "extra config warning",
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -26,6 +29,7 @@ import (
 	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/autodetect"
 	"github.com/grafana/tempo-operator/internal/handlers/storage"
+	"github.com/grafana/tempo-operator/internal/handlers/tenants"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
 	"github.com/grafana/tempo-operator/internal/status"
 )
@@ -35,6 +39,7 @@ var (
 	tenGBQuantity           = resource.MustParse("10Gi")
 	defaultServicesDuration = metav1.Duration{Duration: time.Hour * 24 * 3}
 	defaultTimeout          = metav1.Duration{Duration: time.Second * 30}
+	labelNameRegexp         = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
 )
 
 // TempoStackWebhook provides webhooks for TempoStack CR.
@@ -127,11 +132,60 @@ func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
 		r.Spec.Template.Gateway.Replicas = defaultComponentReplicas
 	}
 
+	if r.Spec.Template.MetricsGenerator.Enabled {
+		if r.Spec.Template.MetricsGenerator.Replicas == nil {
+			r.Spec.Template.MetricsGenerator.Replicas = defaultComponentReplicas
+		}
+		if len(r.Spec.Template.MetricsGenerator.Processors) == 0 {
+			r.Spec.Template.MetricsGenerator.Processors = []v1alpha1.MetricsGeneratorProcessor{
+				v1alpha1.MetricsGeneratorProcessorServiceGraphs,
+				v1alpha1.MetricsGeneratorProcessorSpanMetrics,
+			}
+		}
+	}
+
+	if r.Spec.Ingest != nil && r.Spec.Ingest.Kafka != nil && r.Spec.Template.BlockBuilder.Replicas == nil {
+		r.Spec.Template.BlockBuilder.Replicas = defaultComponentReplicas
+	}
+
+	for _, hpa := range []*v1alpha1.AutoscalingSpec{
+		r.Spec.Template.Distributor.Autoscaling,
+		r.Spec.Template.Querier.Autoscaling,
+		r.Spec.Template.QueryFrontend.Autoscaling,
+	} {
+		if hpa != nil && hpa.Enabled && hpa.MinReplicas == nil {
+			hpa.MinReplicas = ptr.To(int32(1))
+		}
+	}
+
+	if r.Spec.Cache != nil {
+		if len(r.Spec.Cache.Roles) == 0 {
+			r.Spec.Cache.Roles = []v1alpha1.CacheRole{
+				v1alpha1.CacheRoleBloom,
+				v1alpha1.CacheRoleParquetFooter,
+				v1alpha1.CacheRoleFrontendSearch,
+			}
+		}
+		if r.Spec.Cache.Memcached != nil && r.Spec.Cache.Memcached.Managed.Enabled &&
+			r.Spec.Cache.Memcached.Managed.Replicas == nil {
+			r.Spec.Cache.Memcached.Managed.Replicas = defaultComponentReplicas
+		}
+	}
+
 	// Default replication factor if not specified.
 	if r.Spec.ReplicationFactor == 0 {
 		r.Spec.ReplicationFactor = defaultReplicationFactor
 	}
 
+	if r.Spec.ZoneAwareness != nil && r.Spec.ZoneAwareness.Enabled {
+		if r.Spec.ZoneAwareness.TopologyKey == "" {
+			r.Spec.ZoneAwareness.TopologyKey = "topology.kubernetes.io/zone"
+		}
+		if r.Spec.ZoneAwareness.MaxSkew == 0 {
+			r.Spec.ZoneAwareness.MaxSkew = 1
+		}
+	}
+
 	// if tenant mode is Openshift, ingress type should be route by default.
 	if r.Spec.Tenants != nil && r.Spec.Tenants.Mode == v1alpha1.ModeOpenShift && r.Spec.Template.Gateway.Ingress.Type == "" {
 		r.Spec.Template.Gateway.Ingress.Type = v1alpha1.IngressTypeRoute
@@ -241,8 +295,9 @@ func (v *validator) validateServiceAccount(ctx context.Context, tempo v1alpha1.T
 
 func (v *validator) validateStorage(ctx context.Context, tempo v1alpha1.TempoStack) (admission.Warnings, field.ErrorList) { //nolint:unparam
 	_, errs := storage.GetStorageParamsForTempoStack(ctx, v.client, tempo)
-	if len(errs) == 1 && (strings.HasPrefix(errs[0].Detail, storage.ErrFetchingSecret) || strings.HasPrefix(errs[0].Detail, storage.ErrFetchingConfigMap)) {
-		// Do not fail the validation if the storage secret or TLS CA ConfigMap is not found, the user can create these objects later.
+	if len(errs) == 1 && (strings.HasPrefix(errs[0].Detail, storage.ErrFetchingSecret) || strings.HasPrefix(errs[0].Detail, storage.ErrFetchingConfigMap) ||
+		strings.HasPrefix(errs[0].Detail, storage.ErrFetchingPersistentVolumeClaim)) {
+		// Do not fail the validation if the storage secret, TLS CA ConfigMap or PersistentVolumeClaim is not found, the user can create these objects later.
 		// The operator will remain in a ConfigurationError status condition until the storage secret is created.
 		return admission.Warnings{errs[0].Detail}, field.ErrorList{}
 	}
@@ -286,6 +341,13 @@ func (v *validator) validateQueryFrontend(tempo v1alpha1.TempoStack) field.Error
 		)}
 	}
 
+	if errs := v.validateGatewayAPIIngress(
+		field.NewPath("spec").Child("template").Child("queryFrontend").Child("jaegerQuery").Child("ingress"),
+		tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress,
+	); errs != nil {
+		return errs
+	}
+
 	if tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.Enabled {
 		prometheusEndpointPath := field.NewPath("spec").Child("template").Child("queryFrontend").Child("jaegerQuery").Child("monitorTab").Child("prometheusEndpoint")
 		if tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint == "" {
@@ -300,6 +362,50 @@ func (v *validator) validateQueryFrontend(tempo v1alpha1.TempoStack) field.Error
 	return nil
 }
 
+func (v *validator) validateGatewayAPIIngress(path *field.Path, ingress v1alpha1.IngressSpec) field.ErrorList {
+	if ingress.Type != v1alpha1.IngressTypeGatewayAPI {
+		return nil
+	}
+
+	if !v.ctrlConfig.Gates.GatewayAPI {
+		return field.ErrorList{field.Invalid(
+			path.Child("type"),
+			ingress.Type,
+			"please enable the featureGates.gatewayAPI feature gate to use Gateway API routes",
+		)}
+	}
+
+	if ingress.GatewayAPI == nil || ingress.GatewayAPI.ParentRef.Name == "" {
+		return field.ErrorList{field.Required(
+			path.Child("gatewayAPI", "parentRef", "name"),
+			"the parent Gateway is required for the ingress type gateway-api",
+		)}
+	}
+
+	return nil
+}
+
+// validateGatewayAPIGRPCRoute rejects a GRPCRoute which shares a hostname with the HTTPRoute on the same listener.
+// A route without hostnames matches all hostnames of the listener.
+func validateGatewayAPIGRPCRoute(path *field.Path, ingress v1alpha1.IngressSpec) field.ErrorList {
+	if ingress.Type != v1alpha1.IngressTypeGatewayAPI || ingress.GatewayAPI == nil {
+		return nil
+	}
+
+	gatewayAPI := ingress.GatewayAPI
+	sameListener := gatewayAPI.GRPCParentRef().SectionName == gatewayAPI.ParentRef.SectionName
+	sameHost := ingress.Host == "" || gatewayAPI.GRPCHost == "" || ingress.Host == gatewayAPI.GRPCHost
+	if sameListener && sameHost {
+		return field.ErrorList{field.Invalid(
+			path.Child("gatewayAPI", "grpcHost"),
+			gatewayAPI.GRPCHost,
+			"the GRPCRoute and the HTTPRoute must not share a hostname on the same listener, please configure a different grpcHost or grpcSectionName",
+		)}
+	}
+
+	return nil
+}
+
 func (v *validator) validateGateway(ctx context.Context, tempo v1alpha1.TempoStack) field.ErrorList {
 	path := field.NewPath("spec").Child("template").Child("gateway").Child("enabled")
 	if tempo.Spec.Template.Gateway.Enabled {
@@ -331,6 +437,20 @@ func (v *validator) validateGateway(ctx context.Context, tempo v1alpha1.TempoSta
 			)}
 		}
 
+		if errs := v.validateGatewayAPIIngress(
+			field.NewPath("spec").Child("template").Child("gateway").Child("ingress"),
+			tempo.Spec.Template.Gateway.Ingress,
+		); errs != nil {
+			return errs
+		}
+
+		if errs := validateGatewayAPIGRPCRoute(
+			field.NewPath("spec").Child("template").Child("gateway").Child("ingress"),
+			tempo.Spec.Template.Gateway.Ingress,
+		); errs != nil {
+			return errs
+		}
+
 		if tempo.Spec.Template.Gateway.Enabled && tempo.Spec.Template.Distributor.TLS.Enabled {
 			return field.ErrorList{field.Invalid(
 				field.NewPath("spec").Child("template").Child("gateway").Child("enabled"),
@@ -353,6 +473,237 @@ func (v *validator) validateGateway(ctx context.Context, tempo v1alpha1.TempoSta
 	return nil
 }
 
+func (v *validator) validateMetricsGenerator(tempo v1alpha1.TempoStack) field.ErrorList {
+	spec := tempo.Spec.Template.MetricsGenerator
+	if !spec.Enabled {
+		return nil
+	}
+
+	base := field.NewPath("spec").Child("template").Child("metricsGenerator")
+	return validateMetricsGeneratorRemoteWrite(base, spec.Processors, spec.RemoteWrite)
+}
+
+type componentAutoscaling struct {
+	path *field.Path
+	spec *v1alpha1.AutoscalingSpec
+}
+
+func (v *validator) validateAutoscaling(tempo v1alpha1.TempoStack) field.ErrorList {
+	var allErrs field.ErrorList
+	templatePath := field.NewPath("spec").Child("template")
+
+	components := []componentAutoscaling{
+		{templatePath.Child("distributor", "autoscaling"), tempo.Spec.Template.Distributor.Autoscaling},
+		{templatePath.Child("querier", "autoscaling"), tempo.Spec.Template.Querier.Autoscaling},
+		{templatePath.Child("queryFrontend", "autoscaling"), tempo.Spec.Template.QueryFrontend.Autoscaling},
+	}
+	for _, c := range components {
+		if c.spec == nil || !c.spec.Enabled {
+			continue
+		}
+		if c.spec.MinReplicas != nil && *c.spec.MinReplicas > c.spec.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(c.path.Child("minReplicas"), *c.spec.MinReplicas,
+				"minReplicas must not be greater than maxReplicas"))
+		}
+		if c.spec.TargetCPUUtilization == nil && c.spec.TargetMemoryUtilization == nil && len(c.spec.Metrics) == 0 {
+			allErrs = append(allErrs, field.Required(c.path,
+				"at least one of targetCPUUtilization, targetMemoryUtilization or metrics must be set"))
+		}
+	}
+
+	return allErrs
+}
+
+type componentPodDisruptionBudget struct {
+	path *field.Path
+	spec *v1alpha1.PodDisruptionBudgetSpec
+}
+
+func (v *validator) validatePodDisruptionBudgets(tempo v1alpha1.TempoStack) field.ErrorList {
+	var allErrs field.ErrorList
+	templatePath := field.NewPath("spec").Child("template")
+
+	components := []componentPodDisruptionBudget{
+		{templatePath.Child("compactor", "podDisruptionBudget"), tempo.Spec.Template.Compactor.PodDisruptionBudget},
+		{templatePath.Child("distributor", "component", "podDisruptionBudget"), tempo.Spec.Template.Distributor.PodDisruptionBudget},
+		{templatePath.Child("ingester", "podDisruptionBudget"), tempo.Spec.Template.Ingester.PodDisruptionBudget},
+		{templatePath.Child("querier", "podDisruptionBudget"), tempo.Spec.Template.Querier.PodDisruptionBudget},
+		{templatePath.Child("queryFrontend", "component", "podDisruptionBudget"), tempo.Spec.Template.QueryFrontend.PodDisruptionBudget},
+		{templatePath.Child("gateway", "component", "podDisruptionBudget"), tempo.Spec.Template.Gateway.PodDisruptionBudget},
+		{templatePath.Child("metricsGenerator", "component", "podDisruptionBudget"), tempo.Spec.Template.MetricsGenerator.PodDisruptionBudget},
+		{templatePath.Child("blockBuilder", "podDisruptionBudget"), tempo.Spec.Template.BlockBuilder.PodDisruptionBudget},
+	}
+	if tempo.Spec.Cache != nil && tempo.Spec.Cache.Memcached != nil {
+		components = append(components, componentPodDisruptionBudget{
+			field.NewPath("spec", "cache", "memcached", "managed", "component", "podDisruptionBudget"),
+			tempo.Spec.Cache.Memcached.Managed.PodDisruptionBudget,
+		})
+	}
+
+	for _, c := range components {
+		if c.spec != nil && c.spec.MinAvailable != nil && c.spec.MaxUnavailable != nil {
+			allErrs = append(allErrs, field.Invalid(c.path, c.spec,
+				"minAvailable and maxUnavailable cannot be configured at the same time"))
+		}
+	}
+	return allErrs
+}
+
+func (v *validator) validateTempoVersion(ctx context.Context, tempo v1alpha1.TempoStack) field.ErrorList {
+	var defaultImages []string
+	if tempo.Spec.Images.Tempo == "" {
+		defaultImages = append(defaultImages, v.ctrlConfig.DefaultImages.Tempo)
+	}
+	if tempo.Spec.Images.TempoQuery == "" {
+		defaultImages = append(defaultImages, v.ctrlConfig.DefaultImages.TempoQuery)
+	}
+
+	tempoVersion := tempo.Spec.TempoVersion
+	features, allErrs := validateTempoVersion(field.NewPath("spec").Child("tempoVersion"), tempoVersion, defaultImages...)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	if tempo.Spec.Images.Tempo != "" {
+		allErrs = append(allErrs, validateTempoImage(field.NewPath("spec").Child("images", "tempo"), tempo.Spec.Images.Tempo, tempoVersion)...)
+	}
+	if tempo.Spec.Ingest != nil && tempo.Spec.Ingest.Kafka != nil && !features.IngestStorage {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("ingest", "kafka"),
+			fmt.Sprintf("the Kafka ingest path is not supported by Tempo version %s", tempoVersion)))
+	}
+	allErrs = append(allErrs, validateCostAttribution(field.NewPath("spec").Child("limits"), tempo.Spec.LimitSpec, tempoVersion, features)...)
+
+	// The limits of the TempoTenants are merged into the TempoStack, and must be supported by the Tempo version as well.
+	tempoTenants, err := tenants.GetTempoTenants(ctx, v.client, tempo)
+	if err != nil {
+		return append(allErrs, field.InternalError(field.NewPath("spec").Child("tempoVersion"), err))
+	}
+	_, conditions := tenants.Merge(tempo, tempoTenants)
+	for _, name := range sortedKeys(conditions) {
+		if conditions[name].Reason == string(v1alpha1.ReasonTenantUnsupportedLimits) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("tempoVersion"),
+				fmt.Sprintf("TempoTenant %s: %s", name, conditions[name].Message)))
+		}
+	}
+	return allErrs
+}
+
+func (v *validator) validateIngest(tempo v1alpha1.TempoStack) field.ErrorList {
+	if tempo.Spec.Ingest == nil || tempo.Spec.Ingest.Kafka == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	kafkaPath := field.NewPath("spec").Child("ingest", "kafka")
+
+	if tempo.Spec.ZoneAwareness != nil && tempo.Spec.ZoneAwareness.Enabled && len(tempo.Spec.ZoneAwareness.Zones) > 0 {
+		allErrs = append(allErrs, field.Forbidden(kafkaPath,
+			"the Kafka ingest path cannot be combined with per-zone ingester StatefulSets (spec.zoneAwareness.zones)"))
+	}
+
+	// Every ingester consumes one partition of the topic, a block-builder without partitions would be idle.
+	ingesters := ptr.Deref(tempo.Spec.Template.Ingester.Replicas, 1)
+	blockBuilders := ptr.Deref(tempo.Spec.Template.BlockBuilder.Replicas, 1)
+	if blockBuilders > ingesters {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec").Child("template", "blockBuilder", "replicas"),
+			blockBuilders,
+			"the number of block-builders must not be greater than the number of ingesters (Kafka partitions)",
+		))
+	}
+
+	if tempo.Spec.Ingest.Kafka.TLS.Cert != "" && !tempo.Spec.Ingest.Kafka.TLS.Enabled {
+		allErrs = append(allErrs, field.Invalid(
+			kafkaPath.Child("tls", "enabled"),
+			tempo.Spec.Ingest.Kafka.TLS.Enabled,
+			"TLS must be enabled to use a client certificate",
+		))
+	}
+
+	return allErrs
+}
+
+func (v *validator) validateZoneAwareness(tempo v1alpha1.TempoStack) field.ErrorList {
+	if tempo.Spec.ZoneAwareness == nil || !tempo.Spec.ZoneAwareness.Enabled {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	zoneAwarenessPath := field.NewPath("spec").Child("zoneAwareness")
+
+	if tempo.Spec.ReplicationFactor < 2 {
+		allErrs = append(allErrs, field.Invalid(
+			zoneAwarenessPath.Child("enabled"),
+			tempo.Spec.ZoneAwareness.Enabled,
+			"zone-aware replication requires a replication factor of at least 2",
+		))
+	}
+
+	zones := tempo.Spec.ZoneAwareness.Zones
+	if len(zones) > 0 && len(zones) < tempo.Spec.ReplicationFactor {
+		allErrs = append(allErrs, field.Invalid(
+			zoneAwarenessPath.Child("zones"),
+			len(zones),
+			"the number of zones must be greater than or equal to the replication factor",
+		))
+	}
+	for i, zone := range zones {
+		for _, msg := range validation.IsDNS1123Label(zone.Name) {
+			allErrs = append(allErrs, field.Invalid(zoneAwarenessPath.Child("zones").Index(i).Child("name"), zone.Name, msg))
+		}
+	}
+	return allErrs
+}
+
+func (v *validator) validateCache(tempo v1alpha1.TempoStack) field.ErrorList {
+	cache := tempo.Spec.Cache
+	if cache == nil {
+		return nil
+	}
+
+	base := field.NewPath("spec").Child("cache")
+	switch cache.Backend {
+	case v1alpha1.CacheBackendMemcached:
+		if cache.Memcached == nil {
+			return field.ErrorList{field.Required(base.Child("memcached"), "memcached must be configured when the memcached backend is used")}
+		}
+		managed := cache.Memcached.Managed.Enabled
+		if managed && cache.Memcached.Host != "" {
+			return field.ErrorList{field.Invalid(base.Child("memcached", "host"), cache.Memcached.Host,
+				"host must not be set when the managed memcached is enabled")}
+		}
+		if !managed && cache.Memcached.Host == "" {
+			return field.ErrorList{field.Required(base.Child("memcached", "host"),
+				"either host must be set or the managed memcached must be enabled")}
+		}
+	case v1alpha1.CacheBackendRedis:
+		if cache.Redis == nil || cache.Redis.Endpoint == "" {
+			return field.ErrorList{field.Required(base.Child("redis", "endpoint"), "redis endpoint must be configured when the redis backend is used")}
+		}
+	}
+	return nil
+}
+
+func (v *validator) validateStorageMigration(tempo v1alpha1.TempoStack) field.ErrorList {
+	migration := tempo.Spec.Storage.Migration
+	if migration == nil {
+		return nil
+	}
+
+	base := field.NewPath("spec").Child("storage").Child("migration")
+	if tempo.Spec.Storage.Filesystem != nil {
+		return field.ErrorList{field.Invalid(base, "", "storage migration is not supported for filesystem storage")}
+	}
+	if migration.Source.Name == "" {
+		return field.ErrorList{field.Required(base.Child("source", "name"), "the secret of the previous object storage must be set")}
+	}
+	if migration.Source.Name == tempo.Spec.Storage.Secret.Name {
+		return field.ErrorList{field.Invalid(base.Child("source", "name"), migration.Source.Name,
+			"the secret of the previous object storage must be different from the storage secret")}
+	}
+	return nil
+}
+
 func (v *validator) validateObservability(tempo v1alpha1.TempoStack) field.ErrorList {
 	observabilityBase := field.NewPath("spec").Child("observability")
 
@@ -456,6 +807,112 @@ func (v *validator) validateDeprecatedFields(tempo v1alpha1.TempoStack) field.Er
 	return nil
 }
 
+func (v *validator) validateLimits(tempo v1alpha1.TempoStack) field.ErrorList {
+	var allErrs field.ErrorList
+	base := field.NewPath("spec").Child("limits")
+
+	global := tempo.Spec.LimitSpec.Global
+	allErrs = append(allErrs, validateRateLimitSpec(base.Child("global"), global)...)
+	if len(global.Ingestion.MetricsGeneratorProcessors) > 0 {
+		allErrs = append(allErrs, field.Invalid(
+			base.Child("global").Child("ingestion").Child("metricsGeneratorProcessors"),
+			global.Ingestion.MetricsGeneratorProcessors,
+			"the processors of all tenants must be configured in spec.template.metricsGenerator.processors",
+		))
+	}
+
+	metricsGenerator := tempo.Spec.Template.MetricsGenerator
+	for _, tenant := range sortedKeys(tempo.Spec.LimitSpec.PerTenant) {
+		limits := tempo.Spec.LimitSpec.PerTenant[tenant]
+		path := base.Child("perTenant").Key(tenant)
+		allErrs = append(allErrs, validateRateLimitSpec(path, limits)...)
+
+		processorsPath := path.Child("ingestion").Child("metricsGeneratorProcessors")
+		for _, processor := range limits.Ingestion.MetricsGeneratorProcessors {
+			if !metricsGenerator.Enabled {
+				allErrs = append(allErrs, field.Invalid(
+					processorsPath,
+					limits.Ingestion.MetricsGeneratorProcessors,
+					"the metrics-generator must be enabled in spec.template.metricsGenerator",
+				))
+				break
+			}
+			if len(metricsGenerator.RemoteWrite) == 0 &&
+				(processor == v1alpha1.MetricsGeneratorProcessorServiceGraphs || processor == v1alpha1.MetricsGeneratorProcessorSpanMetrics) {
+				allErrs = append(allErrs, field.Invalid(
+					processorsPath,
+					limits.Ingestion.MetricsGeneratorProcessors,
+					fmt.Sprintf("at least one remote-write endpoint is required in spec.template.metricsGenerator.remoteWrite when the %s processor is enabled", processor),
+				))
+				break
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateRateLimitSpec(base *field.Path, spec v1alpha1.RateLimitSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	ingestion := base.Child("ingestion")
+	query := base.Child("query")
+
+	if spec.Ingestion.MaxAttributeBytes != nil && *spec.Ingestion.MaxAttributeBytes < 0 {
+		allErrs = append(allErrs, field.Invalid(
+			ingestion.Child("maxAttributeBytes"),
+			*spec.Ingestion.MaxAttributeBytes,
+			"must be greater than or equal to 0",
+		))
+	}
+
+	for i, forwarder := range spec.Ingestion.Forwarders {
+		if strings.TrimSpace(forwarder) == "" {
+			allErrs = append(allErrs, field.Invalid(
+				ingestion.Child("forwarders").Index(i),
+				forwarder,
+				"forwarder name must not be empty",
+			))
+		}
+	}
+
+	for _, attribute := range sortedKeys(spec.Ingestion.CostAttributionDimensions) {
+		label := spec.Ingestion.CostAttributionDimensions[attribute]
+		if strings.TrimSpace(attribute) == "" {
+			allErrs = append(allErrs, field.Invalid(
+				ingestion.Child("costAttributionDimensions"),
+				attribute,
+				"attribute name must not be empty",
+			))
+		} else if label != "" && !labelNameRegexp.MatchString(label) {
+			allErrs = append(allErrs, field.Invalid(
+				ingestion.Child("costAttributionDimensions").Key(attribute),
+				label,
+				"must be a valid Prometheus label name",
+			))
+		}
+	}
+
+	if spec.Query.MaxMetricsDuration.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(
+			query.Child("maxMetricsDuration"),
+			spec.Query.MaxMetricsDuration.Duration.String(),
+			"must be greater than or equal to 0",
+		))
+	}
+
+	return allErrs
+}
+
+// sortedKeys returns the keys of a map in a stable order, to report validation errors deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (v *validator) validateReceiverTLS(tempo v1alpha1.TempoStack) field.ErrorList {
 	spec := tempo.Spec.Template.Distributor.TLS
 	if spec.Enabled && !v.ctrlConfig.Gates.OpenShift.ServingCertsService {
@@ -513,9 +970,28 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission
 	allErrors = append(allErrors, v.validateQueryFrontend(*tempo)...)
 	allErrors = append(allErrors, v.validateGateway(ctx, *tempo)...)
 	allErrors = append(allErrors, v.validateTenantConfigs(*tempo)...)
+	allErrors = append(allErrors, v.validateMetricsGenerator(*tempo)...)
+	allErrors = append(allErrors, v.validateCache(*tempo)...)
+	allErrors = append(allErrors, v.validateStorageMigration(*tempo)...)
+	allErrors = append(allErrors, v.validateAutoscaling(*tempo)...)
+	allErrors = append(allErrors, v.validatePodDisruptionBudgets(*tempo)...)
+	allErrors = append(allErrors, v.validateZoneAwareness(*tempo)...)
+	allErrors = append(allErrors, v.validateIngest(*tempo)...)
 	allErrors = append(allErrors, v.validateObservability(*tempo)...)
 	allErrors = append(allErrors, v.validateDeprecatedFields(*tempo)...)
+	allErrors = append(allErrors, v.validateLimits(*tempo)...)
+	allErrors = append(allErrors, validateStorageTuning(
+		field.NewPath("spec").Child("storage").Child("tuning"),
+		tempo.Spec.Storage.Tuning,
+		tempo.Spec.Retention.Global.Traces.Duration,
+	)...)
+	allErrors = append(allErrors, validateDedicatedColumns(
+		field.NewPath("spec").Child("storage").Child("dedicatedColumns"),
+		tempo.Spec.Storage.DedicatedColumns,
+	)...)
 	allErrors = append(allErrors, v.validateReceiverTLS(*tempo)...)
+	allErrors = append(allErrors, validateUpgradePolicy(field.NewPath("spec").Child("upgradePolicy"), tempo.Spec.UpgradePolicy)...)
+	allErrors = append(allErrors, v.validateTempoVersion(ctx, *tempo)...)
 	allErrors = append(allErrors, v.validateConflictWithMonolithic(ctx, tempo)...)
 
 	if len(allErrors) == 0 {
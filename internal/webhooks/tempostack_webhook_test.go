@@ -15,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -126,8 +127,10 @@ func TestDefault(t *testing.T) {
 						Ingester: v1alpha1.TempoComponentSpec{
 							Replicas: ptr.To(int32(1)),
 						},
-						Querier: v1alpha1.TempoComponentSpec{
-							Replicas: ptr.To(int32(1)),
+						Querier: v1alpha1.TempoQuerierSpec{
+							TempoComponentSpec: v1alpha1.TempoComponentSpec{
+								Replicas: ptr.To(int32(1)),
+							},
 						},
 						Gateway: v1alpha1.TempoGatewaySpec{
 							TempoComponentSpec: v1alpha1.TempoComponentSpec{
@@ -197,8 +200,10 @@ func TestDefault(t *testing.T) {
 						Ingester: v1alpha1.TempoComponentSpec{
 							Replicas: ptr.To(int32(1)),
 						},
-						Querier: v1alpha1.TempoComponentSpec{
-							Replicas: ptr.To(int32(1)),
+						Querier: v1alpha1.TempoQuerierSpec{
+							TempoComponentSpec: v1alpha1.TempoComponentSpec{
+								Replicas: ptr.To(int32(1)),
+							},
 						},
 						QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
 							TempoComponentSpec: v1alpha1.TempoComponentSpec{
@@ -273,8 +278,10 @@ func TestDefault(t *testing.T) {
 						Ingester: v1alpha1.TempoComponentSpec{
 							Replicas: ptr.To(int32(1)),
 						},
-						Querier: v1alpha1.TempoComponentSpec{
-							Replicas: ptr.To(int32(1)),
+						Querier: v1alpha1.TempoQuerierSpec{
+							TempoComponentSpec: v1alpha1.TempoComponentSpec{
+								Replicas: ptr.To(int32(1)),
+							},
 						},
 						QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
 							TempoComponentSpec: v1alpha1.TempoComponentSpec{
@@ -356,8 +363,10 @@ func TestDefault(t *testing.T) {
 						Ingester: v1alpha1.TempoComponentSpec{
 							Replicas: ptr.To(int32(1)),
 						},
-						Querier: v1alpha1.TempoComponentSpec{
-							Replicas: ptr.To(int32(1)),
+						Querier: v1alpha1.TempoQuerierSpec{
+							TempoComponentSpec: v1alpha1.TempoComponentSpec{
+								Replicas: ptr.To(int32(1)),
+							},
 						},
 						Gateway: v1alpha1.TempoGatewaySpec{
 							TempoComponentSpec: v1alpha1.TempoComponentSpec{
@@ -456,8 +465,10 @@ func TestDefault(t *testing.T) {
 						Ingester: v1alpha1.TempoComponentSpec{
 							Replicas: ptr.To(int32(1)),
 						},
-						Querier: v1alpha1.TempoComponentSpec{
-							Replicas: ptr.To(int32(1)),
+						Querier: v1alpha1.TempoQuerierSpec{
+							TempoComponentSpec: v1alpha1.TempoComponentSpec{
+								Replicas: ptr.To(int32(1)),
+							},
 						},
 						QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
 							TempoComponentSpec: v1alpha1.TempoComponentSpec{
@@ -578,8 +589,10 @@ func TestDefault(t *testing.T) {
 						Ingester: v1alpha1.TempoComponentSpec{
 							Replicas: ptr.To(int32(1)),
 						},
-						Querier: v1alpha1.TempoComponentSpec{
-							Replicas: ptr.To(int32(1)),
+						Querier: v1alpha1.TempoQuerierSpec{
+							TempoComponentSpec: v1alpha1.TempoComponentSpec{
+								Replicas: ptr.To(int32(1)),
+							},
 						},
 						Gateway: v1alpha1.TempoGatewaySpec{
 							TempoComponentSpec: v1alpha1.TempoComponentSpec{
@@ -613,6 +626,82 @@ func TestDefault(t *testing.T) {
 	}
 }
 
+func TestDefaultCache(t *testing.T) {
+	tempo := &v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Cache: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Memcached: &v1alpha1.MemcachedSpec{
+					Managed: v1alpha1.ManagedMemcachedSpec{Enabled: true},
+				},
+			},
+		},
+	}
+
+	defaulter := &Defaulter{}
+	err := defaulter.Default(context.Background(), tempo)
+	assert.NoError(t, err)
+	assert.Equal(t, []v1alpha1.CacheRole{
+		v1alpha1.CacheRoleBloom,
+		v1alpha1.CacheRoleParquetFooter,
+		v1alpha1.CacheRoleFrontendSearch,
+	}, tempo.Spec.Cache.Roles)
+	assert.Equal(t, ptr.To(int32(1)), tempo.Spec.Cache.Memcached.Managed.Replicas)
+}
+
+func TestDefaultAutoscaling(t *testing.T) {
+	tempo := &v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Querier: v1alpha1.TempoQuerierSpec{
+					Autoscaling: &v1alpha1.AutoscalingSpec{Enabled: true, MaxReplicas: 3},
+				},
+				Distributor: v1alpha1.TempoDistributorSpec{
+					Autoscaling: &v1alpha1.AutoscalingSpec{MaxReplicas: 3},
+				},
+			},
+		},
+	}
+
+	defaulter := &Defaulter{}
+	err := defaulter.Default(context.Background(), tempo)
+	assert.NoError(t, err)
+	assert.Equal(t, ptr.To(int32(1)), tempo.Spec.Template.Querier.Autoscaling.MinReplicas)
+	assert.Nil(t, tempo.Spec.Template.Distributor.Autoscaling.MinReplicas)
+}
+
+func TestDefaultZoneAwareness(t *testing.T) {
+	tempo := &v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			ZoneAwareness: &v1alpha1.ZoneAwarenessSpec{Enabled: true},
+		},
+	}
+
+	defaulter := &Defaulter{}
+	err := defaulter.Default(context.Background(), tempo)
+	assert.NoError(t, err)
+	assert.Equal(t, "topology.kubernetes.io/zone", tempo.Spec.ZoneAwareness.TopologyKey)
+	assert.Equal(t, int32(1), tempo.Spec.ZoneAwareness.MaxSkew)
+}
+
+func TestDefaultIngest(t *testing.T) {
+	tempo := &v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Ingest: &v1alpha1.IngestSpec{
+				Kafka: &v1alpha1.KafkaIngestSpec{
+					Brokers: []string{"kafka:9092"},
+					Topic:   "tempo-ingest",
+				},
+			},
+		},
+	}
+
+	defaulter := &Defaulter{}
+	err := defaulter.Default(context.Background(), tempo)
+	assert.NoError(t, err)
+	assert.Equal(t, ptr.To(int32(1)), tempo.Spec.Template.BlockBuilder.Replicas)
+}
+
 func TestValidateStorageSecret(t *testing.T) {
 	tempoAzure := v1alpha1.TempoStack{
 		Spec: v1alpha1.TempoStackSpec{
@@ -635,6 +724,17 @@ func TestValidateStorageSecret(t *testing.T) {
 		},
 	}
 
+	tempoSwift := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "testsecret",
+					Type: "swift",
+				},
+			},
+		},
+	}
+
 	tempoUnknown := v1alpha1.TempoStack{
 		Spec: v1alpha1.TempoStackSpec{
 			Storage: v1alpha1.ObjectStorageSpec{
@@ -891,6 +991,55 @@ func TestValidateStorageSecret(t *testing.T) {
 				field.Invalid(secretNamePath, tempoS3.Spec.Storage.Secret.Name, "storage secret contains fields for long lived and short lived configuration"),
 			},
 		},
+		{
+			name:  "empty Swift secret",
+			tempo: tempoSwift,
+			input: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: tempoSwift.Spec.Storage.Secret.Name,
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(secretNamePath, tempoSwift.Spec.Storage.Secret.Name, "storage secret must contain \"container\" field"),
+				field.Invalid(secretNamePath, tempoSwift.Spec.Storage.Secret.Name, "storage secret must contain \"endpoint\" field"),
+				field.Invalid(secretNamePath, tempoSwift.Spec.Storage.Secret.Name, "storage secret must contain \"access_key_id\" field"),
+				field.Invalid(secretNamePath, tempoSwift.Spec.Storage.Secret.Name, "storage secret must contain \"access_key_secret\" field"),
+			},
+		},
+		{
+			name:  "Swift secret with Keystone credentials",
+			tempo: tempoSwift,
+			input: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: tempoSwift.Spec.Storage.Secret.Name,
+				},
+				Data: map[string][]byte{
+					"container": []byte("tempo"),
+					"auth_url":  []byte("https://keystone.example.com/v3"),
+					"username":  []byte("tempo"),
+					"password":  []byte("secret"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(secretNamePath, tempoSwift.Spec.Storage.Secret.Name,
+					"storage secret contains Keystone credentials, Tempo requires EC2 credentials for the S3 API of Swift (openstack ec2 credentials create)"),
+			},
+		},
+		{
+			name:  "valid Swift secret",
+			tempo: tempoSwift,
+			input: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: tempoSwift.Spec.Storage.Secret.Name,
+				},
+				Data: map[string][]byte{
+					"container":         []byte("tempo"),
+					"endpoint":          []byte("https://swift.example.com"),
+					"access_key_id":     []byte("id"),
+					"access_key_secret": []byte("secret"),
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -902,6 +1051,67 @@ func TestValidateStorageSecret(t *testing.T) {
 	}
 }
 
+func TestValidateStorageFilesystem(t *testing.T) {
+	path := field.NewPath("spec").Child("storage")
+	filesystem := &v1alpha1.FilesystemStorageSpec{ClaimName: "traces"}
+
+	tests := []struct {
+		name     string
+		storage  v1alpha1.ObjectStorageSpec
+		pvc      *corev1.PersistentVolumeClaim
+		expected field.ErrorList
+	}{
+		{
+			name:    "ReadWriteMany PersistentVolumeClaim",
+			storage: v1alpha1.ObjectStorageSpec{Filesystem: filesystem},
+			pvc: &corev1.PersistentVolumeClaim{
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				},
+			},
+		},
+		{
+			name:    "ReadWriteOnce PersistentVolumeClaim",
+			storage: v1alpha1.ObjectStorageSpec{Filesystem: filesystem},
+			pvc: &corev1.PersistentVolumeClaim{
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("filesystem", "claimName"), "traces",
+					"PersistentVolumeClaim must support the ReadWriteMany access mode, because it is mounted by all Tempo components"),
+			},
+		},
+		{
+			name: "filesystem and storage secret",
+			storage: v1alpha1.ObjectStorageSpec{
+				Filesystem: filesystem,
+				Secret:     v1alpha1.ObjectStorageSecretSpec{Name: "testsecret", Type: "s3"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("secret"), "testsecret", "storage secret and filesystem storage are mutually exclusive"),
+			},
+		},
+		{
+			name:    "neither filesystem nor storage secret",
+			storage: v1alpha1.ObjectStorageSpec{},
+			expected: field.ErrorList{
+				field.Required(path.Child("secret", "name"), "storage secret is required if filesystem storage is not configured"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &k8sFake{pvc: test.pvc}
+			tempo := v1alpha1.TempoStack{Spec: v1alpha1.TempoStackSpec{Storage: test.storage}}
+			_, errs := storage.GetStorageParamsForTempoStack(context.Background(), client, tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
 func TestValidateStorageCAConfigMap(t *testing.T) {
 	path := field.NewPath("spec").Child("storage").Child("tls").Child("caName")
 	tempo := v1alpha1.TempoStack{
@@ -1148,6 +1358,90 @@ func TestValidateQueryFrontend(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "gateway-api enabled but gatewayAPI feature gate disabled",
+			input: v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: v1alpha1.TempoTemplateSpec{
+						QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+							JaegerQuery: v1alpha1.JaegerQuerySpec{
+								Enabled: true,
+								Ingress: v1alpha1.IngressSpec{
+									Type: "gateway-api",
+									GatewayAPI: &v1alpha1.GatewayAPISpec{
+										ParentRef: v1alpha1.GatewayParentReference{Name: "public"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					ingressTypePath,
+					v1alpha1.IngressTypeGatewayAPI,
+					"please enable the featureGates.gatewayAPI feature gate to use Gateway API routes",
+				),
+			},
+		},
+		{
+			name: "gateway-api enabled without parent Gateway",
+			input: v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: v1alpha1.TempoTemplateSpec{
+						QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+							JaegerQuery: v1alpha1.JaegerQuerySpec{
+								Enabled: true,
+								Ingress: v1alpha1.IngressSpec{
+									Type: "gateway-api",
+								},
+							},
+						},
+					},
+				},
+			},
+			ctrlConfig: configv1alpha1.ProjectConfig{
+				Gates: configv1alpha1.FeatureGates{
+					GatewayAPI: true,
+				},
+			},
+			expected: field.ErrorList{
+				field.Required(
+					field.NewPath("spec").Child("template").Child("queryFrontend").Child("jaegerQuery").Child("ingress").Child("gatewayAPI", "parentRef", "name"),
+					"the parent Gateway is required for the ingress type gateway-api",
+				),
+			},
+		},
+		{
+			name: "valid gateway-api configuration",
+			input: v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: v1alpha1.TempoTemplateSpec{
+						QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+							JaegerQuery: v1alpha1.JaegerQuerySpec{
+								Enabled: true,
+								Ingress: v1alpha1.IngressSpec{
+									Type: "gateway-api",
+									GatewayAPI: &v1alpha1.GatewayAPISpec{
+										ParentRef: v1alpha1.GatewayParentReference{Name: "public"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ctrlConfig: configv1alpha1.ProjectConfig{
+				Gates: configv1alpha1.FeatureGates{
+					GatewayAPI: true,
+				},
+			},
+			expected: nil,
+		},
 		{
 			name: "monitor tab enabled, missing prometheus endpoint",
 			input: v1alpha1.TempoStack{
@@ -2057,74 +2351,529 @@ func TestValidateDeprecatedFields(t *testing.T) {
 	}
 }
 
-func TestValidateReceiverTLSAndGateway(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    v1alpha1.TempoStack
-		expected field.ErrorList
+func TestValidateLimits(t *testing.T) {
+	negative := -1
+	processors := []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorSpanMetrics}
+
+	tt := []struct {
+		name             string
+		limits           v1alpha1.LimitSpec
+		metricsGenerator v1alpha1.TempoMetricsGeneratorSpec
+		expected         field.ErrorList
 	}{
 		{
-			name: "valid configuration disable both",
-			input: v1alpha1.TempoStack{
-				Spec: v1alpha1.TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: v1alpha1.TempoTemplateSpec{
-						Gateway: v1alpha1.TempoGatewaySpec{
-							Enabled: false,
-						},
-						Distributor: v1alpha1.TempoDistributorSpec{
-							TLS: v1alpha1.TLSSpec{
-								Enabled: false,
-							},
-						},
+			name: "no limits",
+		},
+		{
+			name: "valid limits",
+			limits: v1alpha1.LimitSpec{
+				Global: v1alpha1.RateLimitSpec{
+					Ingestion: v1alpha1.IngestionLimitSpec{
+						Forwarders:                []string{"otel-collector"},
+						CostAttributionDimensions: map[string]string{"service.name": "service", "resource.team": ""},
 					},
-					Tenants: &v1alpha1.TenantsSpec{
-						Mode: v1alpha1.ModeStatic,
+				},
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"tenant1": {
+						Ingestion: v1alpha1.IngestionLimitSpec{MetricsGeneratorProcessors: processors},
+						Query:     v1alpha1.QueryLimit{MaxMetricsDuration: metav1.Duration{Duration: time.Hour}},
 					},
 				},
 			},
-			expected: nil,
+			metricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+				Enabled:     true,
+				RemoteWrite: []v1alpha1.MetricsGeneratorRemoteWriteSpec{{URL: "http://prometheus:9090/api/v1/write"}},
+			},
 		},
 		{
-			name: "valid configuration enable only gateway",
-			input: v1alpha1.TempoStack{
-				Spec: v1alpha1.TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: v1alpha1.TempoTemplateSpec{
-						Gateway: v1alpha1.TempoGatewaySpec{
-							Enabled: true,
-						},
-						QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
-							JaegerQuery: v1alpha1.JaegerQuerySpec{
-								Enabled: true,
-							},
-						},
-						Distributor: v1alpha1.TempoDistributorSpec{
-							TLS: v1alpha1.TLSSpec{
-								Enabled: false,
-							},
-						},
-					},
-					Tenants: &v1alpha1.TenantsSpec{
-						Mode: v1alpha1.ModeStatic,
+			name: "invalid values",
+			limits: v1alpha1.LimitSpec{
+				Global: v1alpha1.RateLimitSpec{
+					Ingestion: v1alpha1.IngestionLimitSpec{
+						MaxAttributeBytes:         &negative,
+						Forwarders:                []string{""},
+						CostAttributionDimensions: map[string]string{"service.name": "service.name"},
 					},
+					Query: v1alpha1.QueryLimit{MaxMetricsDuration: metav1.Duration{Duration: -time.Hour}},
 				},
 			},
-			expected: nil,
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec.limits.global.ingestion.maxAttributeBytes"), -1, "must be greater than or equal to 0"),
+				field.Invalid(field.NewPath("spec.limits.global.ingestion.forwarders").Index(0), "", "forwarder name must not be empty"),
+				field.Invalid(field.NewPath("spec.limits.global.ingestion.costAttributionDimensions").Key("service.name"), "service.name", "must be a valid Prometheus label name"),
+				field.Invalid(field.NewPath("spec.limits.global.query.maxMetricsDuration"), "-1h0m0s", "must be greater than or equal to 0"),
+			},
 		},
 		{
-			name: "valid configuration enable only receiver TLS",
-			input: v1alpha1.TempoStack{
-				Spec: v1alpha1.TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: v1alpha1.TempoTemplateSpec{
-						Gateway: v1alpha1.TempoGatewaySpec{
-							Enabled: false,
-						},
-						Distributor: v1alpha1.TempoDistributorSpec{
-							TLS: v1alpha1.TLSSpec{
-								Enabled: true,
-								Cert:    "my-cert",
+			name: "global metrics-generator processors",
+			limits: v1alpha1.LimitSpec{
+				Global: v1alpha1.RateLimitSpec{
+					Ingestion: v1alpha1.IngestionLimitSpec{MetricsGeneratorProcessors: processors},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec.limits.global.ingestion.metricsGeneratorProcessors"),
+					processors,
+					"the processors of all tenants must be configured in spec.template.metricsGenerator.processors",
+				),
+			},
+		},
+		{
+			name: "per-tenant processors without metrics-generator",
+			limits: v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"tenant1": {Ingestion: v1alpha1.IngestionLimitSpec{MetricsGeneratorProcessors: processors}},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec.limits.perTenant[tenant1].ingestion.metricsGeneratorProcessors"),
+					processors,
+					"the metrics-generator must be enabled in spec.template.metricsGenerator",
+				),
+			},
+		},
+		{
+			name: "per-tenant processors without remote-write",
+			limits: v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"tenant1": {Ingestion: v1alpha1.IngestionLimitSpec{MetricsGeneratorProcessors: processors}},
+				},
+			},
+			metricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{Enabled: true},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec.limits.perTenant[tenant1].ingestion.metricsGeneratorProcessors"),
+					processors,
+					"at least one remote-write endpoint is required in spec.template.metricsGenerator.remoteWrite when the span-metrics processor is enabled",
+				),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: configv1alpha1.ProjectConfig{}}
+			tempo := v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					LimitSpec: tc.limits,
+					Template: v1alpha1.TempoTemplateSpec{
+						MetricsGenerator: tc.metricsGenerator,
+					},
+				},
+			}
+			assert.Equal(t, tc.expected, v.validateLimits(tempo))
+		})
+	}
+}
+
+func TestValidateStorageTuning(t *testing.T) {
+	path := field.NewPath("spec", "storage", "tuning")
+
+	tt := []struct {
+		name     string
+		input    *v1alpha1.StorageTuningSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "no tuning",
+		},
+		{
+			name: "valid tuning",
+			input: &v1alpha1.StorageTuningSpec{
+				BlocklistPoll: &metav1.Duration{Duration: 2 * time.Minute},
+				Block: &v1alpha1.BlockTuningSpec{
+					Version:                  v1alpha1.BlockVersionParquet4,
+					BloomFilterFalsePositive: "0.01",
+				},
+				Compaction: &v1alpha1.CompactionTuningSpec{Window: &metav1.Duration{Duration: time.Hour}},
+			},
+		},
+		{
+			name: "blocklist poll longer than complete block timeout",
+			input: &v1alpha1.StorageTuningSpec{
+				BlocklistPoll: &metav1.Duration{Duration: 20 * time.Minute},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("blocklistPoll"), "20m0s",
+					"must not be greater than the complete block timeout of the ingester (15m0s), otherwise recently flushed blocks are not searchable"),
+			},
+		},
+		{
+			name: "invalid block and compaction settings",
+			input: &v1alpha1.StorageTuningSpec{
+				Block: &v1alpha1.BlockTuningSpec{
+					BloomFilterFalsePositive: "1.5",
+					MaxDuration:              &metav1.Duration{},
+				},
+				Compaction: &v1alpha1.CompactionTuningSpec{Window: &metav1.Duration{Duration: 72 * time.Hour}},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("block", "bloomFilterFalsePositive"), "1.5", "must be a number greater than 0 and less than 1"),
+				field.Invalid(path.Child("block", "maxDuration"), "0s", "must be greater than 0"),
+				field.Invalid(path.Child("compaction", "window"), "72h0m0s", "must be shorter than the retention"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, validateStorageTuning(path, tc.input, 48*time.Hour))
+		})
+	}
+}
+
+func TestValidateDedicatedColumns(t *testing.T) {
+	path := field.NewPath("spec", "storage", "dedicatedColumns")
+	spanColumns := func(n int) []v1alpha1.DedicatedColumn {
+		columns := []v1alpha1.DedicatedColumn{}
+		for i := 0; i < n; i++ {
+			columns = append(columns, v1alpha1.DedicatedColumn{Scope: v1alpha1.DedicatedColumnScopeSpan, Name: fmt.Sprintf("attr%d", i)})
+		}
+		return columns
+	}
+
+	tt := []struct {
+		name     string
+		input    []v1alpha1.DedicatedColumn
+		expected field.ErrorList
+	}{
+		{
+			name: "no columns",
+		},
+		{
+			name: "same name in different scopes",
+			input: []v1alpha1.DedicatedColumn{
+				{Scope: v1alpha1.DedicatedColumnScopeResource, Name: "k8s.namespace.name"},
+				{Scope: v1alpha1.DedicatedColumnScopeSpan, Name: "k8s.namespace.name", Type: v1alpha1.DedicatedColumnTypeString},
+			},
+		},
+		{
+			name: "duplicate column",
+			input: []v1alpha1.DedicatedColumn{
+				{Scope: v1alpha1.DedicatedColumnScopeSpan, Name: "http.route"},
+				{Scope: v1alpha1.DedicatedColumnScopeSpan, Name: "http.route", Type: v1alpha1.DedicatedColumnTypeString},
+			},
+			expected: field.ErrorList{
+				field.Duplicate(path.Index(1), "http.route"),
+			},
+		},
+		{
+			name:  "maximum number of columns",
+			input: append(spanColumns(10), v1alpha1.DedicatedColumn{Scope: v1alpha1.DedicatedColumnScopeResource, Name: "attr10"}),
+		},
+		{
+			name:  "too many columns",
+			input: spanColumns(12),
+			expected: field.ErrorList{
+				field.Invalid(path.Index(10), "attr10", "at most 10 string columns are supported for the span scope"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, validateDedicatedColumns(path, tc.input))
+		})
+	}
+}
+
+func TestValidateUpgradePolicy(t *testing.T) {
+	path := field.NewPath("spec", "upgradePolicy")
+
+	tt := []struct {
+		name     string
+		input    *v1alpha1.UpgradePolicySpec
+		expected field.ErrorList
+	}{
+		{
+			name: "no upgrade policy",
+		},
+		{
+			name:  "manual upgrade policy",
+			input: &v1alpha1.UpgradePolicySpec{Mode: v1alpha1.UpgradePolicyModeManual},
+		},
+		{
+			name: "valid maintenance window",
+			input: &v1alpha1.UpgradePolicySpec{
+				Mode: v1alpha1.UpgradePolicyModeWindow,
+				MaintenanceWindow: &v1alpha1.MaintenanceWindowSpec{
+					Schedule: "0 2 * * 6",
+					Duration: &metav1.Duration{Duration: 2 * time.Hour},
+					TimeZone: "Europe/Berlin",
+				},
+			},
+		},
+		{
+			name:  "missing maintenance window",
+			input: &v1alpha1.UpgradePolicySpec{Mode: v1alpha1.UpgradePolicyModeWindow},
+			expected: field.ErrorList{
+				field.Required(path.Child("maintenanceWindow"), "the window upgrade policy requires a maintenance window"),
+			},
+		},
+		{
+			name: "invalid maintenance window",
+			input: &v1alpha1.UpgradePolicySpec{
+				Mode: v1alpha1.UpgradePolicyModeWindow,
+				MaintenanceWindow: &v1alpha1.MaintenanceWindowSpec{
+					Schedule: "0 25 * * *",
+					Duration: &metav1.Duration{Duration: 0},
+					TimeZone: "Mars/Olympus_Mons",
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("maintenanceWindow", "schedule"), "0 25 * * *", "invalid cron schedule: end of range (25) above maximum (23): 25"),
+				field.Invalid(path.Child("maintenanceWindow", "duration"), "0s", "must be greater than 0"),
+				field.Invalid(path.Child("maintenanceWindow", "timeZone"), "Mars/Olympus_Mons", "unknown time zone"),
+			},
+		},
+		{
+			name: "time zone in schedule",
+			input: &v1alpha1.UpgradePolicySpec{
+				Mode: v1alpha1.UpgradePolicyModeWindow,
+				MaintenanceWindow: &v1alpha1.MaintenanceWindowSpec{
+					Schedule: "CRON_TZ=Europe/Berlin 0 2 * * 6",
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("maintenanceWindow", "schedule"), "CRON_TZ=Europe/Berlin 0 2 * * 6",
+					"time zones are not supported in the schedule, use the timeZone field"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, validateUpgradePolicy(path, tc.input))
+		})
+	}
+}
+
+func TestValidateTempoVersion(t *testing.T) {
+	path := field.NewPath("spec", "tempoVersion")
+	ctrlConfig := configv1alpha1.ProjectConfig{
+		DefaultImages: configv1alpha1.ImagesSpec{
+			Tempo:      "docker.io/grafana/tempo:2.8.1",
+			TempoQuery: "docker.io/grafana/tempo-query@sha256:4f2a8e1c3b5d7f9a0c2e4b6d8f1a3c5e7b9d0f2a4c6e8b1d3f5a7c9e0b2d4f6a",
+		},
+	}
+	costAttribution := v1alpha1.LimitSpec{
+		Global: v1alpha1.RateLimitSpec{
+			Ingestion: v1alpha1.IngestionLimitSpec{
+				CostAttributionDimensions: map[string]string{"service.name": "service"},
+			},
+		},
+		PerTenant: map[string]v1alpha1.RateLimitSpec{
+			"tenant-a": {
+				Ingestion: v1alpha1.IngestionLimitSpec{
+					CostAttributionDimensions: map[string]string{"service.name": "service"},
+				},
+			},
+		},
+	}
+
+	tt := []struct {
+		name     string
+		input    v1alpha1.TempoStackSpec
+		tenants  []v1alpha1.TempoTenant
+		expected field.ErrorList
+	}{
+		{
+			name:  "default Tempo version",
+			input: v1alpha1.TempoStackSpec{Ingest: &v1alpha1.IngestSpec{Kafka: &v1alpha1.KafkaIngestSpec{}}, LimitSpec: costAttribution},
+		},
+		{
+			name: "supported Tempo version",
+			input: v1alpha1.TempoStackSpec{
+				TempoVersion: "2.8.0",
+				Images:       configv1alpha1.ImagesSpec{TempoQuery: "docker.io/grafana/tempo-query:2.8.0"},
+			},
+		},
+		{
+			name:  "unsupported Tempo version",
+			input: v1alpha1.TempoStackSpec{TempoVersion: "2.5.0"},
+			expected: field.ErrorList{
+				field.Invalid(path, "2.5.0", "unsupported Tempo version 2.5.0, the supported versions of this operator version are 2.6.x, 2.7.x, 2.8.x"),
+			},
+		},
+		{
+			name:  "default image pinned by digest",
+			input: v1alpha1.TempoStackSpec{TempoVersion: "2.8.0"},
+			expected: field.ErrorList{
+				field.Invalid(path, "2.8.0", "cannot change the tag of the image "+ctrlConfig.DefaultImages.TempoQuery+", the image is pinned by digest, set the image in the CR"),
+			},
+		},
+		{
+			name: "features not supported by the Tempo version",
+			input: v1alpha1.TempoStackSpec{
+				TempoVersion: "2.7.2",
+				Images:       configv1alpha1.ImagesSpec{TempoQuery: "docker.io/grafana/tempo-query:2.7.2"},
+				Ingest:       &v1alpha1.IngestSpec{Kafka: &v1alpha1.KafkaIngestSpec{}},
+				LimitSpec:    costAttribution,
+			},
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "ingest", "kafka"), "the Kafka ingest path is not supported by Tempo version 2.7.2"),
+				field.Forbidden(field.NewPath("spec", "limits", "global", "ingestion", "costAttributionDimensions"), "cost attribution is not supported by Tempo version 2.7.2"),
+				field.Forbidden(field.NewPath("spec", "limits", "perTenant").Key("tenant-a").Child("ingestion", "costAttributionDimensions"), "cost attribution is not supported by Tempo version 2.7.2"),
+			},
+		},
+		{
+			name: "Tempo image of the pinned Tempo version",
+			input: v1alpha1.TempoStackSpec{
+				TempoVersion: "2.7.2",
+				Images: configv1alpha1.ImagesSpec{
+					Tempo:      "registry.example.com/grafana/tempo:2.7.1",
+					TempoQuery: "docker.io/grafana/tempo-query:2.7.2",
+				},
+			},
+		},
+		{
+			name: "Tempo image with a custom tag",
+			input: v1alpha1.TempoStackSpec{
+				Images: configv1alpha1.ImagesSpec{Tempo: "registry.example.com/grafana/tempo:custom"},
+			},
+		},
+		{
+			name: "Tempo image of a different Tempo version",
+			input: v1alpha1.TempoStackSpec{
+				TempoVersion: "2.7.2",
+				Images: configv1alpha1.ImagesSpec{
+					Tempo:      "docker.io/grafana/tempo:2.8.1",
+					TempoQuery: "docker.io/grafana/tempo-query:2.7.2",
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "images", "tempo"), "docker.io/grafana/tempo:2.8.1",
+					"the image runs Tempo 2.8.1, but the configuration is rendered for Tempo 2.7.x, set spec.tempoVersion to the Tempo version of the image"),
+			},
+		},
+		{
+			name: "Tempo image of a different Tempo version without a pinned Tempo version",
+			input: v1alpha1.TempoStackSpec{
+				Images: configv1alpha1.ImagesSpec{Tempo: "docker.io/grafana/tempo:2.6.3"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "images", "tempo"), "docker.io/grafana/tempo:2.6.3",
+					"the image runs Tempo 2.6.3, but the configuration is rendered for Tempo 2.8.x, set spec.tempoVersion to the Tempo version of the image"),
+			},
+		},
+		{
+			name: "Tempo image of an unsupported Tempo version",
+			input: v1alpha1.TempoStackSpec{
+				Images: configv1alpha1.ImagesSpec{Tempo: "docker.io/grafana/tempo:2.5.0"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "images", "tempo"), "docker.io/grafana/tempo:2.5.0",
+					"unsupported Tempo version 2.5.0, the supported versions of this operator version are 2.6.x, 2.7.x, 2.8.x"),
+			},
+		},
+		{
+			name: "TempoTenant limits not supported by the Tempo version",
+			input: v1alpha1.TempoStackSpec{
+				TempoVersion: "2.7.2",
+				Images:       configv1alpha1.ImagesSpec{TempoQuery: "docker.io/grafana/tempo-query:2.7.2"},
+			},
+			tenants: []v1alpha1.TempoTenant{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "team-b"},
+					Spec: v1alpha1.TempoTenantSpec{
+						TempoStack: "simplest",
+						TenantName: "tenant-b",
+						Limits:     &costAttribution.Global,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "team-c"},
+					Spec: v1alpha1.TempoTenantSpec{
+						TempoStack: "simplest",
+						TenantName: "tenant-c",
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Forbidden(path, "TempoTenant team-b: cost attribution is not supported by Tempo version 2.7.2"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: ctrlConfig, client: &k8sFake{tempoTenants: tc.tenants}}
+			tempo := v1alpha1.TempoStack{ObjectMeta: metav1.ObjectMeta{Name: "simplest"}, Spec: tc.input}
+			assert.Equal(t, tc.expected, v.validateTempoVersion(context.Background(), tempo))
+		})
+	}
+}
+
+func TestValidateReceiverTLSAndGateway(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    v1alpha1.TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name: "valid configuration disable both",
+			input: v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: v1alpha1.TempoTemplateSpec{
+						Gateway: v1alpha1.TempoGatewaySpec{
+							Enabled: false,
+						},
+						Distributor: v1alpha1.TempoDistributorSpec{
+							TLS: v1alpha1.TLSSpec{
+								Enabled: false,
+							},
+						},
+					},
+					Tenants: &v1alpha1.TenantsSpec{
+						Mode: v1alpha1.ModeStatic,
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "valid configuration enable only gateway",
+			input: v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: v1alpha1.TempoTemplateSpec{
+						Gateway: v1alpha1.TempoGatewaySpec{
+							Enabled: true,
+						},
+						QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+							JaegerQuery: v1alpha1.JaegerQuerySpec{
+								Enabled: true,
+							},
+						},
+						Distributor: v1alpha1.TempoDistributorSpec{
+							TLS: v1alpha1.TLSSpec{
+								Enabled: false,
+							},
+						},
+					},
+					Tenants: &v1alpha1.TenantsSpec{
+						Mode: v1alpha1.ModeStatic,
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "valid configuration enable only receiver TLS",
+			input: v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: v1alpha1.TempoTemplateSpec{
+						Gateway: v1alpha1.TempoGatewaySpec{
+							Enabled: false,
+						},
+						Distributor: v1alpha1.TempoDistributorSpec{
+							TLS: v1alpha1.TLSSpec{
+								Enabled: true,
+								Cert:    "my-cert",
 							},
 						},
 					},
@@ -2287,12 +3036,23 @@ func TestWarning(t *testing.T) {
 type k8sFake struct {
 	secret              *corev1.Secret
 	configmap           *corev1.ConfigMap
+	pvc                 *corev1.PersistentVolumeClaim
 	tempoStack          *v1alpha1.TempoStack
 	tempoMonolithic     *v1alpha1.TempoMonolithic
+	tempoTenants        []v1alpha1.TempoTenant
 	subjectAccessReview *authorizationv1.SubjectAccessReview
 	client.Client
 }
 
+func (k *k8sFake) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	switch typed := list.(type) {
+	case *v1alpha1.TempoTenantList:
+		typed.Items = append([]v1alpha1.TempoTenant{}, k.tempoTenants...)
+		return nil
+	}
+	return fmt.Errorf("mock: fails always")
+}
+
 func (k *k8sFake) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
 	switch typed := obj.(type) {
 	case *authorizationv1.SubjectAccessReview:
@@ -2316,6 +3076,11 @@ func (k *k8sFake) Get(ctx context.Context, key client.ObjectKey, obj client.Obje
 			k.configmap.DeepCopyInto(typed)
 			return nil
 		}
+	case *corev1.PersistentVolumeClaim:
+		if k.pvc != nil {
+			k.pvc.DeepCopyInto(typed)
+			return nil
+		}
 	case *v1alpha1.TempoStack:
 		if k.tempoStack != nil {
 			k.tempoStack.DeepCopyInto(typed)
@@ -2383,3 +3148,448 @@ func TestConflictMonolithicValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMetricsGenerator(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    v1alpha1.TempoMetricsGeneratorSpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "disabled",
+			input: v1alpha1.TempoMetricsGeneratorSpec{},
+		},
+		{
+			name: "local-blocks without remote-write",
+			input: v1alpha1.TempoMetricsGeneratorSpec{
+				Enabled:    true,
+				Processors: []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorLocalBlocks},
+			},
+		},
+		{
+			name: "span-metrics without remote-write",
+			input: v1alpha1.TempoMetricsGeneratorSpec{
+				Enabled:    true,
+				Processors: []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorSpanMetrics},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec", "template", "metricsGenerator", "remoteWrite"),
+					[]v1alpha1.MetricsGeneratorRemoteWriteSpec(nil),
+					"at least one remote-write endpoint is required when the span-metrics processor is enabled",
+				),
+			},
+		},
+		{
+			name: "invalid remote-write URL",
+			input: v1alpha1.TempoMetricsGeneratorSpec{
+				Enabled:    true,
+				Processors: []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorServiceGraphs},
+				RemoteWrite: []v1alpha1.MetricsGeneratorRemoteWriteSpec{
+					{URL: "prometheus:9090"},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec", "template", "metricsGenerator", "remoteWrite").Index(0).Child("url"),
+					"prometheus:9090",
+					"must be an absolute URL, e.g. http://prometheus:9090/api/v1/write",
+				),
+			},
+		},
+		{
+			name: "valid",
+			input: v1alpha1.TempoMetricsGeneratorSpec{
+				Enabled:    true,
+				Processors: []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorServiceGraphs},
+				RemoteWrite: []v1alpha1.MetricsGeneratorRemoteWriteSpec{
+					{URL: "http://prometheus:9090/api/v1/write"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: configv1alpha1.ProjectConfig{}}
+			tempo := v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Template: v1alpha1.TempoTemplateSpec{
+						MetricsGenerator: tc.input,
+					},
+				},
+			}
+			assert.Equal(t, tc.expected, v.validateMetricsGenerator(tempo))
+		})
+	}
+}
+
+func TestValidateCache(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    *v1alpha1.CacheSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "managed memcached",
+			input: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Memcached: &v1alpha1.MemcachedSpec{
+					Managed: v1alpha1.ManagedMemcachedSpec{Enabled: true},
+				},
+			},
+		},
+		{
+			name: "external memcached",
+			input: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Memcached: &v1alpha1.MemcachedSpec{
+					Host: "memcached.cache.svc.cluster.local",
+				},
+			},
+		},
+		{
+			name: "memcached not configured",
+			input: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+			},
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "cache", "memcached"), "memcached must be configured when the memcached backend is used"),
+			},
+		},
+		{
+			name: "memcached without host",
+			input: &v1alpha1.CacheSpec{
+				Backend:   v1alpha1.CacheBackendMemcached,
+				Memcached: &v1alpha1.MemcachedSpec{},
+			},
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "cache", "memcached", "host"), "either host must be set or the managed memcached must be enabled"),
+			},
+		},
+		{
+			name: "managed memcached with host",
+			input: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Memcached: &v1alpha1.MemcachedSpec{
+					Host:    "memcached",
+					Managed: v1alpha1.ManagedMemcachedSpec{Enabled: true},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "cache", "memcached", "host"), "memcached", "host must not be set when the managed memcached is enabled"),
+			},
+		},
+		{
+			name: "redis",
+			input: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendRedis,
+				Redis:   &v1alpha1.RedisSpec{Endpoint: "redis:6379"},
+			},
+		},
+		{
+			name: "redis without endpoint",
+			input: &v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendRedis,
+			},
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "cache", "redis", "endpoint"), "redis endpoint must be configured when the redis backend is used"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: configv1alpha1.ProjectConfig{}}
+			tempo := v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Cache: tc.input,
+				},
+			}
+			assert.Equal(t, tc.expected, v.validateCache(tempo))
+		})
+	}
+}
+
+func TestValidateStorageMigration(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    v1alpha1.ObjectStorageSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "disabled",
+			input: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{Name: "s3", Type: v1alpha1.ObjectStorageSecretS3},
+			},
+		},
+		{
+			name: "migration",
+			input: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{Name: "s3", Type: v1alpha1.ObjectStorageSecretS3},
+				Migration: &v1alpha1.StorageMigrationSpec{
+					Source: v1alpha1.ObjectStorageSecretSpec{Name: "minio", Type: v1alpha1.ObjectStorageSecretS3},
+				},
+			},
+		},
+		{
+			name: "same secret",
+			input: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{Name: "s3", Type: v1alpha1.ObjectStorageSecretS3},
+				Migration: &v1alpha1.StorageMigrationSpec{
+					Source: v1alpha1.ObjectStorageSecretSpec{Name: "s3", Type: v1alpha1.ObjectStorageSecretS3},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "storage", "migration", "source", "name"), "s3",
+					"the secret of the previous object storage must be different from the storage secret"),
+			},
+		},
+		{
+			name: "source secret not set",
+			input: v1alpha1.ObjectStorageSpec{
+				Secret:    v1alpha1.ObjectStorageSecretSpec{Name: "s3", Type: v1alpha1.ObjectStorageSecretS3},
+				Migration: &v1alpha1.StorageMigrationSpec{},
+			},
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "storage", "migration", "source", "name"), "the secret of the previous object storage must be set"),
+			},
+		},
+		{
+			name: "filesystem storage",
+			input: v1alpha1.ObjectStorageSpec{
+				Filesystem: &v1alpha1.FilesystemStorageSpec{ClaimName: "traces"},
+				Migration: &v1alpha1.StorageMigrationSpec{
+					Source: v1alpha1.ObjectStorageSecretSpec{Name: "minio", Type: v1alpha1.ObjectStorageSecretS3},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "storage", "migration"), "", "storage migration is not supported for filesystem storage"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: configv1alpha1.ProjectConfig{}}
+			tempo := v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Storage: tc.input,
+				},
+			}
+			assert.Equal(t, tc.expected, v.validateStorageMigration(tempo))
+		})
+	}
+}
+
+func TestValidateAutoscaling(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    v1alpha1.TempoTemplateSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "disabled",
+			input: v1alpha1.TempoTemplateSpec{
+				QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+					Autoscaling: &v1alpha1.AutoscalingSpec{MaxReplicas: 3},
+				},
+			},
+		},
+		{
+			name: "valid",
+			input: v1alpha1.TempoTemplateSpec{
+				Querier: v1alpha1.TempoQuerierSpec{
+					Autoscaling: &v1alpha1.AutoscalingSpec{
+						Enabled:              true,
+						MinReplicas:          ptr.To(int32(2)),
+						MaxReplicas:          10,
+						TargetCPUUtilization: ptr.To(int32(70)),
+					},
+				},
+			},
+		},
+		{
+			name: "minReplicas greater than maxReplicas and no target",
+			input: v1alpha1.TempoTemplateSpec{
+				Distributor: v1alpha1.TempoDistributorSpec{
+					Autoscaling: &v1alpha1.AutoscalingSpec{
+						Enabled:     true,
+						MinReplicas: ptr.To(int32(5)),
+						MaxReplicas: 3,
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "template", "distributor", "autoscaling", "minReplicas"), int32(5),
+					"minReplicas must not be greater than maxReplicas"),
+				field.Required(field.NewPath("spec", "template", "distributor", "autoscaling"),
+					"at least one of targetCPUUtilization, targetMemoryUtilization or metrics must be set"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: configv1alpha1.ProjectConfig{}}
+			tempo := v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Template: tc.input,
+				},
+			}
+			assert.Equal(t, tc.expected, v.validateAutoscaling(tempo))
+		})
+	}
+}
+
+func TestValidatePodDisruptionBudgets(t *testing.T) {
+	v := &validator{ctrlConfig: configv1alpha1.ProjectConfig{}}
+
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoComponentSpec{
+					PodDisruptionBudget: &v1alpha1.PodDisruptionBudgetSpec{
+						MaxUnavailable: ptr.To(intstr.FromInt(1)),
+					},
+				},
+			},
+		},
+	}
+	assert.Empty(t, v.validatePodDisruptionBudgets(tempo))
+
+	pdb := &v1alpha1.PodDisruptionBudgetSpec{
+		MinAvailable:   ptr.To(intstr.FromInt(1)),
+		MaxUnavailable: ptr.To(intstr.FromInt(1)),
+	}
+	tempo.Spec.Template.Ingester.PodDisruptionBudget = pdb
+	assert.Equal(t, field.ErrorList{
+		field.Invalid(field.NewPath("spec", "template", "ingester", "podDisruptionBudget"), pdb,
+			"minAvailable and maxUnavailable cannot be configured at the same time"),
+	}, v.validatePodDisruptionBudgets(tempo))
+}
+
+func TestValidateZoneAwareness(t *testing.T) {
+	v := &validator{ctrlConfig: configv1alpha1.ProjectConfig{}}
+
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			ReplicationFactor: 3,
+			ZoneAwareness:     &v1alpha1.ZoneAwarenessSpec{Enabled: true},
+		},
+	}
+	assert.Empty(t, v.validateZoneAwareness(tempo))
+
+	tempo.Spec.ReplicationFactor = 1
+	assert.Equal(t, field.ErrorList{
+		field.Invalid(field.NewPath("spec", "zoneAwareness", "enabled"), true,
+			"zone-aware replication requires a replication factor of at least 2"),
+	}, v.validateZoneAwareness(tempo))
+
+	tempo.Spec.ReplicationFactor = 3
+	tempo.Spec.ZoneAwareness.Zones = []v1alpha1.ZoneSpec{{Name: "zone-a"}, {Name: "Zone_B"}}
+	assert.Equal(t, field.ErrorList{
+		field.Invalid(field.NewPath("spec", "zoneAwareness", "zones"), 2,
+			"the number of zones must be greater than or equal to the replication factor"),
+		field.Invalid(field.NewPath("spec", "zoneAwareness", "zones").Index(1).Child("name"), "Zone_B",
+			"a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+	}, v.validateZoneAwareness(tempo))
+}
+
+func TestValidateIngest(t *testing.T) {
+	v := &validator{ctrlConfig: configv1alpha1.ProjectConfig{}}
+
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			ReplicationFactor: 3,
+			Ingest: &v1alpha1.IngestSpec{
+				Kafka: &v1alpha1.KafkaIngestSpec{
+					Brokers: []string{"kafka:9092"},
+					Topic:   "tempo-ingest",
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester:     v1alpha1.TempoComponentSpec{Replicas: ptr.To(int32(3))},
+				BlockBuilder: v1alpha1.TempoComponentSpec{Replicas: ptr.To(int32(2))},
+			},
+		},
+	}
+	assert.Empty(t, v.validateIngest(tempo))
+
+	tempo.Spec.ZoneAwareness = &v1alpha1.ZoneAwarenessSpec{
+		Enabled: true,
+		Zones:   []v1alpha1.ZoneSpec{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+	}
+	tempo.Spec.Template.BlockBuilder.Replicas = ptr.To(int32(4))
+	tempo.Spec.Ingest.Kafka.TLS.Cert = "kafka-cert"
+	assert.Equal(t, field.ErrorList{
+		field.Forbidden(field.NewPath("spec", "ingest", "kafka"),
+			"the Kafka ingest path cannot be combined with per-zone ingester StatefulSets (spec.zoneAwareness.zones)"),
+		field.Invalid(field.NewPath("spec", "template", "blockBuilder", "replicas"), int32(4),
+			"the number of block-builders must not be greater than the number of ingesters (Kafka partitions)"),
+		field.Invalid(field.NewPath("spec", "ingest", "kafka", "tls", "enabled"), false,
+			"TLS must be enabled to use a client certificate"),
+	}, v.validateIngest(tempo))
+}
+
+func TestValidateGatewayAPIGRPCRoute(t *testing.T) {
+	path := field.NewPath("spec", "template", "gateway", "ingress")
+	tests := []struct {
+		name       string
+		host       string
+		gatewayAPI v1alpha1.GatewayAPISpec
+		expected   field.ErrorList
+	}{
+		{
+			name: "different hostnames",
+			host: "tempo.example.com",
+			gatewayAPI: v1alpha1.GatewayAPISpec{
+				ParentRef: v1alpha1.GatewayParentReference{Name: "public"},
+				GRPCHost:  "otlp.tempo.example.com",
+			},
+		},
+		{
+			name: "different listeners",
+			host: "tempo.example.com",
+			gatewayAPI: v1alpha1.GatewayAPISpec{
+				ParentRef:       v1alpha1.GatewayParentReference{Name: "public", SectionName: "https"},
+				GRPCHost:        "tempo.example.com",
+				GRPCSectionName: "grpc",
+			},
+		},
+		{
+			name: "same hostname on the same listener",
+			host: "tempo.example.com",
+			gatewayAPI: v1alpha1.GatewayAPISpec{
+				ParentRef:       v1alpha1.GatewayParentReference{Name: "public", SectionName: "https"},
+				GRPCHost:        "tempo.example.com",
+				GRPCSectionName: "https",
+			},
+			expected: field.ErrorList{field.Invalid(path.Child("gatewayAPI", "grpcHost"), "tempo.example.com",
+				"the GRPCRoute and the HTTPRoute must not share a hostname on the same listener, please configure a different grpcHost or grpcSectionName")},
+		},
+		{
+			name: "GRPCRoute without hostname on the same listener",
+			host: "tempo.example.com",
+			gatewayAPI: v1alpha1.GatewayAPISpec{
+				ParentRef: v1alpha1.GatewayParentReference{Name: "public"},
+			},
+			expected: field.ErrorList{field.Invalid(path.Child("gatewayAPI", "grpcHost"), "",
+				"the GRPCRoute and the HTTPRoute must not share a hostname on the same listener, please configure a different grpcHost or grpcSectionName")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ingress := v1alpha1.IngressSpec{
+				Type:       v1alpha1.IngressTypeGatewayAPI,
+				Host:       test.host,
+				GatewayAPI: &test.gatewayAPI,
+			}
+			assert.Equal(t, test.expected, validateGatewayAPIGRPCRoute(path, ingress))
+		})
+	}
+}
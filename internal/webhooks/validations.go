@@ -3,9 +3,18 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/grafana/tempo-operator/api/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/gateway"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/version"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/robfig/cron/v3"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -17,6 +26,12 @@ import (
 
 const maxLabelLength = 63
 
+// ingesterCompleteBlockTimeout is the default time an ingester keeps a flushed block, Tempo's default is used.
+const ingesterCompleteBlockTimeout = 15 * time.Minute
+
+// maxDedicatedColumnsPerScope is the maximum number of dedicated columns per scope and type of the parquet block format.
+const maxDedicatedColumnsPerScope = 10
+
 func validateName(name string) field.ErrorList {
 	// We need to check this because the name is used as a label value for app.kubernetes.io/instance
 	// Only validate the length, because the DNS rules are enforced by the functions in the `naming` package.
@@ -51,6 +66,219 @@ func validateTempoNameConflict(getFn func() error, instanceName string, to strin
 	return allErrs
 }
 
+// validateMetricsGeneratorRemoteWrite checks that processors which produce metrics have a remote-write endpoint
+// to send them to, and that all remote-write endpoints are absolute URLs.
+func validateMetricsGeneratorRemoteWrite(base *field.Path, processors []v1alpha1.MetricsGeneratorProcessor, remoteWrite []v1alpha1.MetricsGeneratorRemoteWriteSpec) field.ErrorList {
+	if len(remoteWrite) == 0 {
+		for _, processor := range processors {
+			if processor == v1alpha1.MetricsGeneratorProcessorServiceGraphs || processor == v1alpha1.MetricsGeneratorProcessorSpanMetrics {
+				return field.ErrorList{field.Invalid(
+					base.Child("remoteWrite"),
+					remoteWrite,
+					fmt.Sprintf("at least one remote-write endpoint is required when the %s processor is enabled", processor),
+				)}
+			}
+		}
+	}
+
+	for i, rw := range remoteWrite {
+		u, err := url.Parse(rw.URL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return field.ErrorList{field.Invalid(
+				base.Child("remoteWrite").Index(i).Child("url"),
+				rw.URL,
+				"must be an absolute URL, e.g. http://prometheus:9090/api/v1/write",
+			)}
+		}
+	}
+
+	return nil
+}
+
+// validateStorageTuning rejects storage tuning values which are invalid or known to cause issues.
+// The retention is only checked if it is greater than zero.
+func validateStorageTuning(base *field.Path, spec *v1alpha1.StorageTuningSpec, retention time.Duration) field.ErrorList {
+	if spec == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+
+	if spec.BlocklistPoll != nil {
+		poll := spec.BlocklistPoll.Duration
+		path := base.Child("blocklistPoll")
+		switch {
+		case poll <= 0:
+			allErrs = append(allErrs, field.Invalid(path, poll.String(), "must be greater than 0"))
+		case poll > ingesterCompleteBlockTimeout:
+			allErrs = append(allErrs, field.Invalid(path, poll.String(),
+				fmt.Sprintf("must not be greater than the complete block timeout of the ingester (%s), otherwise recently flushed blocks are not searchable", ingesterCompleteBlockTimeout)))
+		case retention > 0 && poll >= retention:
+			allErrs = append(allErrs, field.Invalid(path, poll.String(), "must be shorter than the retention"))
+		}
+	}
+
+	if block := spec.Block; block != nil {
+		path := base.Child("block")
+		if block.BloomFilterFalsePositive != "" {
+			falsePositive, err := strconv.ParseFloat(block.BloomFilterFalsePositive, 64)
+			if err != nil || falsePositive <= 0 || falsePositive >= 1 {
+				allErrs = append(allErrs, field.Invalid(path.Child("bloomFilterFalsePositive"), block.BloomFilterFalsePositive,
+					"must be a number greater than 0 and less than 1"))
+			}
+		}
+		if block.MaxDuration != nil && block.MaxDuration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxDuration"), block.MaxDuration.Duration.String(), "must be greater than 0"))
+		}
+
+	}
+
+	if compaction := spec.Compaction; compaction != nil && compaction.Window != nil {
+		window := compaction.Window.Duration
+		path := base.Child("compaction").Child("window")
+		switch {
+		case window <= 0:
+			allErrs = append(allErrs, field.Invalid(path, window.String(), "must be greater than 0"))
+		case retention > 0 && window >= retention:
+			allErrs = append(allErrs, field.Invalid(path, window.String(), "must be shorter than the retention"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateDedicatedColumns rejects duplicate dedicated attribute columns and checks the
+// number of columns per scope and type which are supported by the parquet block format.
+func validateDedicatedColumns(base *field.Path, columns []v1alpha1.DedicatedColumn) field.ErrorList {
+	var allErrs field.ErrorList
+	names := map[v1alpha1.DedicatedColumnScope]map[string]bool{}
+	counts := map[v1alpha1.DedicatedColumnScope]map[v1alpha1.DedicatedColumnType]int{}
+
+	for i, column := range columns {
+		columnType := column.Type
+		if columnType == "" {
+			columnType = v1alpha1.DedicatedColumnTypeString
+		}
+		if names[column.Scope] == nil {
+			names[column.Scope] = map[string]bool{}
+			counts[column.Scope] = map[v1alpha1.DedicatedColumnType]int{}
+		}
+
+		if names[column.Scope][column.Name] {
+			allErrs = append(allErrs, field.Duplicate(base.Index(i), column.Name))
+			continue
+		}
+		names[column.Scope][column.Name] = true
+
+		counts[column.Scope][columnType]++
+		if counts[column.Scope][columnType] == maxDedicatedColumnsPerScope+1 {
+			allErrs = append(allErrs, field.Invalid(base.Index(i), column.Name,
+				fmt.Sprintf("at most %d %s columns are supported for the %s scope", maxDedicatedColumnsPerScope, columnType, column.Scope)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateUpgradePolicy checks the maintenance window of the upgrade policy.
+func validateUpgradePolicy(base *field.Path, policy *v1alpha1.UpgradePolicySpec) field.ErrorList {
+	if policy == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+
+	window := policy.MaintenanceWindow
+	if window == nil {
+		if policy.Mode == v1alpha1.UpgradePolicyModeWindow {
+			allErrs = append(allErrs, field.Required(base.Child("maintenanceWindow"), "the window upgrade policy requires a maintenance window"))
+		}
+		return allErrs
+	}
+
+	path := base.Child("maintenanceWindow")
+	if strings.Contains(window.Schedule, "TZ") {
+		allErrs = append(allErrs, field.Invalid(path.Child("schedule"), window.Schedule,
+			"time zones are not supported in the schedule, use the timeZone field"))
+	} else if _, err := cron.ParseStandard(window.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("schedule"), window.Schedule, fmt.Sprintf("invalid cron schedule: %s", err)))
+	}
+
+	if window.Duration != nil && window.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("duration"), window.Duration.Duration.String(), "must be greater than 0"))
+	}
+
+	if window.TimeZone != "" {
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), window.TimeZone, "unknown time zone"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateTempoVersion checks that the pinned Tempo version is supported by the operator,
+// and that the images of this version can be derived from the default images.
+func validateTempoVersion(path *field.Path, tempoVersion string, defaultImages ...string) (version.TempoFeatures, field.ErrorList) {
+	features, err := version.TempoFeaturesFor(tempoVersion)
+	if err != nil {
+		return features, field.ErrorList{field.Invalid(path, tempoVersion, err.Error())}
+	}
+	if tempoVersion == "" {
+		return features, nil
+	}
+
+	var allErrs field.ErrorList
+	for _, image := range defaultImages {
+		if _, err := manifestutils.ImageWithTag(image, tempoVersion); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, tempoVersion, fmt.Sprintf("%s, set the image in the CR", err)))
+		}
+	}
+	return features, allErrs
+}
+
+// validateTempoImage checks that the Tempo image set in the CR runs the Tempo release the configuration is rendered for.
+// Images with a tag which is not a version, e.g. images pinned by digest, cannot be validated.
+func validateTempoImage(path *field.Path, image string, tempoVersion string) field.ErrorList {
+	tag := manifestutils.ImageTag(image)
+	if _, err := semver.StrictNewVersion(tag); err != nil {
+		return nil
+	}
+
+	imageRelease, err := version.TempoReleaseFor(tag)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, image, err.Error())}
+	}
+	release, err := version.TempoReleaseFor(tempoVersion)
+	if err != nil {
+		// the invalid Tempo version is reported by validateTempoVersion
+		return nil
+	}
+	if imageRelease.Version != release.Version {
+		return field.ErrorList{field.Invalid(path, image, fmt.Sprintf(
+			"the image runs Tempo %s, but the configuration is rendered for Tempo %s.x, set spec.tempoVersion to the Tempo version of the image",
+			tag, release.Version))}
+	}
+	return nil
+}
+
+// validateCostAttribution checks that cost attribution is supported by the Tempo version.
+func validateCostAttribution(base *field.Path, limits v1alpha1.LimitSpec, tempoVersion string, features version.TempoFeatures) field.ErrorList {
+	if features.CostAttribution {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	message := fmt.Sprintf("cost attribution is not supported by Tempo version %s", tempoVersion)
+	if len(limits.Global.Ingestion.CostAttributionDimensions) > 0 {
+		allErrs = append(allErrs, field.Forbidden(base.Child("global", "ingestion", "costAttributionDimensions"), message))
+	}
+	for _, tenant := range sortedKeys(limits.PerTenant) {
+		if len(limits.PerTenant[tenant].Ingestion.CostAttributionDimensions) > 0 {
+			allErrs = append(allErrs, field.Forbidden(base.Child("perTenant").Key(tenant).Child("ingestion", "costAttributionDimensions"), message))
+		}
+	}
+	return allErrs
+}
+
 func subjectAccessReviewsForClusterRole(user authenticationv1.UserInfo, clusterRole rbacv1.ClusterRole) []authorizationv1.SubjectAccessReview {
 	reviews := []authorizationv1.SubjectAccessReview{}
 	for _, rule := range clusterRole.Rules {